@@ -0,0 +1,39 @@
+package GoCache
+
+import "sync/atomic"
+
+// GetItem ... Get the live Item at k with its Data, Expiration, CreatedAt
+// and LastAccessedAt, without counting as an access
+func (c *Cache) GetItem(k string) (Item, bool) {
+	k = c.key(k)
+	c = c.rLockFor(k)
+	defer c.mutex.RUnlock()
+	item, found := c.items[k]
+	if !found || c.expired(item) {
+		return Item{}, false
+	}
+	return c.view(item), true
+}
+
+// view ... Return item as handed out of the Cache, with its Data read
+// back from disk or opened and its LastAccessedAt filled in
+func (c *Cache) view(item Item) Item {
+	item.Object = c.open(item.Object)
+	if item.accessed != nil {
+		item.LastAccessedAt = item.accessed.Load()
+		item.accessed = nil
+	}
+	return item
+}
+
+// newAccessed ... Make the live LastAccessedAt of an Item being stored,
+// starting from the one it was loaded with, or its CreatedAt
+func newAccessed(item Item) *atomic.Int64 {
+	a := new(atomic.Int64)
+	if item.LastAccessedAt > 0 {
+		a.Store(item.LastAccessedAt)
+	} else {
+		a.Store(item.CreatedAt)
+	}
+	return a
+}
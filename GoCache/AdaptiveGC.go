@@ -0,0 +1,86 @@
+package GoCache
+
+import "time"
+
+// A sweep where at least this share of the scanned Data had Expired
+// counts as heavy churn and halves the GC interval
+const adaptiveGcHeavyRatio = 0.25
+
+// EnableAdaptiveGC ... Let the GC interval float between min and max
+// It shrinks while sweeps find lots of Expired Data and grows back
+// while sweeps find nothing, pass zeros to go back to a fixed interval
+func (c *Cache) EnableAdaptiveGC(min, max time.Duration) {
+	if min > max {
+		min, max = max, min
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.gcMinInterval = min
+	c.gcMaxInterval = max
+}
+
+// GcInterval ... Return the interval the GC currently sweeps at
+func (c *Cache) GcInterval() time.Duration {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.gcInterval
+}
+
+// nextGcInterval ... Pick the interval after a sweep that removed
+// removed of scanned Data
+func (c *Cache) nextGcInterval(cur time.Duration, removed, scanned int) time.Duration {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.gcMinInterval <= 0 || c.gcMaxInterval <= 0 {
+		return cur
+	}
+	c.gcInterval = adaptGcInterval(cur, c.gcMinInterval, c.gcMaxInterval, removed, scanned)
+	return c.gcInterval
+}
+
+// EnableAdaptiveGC ... Let the GC interval float between min and max,
+// by what the sweeps of all shards together find
+func (sc *ShardedCache) EnableAdaptiveGC(min, max time.Duration) {
+	if min > max {
+		min, max = max, min
+	}
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	sc.gcMin, sc.gcMax = min, max
+}
+
+// GcInterval ... Return the interval the GC currently sweeps all shards at
+func (sc *ShardedCache) GcInterval() time.Duration {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	return sc.gcInterval
+}
+
+func (sc *ShardedCache) nextGcInterval(cur time.Duration, removed, scanned int) time.Duration {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	if sc.gcMin <= 0 || sc.gcMax <= 0 {
+		return cur
+	}
+	sc.gcInterval = adaptGcInterval(cur, sc.gcMin, sc.gcMax, removed, scanned)
+	return sc.gcInterval
+}
+
+// adaptGcInterval ... Halve cur after a sweep of heavy churn, double it
+// after one that removed nothing, within [min, max]
+func adaptGcInterval(cur, min, max time.Duration, removed, scanned int) time.Duration {
+	next := cur
+	switch {
+	case removed == 0:
+		next = cur * 2
+	case float64(removed) >= adaptiveGcHeavyRatio*float64(scanned):
+		next = cur / 2
+	}
+	if next < min {
+		next = min
+	}
+	if next > max {
+		next = max
+	}
+	return next
+}
@@ -0,0 +1,72 @@
+package GoCache_test
+
+import (
+	"GoCache"
+	"GoCache/clocktest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// stepUntil ... Move clock a second at a time until c sweeps at want
+func stepUntil(t *testing.T, clock *clocktest.FakeClock, c *GoCache.Cache, want time.Duration) {
+	t.Helper()
+	for step := 0; step < 64; step++ {
+		clock.Add(time.Second)
+		deadline := time.Now().Add(20 * time.Millisecond)
+		for time.Now().Before(deadline) {
+			if c.GcInterval() == want {
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+	t.Fatalf("GcInterval = %v, want %v", c.GcInterval(), want)
+}
+
+func TestAdaptiveGCFakeClock(t *testing.T) {
+	clock := clocktest.New(time.Unix(1000, 0))
+	c := GoCache.New(
+		GoCache.WithClock(clock),
+		GoCache.WithGCInterval(8*time.Second),
+		GoCache.WithAdaptiveGC(time.Second, 32*time.Second),
+	)
+	defer c.StopGc()
+	clock.BlockUntil(1)
+
+	for i := 0; i < 100; i++ {
+		c.Set("k"+strconv.Itoa(i), i, time.Second)
+	}
+	// The first sweep finds everything Expired and halves the interval
+	stepUntil(t, clock, c, 4*time.Second)
+	if n := c.Count(); n != 0 {
+		t.Fatalf("Count after heavy sweep = %d, want 0", n)
+	}
+
+	// Sweeps of an empty Cache find nothing and double it up to max
+	stepUntil(t, clock, c, 8*time.Second)
+	stepUntil(t, clock, c, 16*time.Second)
+	stepUntil(t, clock, c, 32*time.Second)
+
+	// New churn halves it again
+	for i := 0; i < 100; i++ {
+		c.Set("k"+strconv.Itoa(i), i, time.Second)
+	}
+	stepUntil(t, clock, c, 16*time.Second)
+}
+
+func TestAdaptiveGCFixed(t *testing.T) {
+	clock := clocktest.New(time.Unix(1000, 0))
+	c := GoCache.New(GoCache.WithClock(clock), GoCache.WithGCInterval(8*time.Second))
+	defer c.StopGc()
+	clock.BlockUntil(1)
+
+	c.Set("k", 1, time.Second)
+	for i := 0; i < 40; i++ {
+		clock.Add(time.Second)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if got := c.GcInterval(); got != 8*time.Second {
+		t.Fatalf("GcInterval without adaptive GC = %v, want 8s", got)
+	}
+}
@@ -0,0 +1,269 @@
+package GoCache
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Append log format: one record per change, an op byte, the length of
+// the payload as a big endian uint32 and the payload
+// Set carries the key and Item encoded by the Codec as a one entry map,
+// Delete carries the key, Flush carries nothing
+const (
+	logSet byte = iota + 1
+	logDelete
+	logFlush
+)
+
+// Defaults of WithAppendLog
+const (
+	DefaultLogSyncInterval    = time.Second
+	DefaultLogCompactInterval = time.Hour
+)
+
+// WithAppendLog ... Append every Set and Delete to file and replay it
+// when the Cache is made, so a crash loses at most the changes of the
+// last Options.LogSyncInterval
+// Errors opening or writing the log are returned by Close
+// The log is rewritten to just the live Data every Options.LogCompactInterval
+// A ShardedCache keeps one log per shard, named file.0, file.1...
+func WithAppendLog(file string) Option {
+	return func(o *Options) { o.AppendLog = file }
+}
+
+// appendLog ... The open log of a Cache, its methods are called with
+// the lock of the Cache held except sync
+type appendLog struct {
+	mutex sync.Mutex
+	file  string
+	codec Codec
+	f     *os.File
+	w     *bufio.Writer
+	err   error // First write error, returned by close
+}
+
+// openAppendLog ... Replay file into c, rewrite it compacted and keep
+// it open for appending
+func openAppendLog(c *Cache, file string) error {
+	if err := replayLog(c, file); err != nil {
+		return err
+	}
+	l := &appendLog{file: file, codec: c.codec}
+	if err := l.rewrite(c.items); err != nil {
+		return err
+	}
+	c.aof = l
+	return nil
+}
+
+// replayLog ... Apply the records of file to c, a missing file is
+// empty and a record cut short by a crash ends the log
+func replayLog(c *Cache, file string) error {
+	f, err := os.Open(file)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	r := bufio.NewReader(f)
+	c.mutex.Lock()
+	defer c.unlock()
+	var head [5]byte
+	for {
+		if _, err := io.ReadFull(r, head[:]); err != nil {
+			return nil
+		}
+		payload := make([]byte, binary.BigEndian.Uint32(head[1:]))
+		if _, err := io.ReadFull(r, payload); err != nil {
+			return nil
+		}
+		switch head[0] {
+		case logSet:
+			items, err := c.codec.Decode(bytes.NewReader(payload))
+			if err != nil {
+				return err
+			}
+			for k, item := range items {
+				if c.expired(item) {
+					c.delete(k)
+				} else {
+					c.put(k, item)
+				}
+			}
+		case logDelete:
+			c.delete(string(payload))
+		case logFlush:
+			c.flush()
+		}
+	}
+}
+
+func (l *appendLog) record(op byte, payload []byte) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.err != nil {
+		return
+	}
+	var head [5]byte
+	head[0] = op
+	binary.BigEndian.PutUint32(head[1:], uint32(len(payload)))
+	if _, err := l.w.Write(head[:]); err != nil {
+		l.err = err
+		return
+	}
+	if _, err := l.w.Write(payload); err != nil {
+		l.err = err
+	}
+}
+
+func (l *appendLog) set(k string, item Item) {
+	var buf bytes.Buffer
+	if err := l.codec.Encode(&buf, map[string]Item{k: item}); err != nil {
+		l.mutex.Lock()
+		l.err = err
+		l.mutex.Unlock()
+		return
+	}
+	l.record(logSet, buf.Bytes())
+}
+
+func (l *appendLog) delete(k string) {
+	l.record(logDelete, []byte(k))
+}
+
+func (l *appendLog) flush() {
+	l.record(logFlush, nil)
+}
+
+// sync ... Write the buffered records and fsync the log
+func (l *appendLog) sync() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.err != nil {
+		return l.err
+	}
+	if l.err = l.w.Flush(); l.err != nil {
+		return l.err
+	}
+	l.err = l.f.Sync()
+	return l.err
+}
+
+// rewrite ... Replace the log by one Set record per live Data
+func (l *appendLog) rewrite(items map[string]Item) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	err := writeFileAtomic(l.file, false, func(w io.Writer) error {
+		var buf bytes.Buffer
+		var head [5]byte
+		head[0] = logSet
+		for k, item := range items {
+			buf.Reset()
+			if err := l.codec.Encode(&buf, map[string]Item{k: item}); err != nil {
+				return err
+			}
+			binary.BigEndian.PutUint32(head[1:], uint32(buf.Len()))
+			if _, err := w.Write(head[:]); err != nil {
+				return err
+			}
+			if _, err := w.Write(buf.Bytes()); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(l.file, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		return err
+	}
+	if l.f != nil {
+		l.f.Close()
+	}
+	l.f = f
+	l.w = bufio.NewWriter(f)
+	l.err = nil
+	return nil
+}
+
+// close ... Sync and close the log, return the first error it met
+func (l *appendLog) close() error {
+	err := l.sync()
+	if cerr := l.f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// compactLog ... Rewrite the log of c to just its live Data
+func (c *Cache) compactLog() error {
+	c.mutex.Lock()
+	defer c.unlock()
+	if c.aof == nil {
+		return nil
+	}
+	return c.aof.rewrite(c.items)
+}
+
+// closeLog ... Close the log of c, later changes are not logged
+// Return the error met opening or writing the log
+func (c *Cache) closeLog() error {
+	c.mutex.Lock()
+	defer c.unlock()
+	if c.aof == nil {
+		return c.aofErr
+	}
+	err := c.aof.close()
+	c.aof = nil
+	return err
+}
+
+// runAppendLogs ... Sync the logs of caches every syncEvery and compact
+// them every compactEvery until stop is closed
+func runAppendLogs(clock Clock, syncEvery, compactEvery time.Duration, stop chan bool, caches []*Cache) {
+	if syncEvery <= 0 {
+		syncEvery = DefaultLogSyncInterval
+	}
+	if compactEvery <= 0 {
+		compactEvery = DefaultLogCompactInterval
+	}
+	syncTicker := clock.NewTicker(syncEvery)
+	compactTicker := clock.NewTicker(compactEvery)
+	failed := map[*Cache]error{} // A failed log keeps its error, warn of it once
+	for {
+		select {
+		case <-syncTicker.C():
+			for _, c := range caches {
+				c.mutex.RLock()
+				var err error
+				if c.aof != nil {
+					err = c.aof.sync()
+				}
+				c.mutex.RUnlock()
+				if err != nil && err != failed[c] {
+					failed[c] = err
+					c.warn("append log sync failed", "err", err)
+				}
+			}
+		case <-compactTicker.C():
+			for _, c := range caches {
+				if err := c.compactLog(); err != nil {
+					c.warn("append log compaction failed", "err", err)
+				}
+			}
+		case <-stop:
+			syncTicker.Stop()
+			compactTicker.Stop()
+			return
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package GoCache
+
+import "time"
+
+// AutoShards ... Shard count for NewShardedCache and NewSharded that
+// picks one from GOMAXPROCS: 4 shards per P, to a power of two, at least
+// 16 and at most DefaultShards. Few shards spare memory and whole Cache
+// scans on small machines, more than the Ps can contend on buys little
+const AutoShards = -1
+
+// maxShards ... Bound of SuggestShards
+const maxShards = 4096
+
+func autoShards(procs int) int {
+	n := 16
+	for n < 4*procs && n < DefaultShards {
+		n *= 2
+	}
+	return n
+}
+
+// ShardCount ... Return the number of shards
+func (sc *ShardedCache) ShardCount() int {
+	return len(sc.shardSet())
+}
+
+// EnableLockWaitStats ... EnableLockWaitStats in every shard
+func (sc *ShardedCache) EnableLockWaitStats(enable bool) {
+	sc.resharding.Lock()
+	defer sc.resharding.Unlock()
+	for _, c := range sc.shardSet() {
+		c.EnableLockWaitStats(enable)
+	}
+}
+
+// LockWaitStats ... The LockWaitStats of the shards put together,
+// MaxWait the longest of them
+func (sc *ShardedCache) LockWaitStats() LockWaitStats {
+	var s LockWaitStats
+	for _, c := range sc.shardSet() {
+		cs := c.LockWaitStats()
+		s.Acquisitions += cs.Acquisitions
+		s.TotalWait += cs.TotalWait
+		if cs.MaxWait > s.MaxWait {
+			s.MaxWait = cs.MaxWait
+		}
+	}
+	return s
+}
+
+// SuggestShards ... Return the shard count to make the next ShardedCache
+// with, from the lock waits recorded since EnableLockWaitStats or
+// WithLockWaitStats: twice the current count while the mean wait per
+// acquisition is over maxWait, up to 4096, else the current count
+// Pass it to Reshard, or let WithOnlineResharding do that from the GC
+func (sc *ShardedCache) SuggestShards(maxWait time.Duration) int {
+	n := len(sc.shardSet())
+	s := sc.LockWaitStats()
+	if s.Acquisitions == 0 || s.TotalWait/time.Duration(s.Acquisitions) <= maxWait {
+		return n
+	}
+	if n*2 > maxShards {
+		return maxShards
+	}
+	return n * 2
+}
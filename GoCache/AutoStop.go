@@ -0,0 +1,42 @@
+//go:build go1.24
+
+package GoCache
+
+import (
+	"runtime"
+	"time"
+	"weak"
+)
+
+// weakGcLoop ... Make a GC loop for gcRunner.run holding p only by a weak
+// pointer, which ends once p is collected
+// The cleanup sends the zero interval that ends runGc, so the loop does
+// not wait for its next tick to find p gone
+func weakGcLoop[T any](p *T, clock Clock, reset chan time.Duration,
+	interval func(*T) time.Duration, tick func(*T, *sweepLog, time.Duration) time.Duration) func(stop <-chan struct{}) {
+	wp := weak.Make(p)
+	runtime.AddCleanup(p, func(reset chan time.Duration) { resetGc(reset, 0) }, reset)
+	return func(stop <-chan struct{}) {
+		var log sweepLog
+		start := weakInterval(wp, interval)
+		if start <= 0 {
+			return
+		}
+		runGc(clock, start, stop, reset, func(d time.Duration) time.Duration {
+			p := wp.Value()
+			if p == nil {
+				return 0
+			}
+			return tick(p, &log, d)
+		})
+	}
+}
+
+// weakInterval ... Return the interval of the target of wp, zero if it
+// was collected; kept apart so the loop holds no strong pointer
+func weakInterval[T any](wp weak.Pointer[T], interval func(*T) time.Duration) time.Duration {
+	if p := wp.Value(); p != nil {
+		return interval(p)
+	}
+	return 0
+}
@@ -0,0 +1,17 @@
+//go:build !go1.24
+
+package GoCache
+
+import "time"
+
+// weakGcLoop ... Without weak pointers WithAutoStopGC does nothing: the
+// loop holds p like any other
+func weakGcLoop[T any](p *T, clock Clock, reset chan time.Duration,
+	interval func(*T) time.Duration, tick func(*T, *sweepLog, time.Duration) time.Duration) func(stop <-chan struct{}) {
+	return func(stop <-chan struct{}) {
+		var log sweepLog
+		runGc(clock, interval(p), stop, reset, func(d time.Duration) time.Duration {
+			return tick(p, &log, d)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package GoCache
+
+import (
+	"context"
+	"time"
+)
+
+// Backend ... Minimal contract of a pluggable cache, for frameworks and
+// libraries to depend on instead of a concrete Cache, so any backend,
+// this package's or a remote one, can be dropped in
+// Get reports a missing key with found false, not an error: errors are
+// failures of the backend or ctx
+type Backend interface {
+	Get(ctx context.Context, k string) (v interface{}, found bool, err error)
+	// Set ... Store v under k, Expiring after d; DefaultExpiration uses
+	// the default of the backend, NoExpiration never Expires
+	Set(ctx context.Context, k string, v interface{}, d time.Duration) error
+	Delete(ctx context.Context, k string) error
+	// Clear ... Delete every key
+	Clear(ctx context.Context) error
+}
+
+// ctxCache ... What a Cache and a ShardedCache have for a Backend
+type ctxCache interface {
+	GetCtx(ctx context.Context, k string) (interface{}, bool, error)
+	SetCtx(ctx context.Context, k string, v interface{}, d time.Duration) error
+	DeleteCtx(ctx context.Context, k string) error
+	Flush()
+}
+
+// backend ... A Cache or ShardedCache seen as a Backend
+type backend struct {
+	c ctxCache
+}
+
+// AsBackend ... Return c as a Backend, whose methods are the Ctx
+// variants of c and Clear its Flush
+//
+//	var b GoCache.Backend = GoCache.AsBackend(c)
+func AsBackend(c *Cache) Backend {
+	return backend{c}
+}
+
+// ShardedBackend ... Return sc as a Backend, see AsBackend
+func ShardedBackend(sc *ShardedCache) Backend {
+	return backend{sc}
+}
+
+func (b backend) Get(ctx context.Context, k string) (interface{}, bool, error) {
+	return b.c.GetCtx(ctx, k)
+}
+
+func (b backend) Set(ctx context.Context, k string, v interface{}, d time.Duration) error {
+	return b.c.SetCtx(ctx, k, v, d)
+}
+
+func (b backend) Delete(ctx context.Context, k string) error {
+	return b.c.DeleteCtx(ctx, k)
+}
+
+func (b backend) Clear(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	b.c.Flush()
+	return nil
+}
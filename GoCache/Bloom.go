@@ -0,0 +1,94 @@
+package GoCache
+
+import (
+	"math"
+	"sync/atomic"
+)
+
+// WithBloomFilter ... Track every key ever Set in a Bloom filter sized for
+// expectedKeys at falsePositiveRate, and let Get turn away keys it has
+// never seen without taking the lock or looking in the map
+// Deleted and Expired keys stay in the filter until Flush, so it pays off
+// when most misses are for keys that were never stored; past expectedKeys
+// it lets more and more misses through to the map
+func WithBloomFilter(expectedKeys int, falsePositiveRate float64) Option {
+	return func(o *Options) {
+		o.BloomKeys = expectedKeys
+		o.BloomFalsePositiveRate = falsePositiveRate
+	}
+}
+
+// DefaultBloomFalsePositiveRate ... Rate WithBloomFilter uses when given
+// one outside (0, 1)
+const DefaultBloomFalsePositiveRate = 0.01
+
+// bloomFilter ... Bits set under the lock of the Cache by add, read
+// without it by mayContain
+type bloomFilter struct {
+	bits   []atomic.Uint64
+	m      uint64 // Number of bits
+	hashes int
+}
+
+func newBloomFilter(n int, p float64) *bloomFilter {
+	if n < 1 {
+		n = 1
+	}
+	if p <= 0 || p >= 1 {
+		p = DefaultBloomFalsePositiveRate
+	}
+	m := uint64(math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2)))
+	m = (m + 63) / 64 * 64
+	k := int(math.Round(float64(m) / float64(n) * math.Ln2))
+	if k < 1 {
+		k = 1
+	}
+	return &bloomFilter{bits: make([]atomic.Uint64, m/64), m: m, hashes: k}
+}
+
+// positions ... Two halves of the FNV-1a hash of k, combined into the
+// bit positions by double hashing
+func positions[K string | []byte](k K) (h1, h2 uint64) {
+	h := uint64(14695981039346656037)
+	for i := 0; i < len(k); i++ {
+		h ^= uint64(k[i])
+		h *= 1099511628211
+	}
+	return h & 0xffffffff, h>>32 | 1
+}
+
+func (b *bloomFilter) add(k string) {
+	h1, h2 := positions(k)
+	for i := 0; i < b.hashes; i++ {
+		bit := (h1 + uint64(i)*h2) % b.m
+		w, mask := &b.bits[bit/64], uint64(1)<<(bit%64)
+		for old := w.Load(); old&mask == 0 && !w.CompareAndSwap(old, old|mask); old = w.Load() {
+		}
+	}
+}
+
+// mayContain ... Report false only for keys add never saw
+func (b *bloomFilter) mayContain(k string) bool {
+	return b.test(positions(k))
+}
+
+// mayContainBytes ... mayContain of a []byte key
+func (b *bloomFilter) mayContainBytes(k []byte) bool {
+	return b.test(positions(k))
+}
+
+func (b *bloomFilter) test(h1, h2 uint64) bool {
+	for i := 0; i < b.hashes; i++ {
+		bit := (h1 + uint64(i)*h2) % b.m
+		if b.bits[bit/64].Load()&(uint64(1)<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *bloomFilter) reset() {
+	for i := range b.bits {
+		b.bits[i].Store(0)
+	}
+}
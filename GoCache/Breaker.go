@@ -0,0 +1,135 @@
+package GoCache
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// WithCircuitBreaker ... Stop calling the loaders of GetOrCompute,
+// GetOrComputeResult, refresh ahead and stale revalidation for coolDown
+// once failures of their calls in a row failed, so a failing backend is
+// not hammered by every miss. While the circuit is open GetOrCompute
+// serves the Expired Data of the key if the Cache still holds it, or
+// fails at once with ErrCircuitOpen; background reloads are skipped.
+// After coolDown one call is let through: success closes the circuit,
+// failure opens it for coolDown again. The shards of a ShardedCache
+// share the circuit
+func WithCircuitBreaker(failures int, coolDown time.Duration) Option {
+	return func(o *Options) {
+		o.BreakerFailures, o.BreakerCoolDown, o.BreakerPerKey = failures, coolDown, false
+	}
+}
+
+// WithKeyCircuitBreaker ... WithCircuitBreaker with a circuit per key,
+// so one failing key does not stop the loads of the others
+func WithKeyCircuitBreaker(failures int, coolDown time.Duration) Option {
+	return func(o *Options) {
+		o.BreakerFailures, o.BreakerCoolDown, o.BreakerPerKey = failures, coolDown, true
+	}
+}
+
+// breaker ... Circuits of the loaders, one for all keys unless perKey
+type breaker struct {
+	mutex    sync.Mutex
+	clock    Clock
+	failures int
+	coolDown int64
+	perKey   bool
+	circuits map[string]*circuit // Only of keys that failed since their last success
+}
+
+type circuit struct {
+	fails     int
+	openUntil int64 // UnixNano, zero while closed
+}
+
+func newBreaker(opts Options, clock Clock) *breaker {
+	if opts.BreakerFailures <= 0 || opts.BreakerCoolDown <= 0 {
+		return nil
+	}
+	return &breaker{
+		clock:    clock,
+		failures: opts.BreakerFailures,
+		coolDown: int64(opts.BreakerCoolDown),
+		perKey:   opts.BreakerPerKey,
+		circuits: map[string]*circuit{},
+	}
+}
+
+func (b *breaker) circuitKey(k string) string {
+	if b.perKey {
+		return k
+	}
+	return ""
+}
+
+// allow ... Report whether a loader call for k may run. Past the cool
+// down of an open circuit the caller is the trial call, and the circuit
+// stays open for the others meanwhile
+func (b *breaker) allow(k string) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	ct := b.circuits[b.circuitKey(k)]
+	if ct == nil || ct.openUntil == 0 {
+		return true
+	}
+	now := b.clock.Now().UnixNano()
+	if now < ct.openUntil {
+		return false
+	}
+	ct.openUntil = now + b.coolDown
+	return true
+}
+
+// done ... Record the outcome of a loader call for k
+func (b *breaker) done(k string, err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	ck := b.circuitKey(k)
+	if err == nil {
+		delete(b.circuits, ck)
+		return
+	}
+	ct := b.circuits[ck]
+	if ct == nil {
+		ct = &circuit{}
+		b.circuits[ck] = ct
+	}
+	if ct.fails++; ct.fails >= b.failures {
+		ct.openUntil = b.clock.Now().UnixNano() + b.coolDown
+	}
+}
+
+// callLoader ... Call loader for k through the circuit breaker, an error
+// matching ErrCircuitOpen if the circuit is open
+func (c *Cache) callLoader(k string, loader func() (interface{}, error)) (interface{}, error) {
+	b := c.breaker
+	if b == nil {
+		return loader()
+	}
+	if !b.allow(k) {
+		return nil, fmt.Errorf("item %s: %w", k, ErrCircuitOpen)
+	}
+	v, err := loader()
+	b.done(k, err)
+	return v, err
+}
+
+// staleValue ... Return the Expired Data still held at k, for loads
+// the circuit breaker refused
+func (c *Cache) staleValue(k string) (interface{}, bool) {
+	c = c.rLockFor(k)
+	defer c.mutex.RUnlock()
+	item, found := c.items[k]
+	if !found {
+		return nil, false
+	}
+	return c.open(item.Object), true
+}
+
+// circuitOpen ... Report whether err is the refusal of the breaker
+func circuitOpen(err error) bool {
+	return errors.Is(err, ErrCircuitOpen)
+}
@@ -0,0 +1,228 @@
+package GoCache
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Defaults of NewBytesCache
+const (
+	DefaultBytesShards     = 64
+	DefaultBytesShardBytes = 1 << 20
+)
+
+// Entry layout in a shard ring: expiration int64, hash uint64,
+// key length uint16, value length uint32, then key and value
+const bytesHeader = 8 + 8 + 2 + 4
+
+// BytesCache ... Cache of []byte values kept in large preallocated
+// rings, one per shard, indexed by maps holding no pointers, so
+// millions of entries give the Go GC nothing to scan
+// When a ring is full its oldest entries are evicted. Expiration works
+// like in Cache: DefaultExpiration, NoExpiration or a duration
+type BytesCache struct {
+	defaultExpiration time.Duration
+	shards            []*bytesShard
+	clock             Clock
+	gc                gcRunner
+}
+
+type bytesShard struct {
+	mutex   sync.RWMutex
+	index   map[uint64]uint32 // Key hash to offset in buf
+	buf     []byte
+	head    int  // Offset of the oldest entry
+	tail    int  // Offset the next entry is written at
+	end     int  // End of the entries before tail wrapped to 0
+	wrapped bool // The entries run from head to end, then from 0 to tail
+	entries int  // Entries in buf, dead ones included
+}
+
+// NewBytesCache ... Create a BytesCache of shards rings of shardBytes
+// each And goRoutine; zero picks DefaultBytesShards and DefaultBytesShardBytes
+// A value must fit in one ring together with its key. Of opts only
+// WithClock is read
+func NewBytesCache(shards, shardBytes int, defaultExpiration, gcInterval time.Duration, opts ...Option) *BytesCache {
+	if shards <= 0 {
+		shards = DefaultBytesShards
+	}
+	if shardBytes <= 0 {
+		shardBytes = DefaultBytesShardBytes
+	}
+	if gcInterval <= 0 {
+		gcInterval = DefaultGcInterval
+	}
+	bc := &BytesCache{
+		defaultExpiration: defaultExpiration,
+		shards:            make([]*bytesShard, shards),
+		clock:             SystemClock,
+	}
+	if o := buildOptions(opts); o.Clock != nil {
+		bc.clock = o.Clock
+	}
+	for i := range bc.shards {
+		bc.shards[i] = &bytesShard{index: map[uint64]uint32{}, buf: make([]byte, shardBytes)}
+	}
+	bc.gc.run = func(stop <-chan struct{}) {
+		runGc(bc.clock, gcInterval, stop, nil, func(interval time.Duration) time.Duration {
+			bc.DeleteExpired()
+			return interval
+		})
+	}
+	bc.gc.start()
+	return bc
+}
+
+func (bc *BytesCache) shard(h uint64) *bytesShard {
+	return bc.shards[h%uint64(len(bc.shards))]
+}
+
+// Set ... Copy v into the Cache with Expiration d, an error is
+// returned if key and value do not fit in a ring
+func (bc *BytesCache) Set(k string, v []byte, d time.Duration) error {
+	if d == DefaultExpiration {
+		d = bc.defaultExpiration
+	}
+	var e int64
+	if d > 0 {
+		e = bc.clock.Now().Add(d).UnixNano()
+	}
+	h := hash64(k)
+	s := bc.shard(h)
+	n := bytesHeader + len(k) + len(v)
+	if len(k) > 0xffff || n > len(s.buf) {
+		return fmt.Errorf("item %s of %d bytes does not fit in a ring of %d", k, n, len(s.buf))
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	off := s.alloc(n)
+	b := s.buf[off : off+n]
+	binary.LittleEndian.PutUint64(b, uint64(e))
+	binary.LittleEndian.PutUint64(b[8:], h)
+	binary.LittleEndian.PutUint16(b[16:], uint16(len(k)))
+	binary.LittleEndian.PutUint32(b[18:], uint32(len(v)))
+	copy(b[bytesHeader:], k)
+	copy(b[bytesHeader+len(k):], v)
+	s.index[h] = uint32(off)
+	return nil
+}
+
+// Get ... Return a copy of the Data at k
+func (bc *BytesCache) Get(k string) ([]byte, bool) {
+	h := hash64(k)
+	s := bc.shard(h)
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	off, found := s.index[h]
+	if !found {
+		return nil, false
+	}
+	e, key, v := s.entry(int(off))
+	if key != k || e > 0 && bc.clock.Now().UnixNano() > e {
+		return nil, false
+	}
+	return append([]byte(nil), v...), true
+}
+
+// Delete ... Delete the Data at k, its space is reused when the ring
+// comes round to it
+func (bc *BytesCache) Delete(k string) {
+	h := hash64(k)
+	s := bc.shard(h)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if off, found := s.index[h]; found {
+		if _, key, _ := s.entry(int(off)); key == k {
+			delete(s.index, h)
+		}
+	}
+}
+
+// Count ... Return Number of Data In Cache, Expired ones included
+func (bc *BytesCache) Count() int {
+	n := 0
+	for _, s := range bc.shards {
+		s.mutex.RLock()
+		n += len(s.index)
+		s.mutex.RUnlock()
+	}
+	return n
+}
+
+// DeleteExpired ... Drop the Expired Data from the indexes, one shard at a time
+func (bc *BytesCache) DeleteExpired() {
+	now := bc.clock.Now().UnixNano()
+	for _, s := range bc.shards {
+		s.mutex.Lock()
+		for h, off := range s.index {
+			if e := int64(binary.LittleEndian.Uint64(s.buf[off:])); e > 0 && now > e {
+				delete(s.index, h)
+			}
+		}
+		s.mutex.Unlock()
+	}
+}
+
+// Flush ... Delete all Data, keeping the rings
+func (bc *BytesCache) Flush() {
+	for _, s := range bc.shards {
+		s.mutex.Lock()
+		s.index = map[uint64]uint32{}
+		s.head, s.tail, s.end, s.wrapped, s.entries = 0, 0, 0, false, 0
+		s.mutex.Unlock()
+	}
+}
+
+// StopGc ... Stop the GC goRoutine, later calls do nothing
+func (bc *BytesCache) StopGc() {
+	bc.gc.stop()
+}
+
+// entry ... Decode the entry at off
+func (s *bytesShard) entry(off int) (expiration int64, key string, value []byte) {
+	b := s.buf[off:]
+	kn := int(binary.LittleEndian.Uint16(b[16:]))
+	vn := int(binary.LittleEndian.Uint32(b[18:]))
+	return int64(binary.LittleEndian.Uint64(b)), string(b[bytesHeader : bytesHeader+kn]), b[bytesHeader+kn : bytesHeader+kn+vn]
+}
+
+// alloc ... Make room for n bytes at the tail, evicting the oldest
+// entries in the way, and Return their offset
+func (s *bytesShard) alloc(n int) int {
+	for {
+		if s.entries == 0 {
+			s.head, s.tail, s.end, s.wrapped = 0, 0, 0, false
+		}
+		if !s.wrapped {
+			if s.tail+n <= len(s.buf) {
+				break
+			}
+			s.end, s.tail, s.wrapped = s.tail, 0, true
+			continue
+		}
+		if s.tail+n <= s.head {
+			break
+		}
+		s.evictHead()
+	}
+	off := s.tail
+	s.tail += n
+	s.entries++
+	return off
+}
+
+// evictHead ... Drop the oldest entry, and its index if it is still live
+func (s *bytesShard) evictHead() {
+	b := s.buf[s.head:]
+	h := binary.LittleEndian.Uint64(b[8:])
+	if off, found := s.index[h]; found && int(off) == s.head {
+		delete(s.index, h)
+	}
+	s.head += bytesHeader + int(binary.LittleEndian.Uint16(b[16:])) + int(binary.LittleEndian.Uint32(b[18:]))
+	s.entries--
+	if s.wrapped && s.head == s.end {
+		s.head, s.wrapped = 0, false
+	}
+}
@@ -1,17 +1,39 @@
 package GoCache
 
 import (
-	"encoding/gob"
 	"fmt"
 	"io"
-	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 type Item struct {
 	Object     interface{}
 	Expiration int64
+	// SoftExpiration ... Past it the Data is stale but still served, zero when none
+	SoftExpiration int64
+	// ComputeCost ... Time it took to compute the Data, drives WithEarlyExpiration
+	ComputeCost time.Duration
+	Priority    Priority      // See SetWithPriority
+	Sliding     time.Duration // Each Get pushes Expiration this far, zero when fixed
+	Tags        []string      // InvalidateTag of any of them Deletes the Data
+	Deps        []string      // Removing or replacing any of these keys Deletes the Data, see SetWithDeps
+	size        int64         // Approximate bytes, counted against maxBytes
+	CreatedAt   int64         // When the Data was Set, in UnixNano
+	// LastAccessedAt ... When a read last found the Data, in UnixNano
+	// It is kept up to date in the Items of GetItem and Items only
+	LastAccessedAt int64
+	accessed       *atomic.Int64 // Live LastAccessedAt, so reads under the read lock can set it
+	onExpired      func(string, interface{})
+	copyOnRead     bool          // Reads Return a copy, see SetWithCopyOnRead
+	pins           int           // Pin calls not yet Unpinned, see Pin
+	reads          *atomic.Int64 // Reads left, nil when unlimited, see SetWithMaxReads
+	version        uint64        // SnapshotID of its last change, see Diff
+
+	// Meta ... Metadata of the caller, see WithMeta, shared with the Cache
+	// so not to be changed
+	Meta map[string]string
 }
 
 const (
@@ -23,9 +45,89 @@ const (
 type Cache struct {
 	defaultExpiration time.Duration
 	items             map[string]Item // Cache in map
+	initialCapacity   int             // Size hint of items, see WithInitialCapacity
 	mutex             sync.RWMutex
 	gcInterval        time.Duration
 	stopGc            chan bool
+	gcReset           chan time.Duration // New GC intervals, see SetGCInterval
+	lockWait          lockWaitStats
+	skipNonStringText bool
+	gcMinInterval     time.Duration // Adaptive GC bounds, zero when fixed
+	gcMaxInterval     time.Duration
+	maxEntries        int                         // Zero means unbounded
+	maxBytes          int64                       // Zero means unbounded
+	totalBytes        int64                       // Sum of the size of all Data
+	policy            EvictionPolicy              // Picks eviction victims, nil when unbounded
+	tracksReads       atomic.Bool                 // Set once reads need the write lock, read without the lock by lockForRead
+	namespaces        map[string]*namespaceLimits // By prefix, see ConfigureNamespace
+	newPolicy         func() EvictionPolicy
+	admission         AdmissionPolicy // Gate for new Data, nil lets all in
+	newAdmission      func() AdmissionPolicy
+	onEvicted         func(string, interface{})
+	evicted           []keyValue    // Removed under the lock, told to onEvicted by unlock
+	expiredCalls      []expiredCall // OnExpired callbacks for unlock to run
+	expiredSubs       []*expiredSub // Channels of ExpiredEvents
+	expiredItems      []ExpiredItem // Expired Data for unlock to send to expiredSubs
+	readLimited       bool          // Some Data was Set with a read limit, reads take the write lock
+	spent             []string      // Keys out of reads, Deleted by unlock
+	flightMutex       sync.Mutex
+	flights           map[string]*flight             // Loader calls in progress by key
+	gcFlight          *gcFlight                      // RunGC sweep in progress
+	refresh           *refreshConfig                 // nil when refresh-ahead is off
+	slidingByDefault  bool                           // Set makes sliding Data
+	tags              map[string]map[string]struct{} // Keys by tag
+	dependents        map[string]map[string]struct{} // Keys by the keys they depend on
+	versions          *atomic.Uint64                 // Source of SnapshotIDs, shared by shards
+	tombstones        map[string]uint64              // SnapshotIDs of removals, for Diff
+	diffFloor         uint64                         // Diffs from before it Reset
+	stats             cacheStats
+	lazyExpiration    bool             // Get Deletes the Expired Data it finds
+	expirations       *expirationIndex // nil unless Options.ExpirationIndex
+	sweepBatch        int              // Keys a GC tick may look at, zero for all
+	sweepPause        time.Duration    // Time a GC tick may hold the lock, zero for no limit
+	sweepKeys         []string         // Keys left to look at by incremental sweeps
+	stopOnce          sync.Once
+	gc                gcRunner
+	loading           atomic.Int32 // Loads running, see IsLoading
+	loadBatchSize     int          // Data merged per lock by Load, all if zero
+	closeOnce         sync.Once
+	closeErr          error
+	persistFile       string // Close saves the Cache here when set
+	compress          bool   // SaveToFile gzips the file
+	snapshotKey       []byte // SaveToFile encrypts the file with it when set
+	snapshot          *snapshotConfig
+	aof               *appendLog // nil unless WithAppendLog
+	aofErr            error      // Error opening the log, returned by Close
+	overflow          *overflowConfig
+	maxValueSize      int64 // Larger Data is handled by oversizePolicy, zero when unbounded
+	oversizePolicy    OversizePolicy
+	keyFunc           func(string) string // Canonical form of keys, nil to use them as given
+	hot               *hotKeys            // Hits per key, nil unless WithHotKeys
+	invalidator       Invalidator         // nil unless WithInvalidator
+	instanceID        string              // Invalidation.Source of this Cache
+	unsubscribe       func()
+	keyLocks          *keyLocks // Stripes of LockKey, made on first use
+	keyLocksOnce      sync.Once
+	stale             *staleConfig  // nil unless EnableStaleWhileRevalidate
+	ttlJitter         float64       // Expirations are spread by up to this fraction of the TTL
+	earlyBeta         float64       // Scale of WithEarlyExpiration, zero when off
+	bloom             *bloomFilter  // Keys ever Set since Flush, nil unless WithBloomFilter
+	logger            Logger        // nil unless WithLogger
+	idleTimeout       time.Duration // Data not read for this long is evicted, zero when off
+	minTTL            time.Duration // Set durations are clamped within these, zero when unbounded
+	maxTTL            time.Duration
+	prefixes          *radixNode     // Every key, nil unless Options.PrefixIndex
+	sizer             ItemSizer      // Weighs Data, approxSize if nil
+	cloner            Cloner         // Reads Return copies made by it, nil unless WithCopyOnRead
+	transform         ValueTransform // Data is kept sealed by it, nil unless WithValueTransform
+	watchers          []*watcher
+	clock             Clock
+	codec             Codec // Format of Save and Load
+
+	lockFree *readView     // Copy of the Data Get reads without the lock, nil unless WithLockFreeReads
+	breaker  *breaker      // Circuits of the loaders, nil unless WithCircuitBreaker
+	paused   atomic.Bool   // Nothing Expires while set, see PauseExpiration
+	movedTo  *ShardedCache // Set under the lock when a Reshard retires the shard
 }
 
 //Check Data if Expired
@@ -39,13 +141,52 @@ func (item Item) Expired() bool {
 }
 
 // Clear Data in Cache
-func (c *Cache) gcLoop() {
-	ticker := time.NewTicker(c.gcInterval)
+func (c *Cache) gcLoop(stop <-chan struct{}) {
+	var log sweepLog
+	runGc(c.clock, c.GcInterval(), stop, c.gcReset, func(interval time.Duration) time.Duration {
+		return c.gcTick(&log, interval)
+	})
+}
+
+// gcTick ... Sweep once and Return the interval to the next sweep
+func (c *Cache) gcTick(log *sweepLog, interval time.Duration) time.Duration {
+	start := c.clock.Now()
+	removed, scanned := c.sweep()
+	log.observe(c.logger, removed, scanned, c.Count(), c.stats.evictions.Load(), c.clock.Now().Sub(start))
+	return c.nextGcInterval(interval, removed, scanned)
+}
+
+// sweep ... Do the work of one GC tick, a bounded step if configured
+func (c *Cache) sweep() (removed, scanned int) {
+	if c.sweepBatch > 0 || c.sweepPause > 0 {
+		return c.sweepStep()
+	}
+	return c.deleteExpired()
+}
+
+// runGc ... Call sweep every interval until stop is closed
+// sweep returns the interval to wait before the next call, an interval
+// received from reset replaces the current one at once; reset may be nil
+// An interval of zero from either ends the loop as well
+func runGc(clock Clock, interval time.Duration, stop <-chan struct{}, reset <-chan time.Duration, sweep func(time.Duration) time.Duration) {
+	ticker := clock.NewTicker(interval)
 	for {
 		select {
-		case <-ticker.C:
-			c.DeleteExpired()
-		case <-c.stopGc:
+		case <-ticker.C():
+			if next := sweep(interval); next <= 0 {
+				ticker.Stop()
+				return
+			} else if next != interval {
+				interval = next
+				ticker.Reset(interval)
+			}
+		case interval = <-reset:
+			if interval <= 0 {
+				ticker.Stop()
+				return
+			}
+			ticker.Reset(interval)
+		case <-stop:
 			ticker.Stop()
 			return
 		}
@@ -54,175 +195,819 @@ func (c *Cache) gcLoop() {
 
 //Delete Cache Data
 func (c *Cache) delete(k string) {
+	c.remove(k, EventDelete)
+}
+
+// remove ... Delete the Data at k, telling watchers why, and the Data
+// depending on it
+func (c *Cache) remove(k string, why EventType) {
+	item, found := c.items[k]
+	if !found {
+		return
+	}
+	c.unindex(k, item)
 	delete(c.items, k)
+	c.lockFree.changed()
+	c.removed(k, why)
+	if c.prefixes != nil {
+		c.prefixes.remove(k)
+	}
+	if c.policy != nil {
+		c.policy.OnDelete(k)
+	}
+	if nl := c.namespaceOf(k); nl != nil {
+		nl.count--
+		nl.removed(why)
+		if nl.policy != nil {
+			nl.policy.OnDelete(k)
+		}
+	}
+	if c.aof != nil {
+		c.aof.delete(k)
+	}
+	object := release(item.Object, c.onEvicted != nil)
+	if c.onEvicted != nil {
+		c.evicted = append(c.evicted, keyValue{k, c.open(object)})
+	}
+	if c.watchers != nil {
+		c.notify(why, k, nil)
+	}
+	c.invalidateDependents(k)
+}
+
+// index ... Account for item stored at k in the size total and indexes
+func (c *Cache) index(k string, item Item) {
+	c.totalBytes += item.size
+	if nl := c.namespaceOf(k); nl != nil {
+		nl.bytes += item.size
+	}
+	c.tagKey(k, item.Tags)
+	c.dependOn(k, item.Deps)
+	if c.expirations != nil {
+		c.expirations.set(k, item.deadline())
+	}
+}
+
+// unindex ... Undo index for item leaving k
+func (c *Cache) unindex(k string, item Item) {
+	c.totalBytes -= item.size
+	if nl := c.namespaceOf(k); nl != nil {
+		nl.bytes -= item.size
+	}
+	c.untagKey(k, item.Tags)
+	c.undepend(k, item.Deps)
+	if c.expirations != nil {
+		c.expirations.set(k, 0)
+	}
+}
+
+// update ... Store a changed item at k, keeping the indexes in step
+// without counting it as a Set
+func (c *Cache) update(k string, item Item) {
+	c.unindex(k, c.items[k])
+	c.changed(k, &item)
+	c.items[k] = item
+	c.lockFree.changed()
+	c.index(k, item)
+	if c.aof != nil {
+		c.aof.set(k, item)
+	}
 }
 
 // Trans All Data in Map And Delete Expired Data
 func (c *Cache) DeleteExpired() {
+	c.deleteExpired()
+}
 
-	now := time.Now().UnixNano()
+// deleteExpired ... Return the Number of Data removed and scanned
+func (c *Cache) deleteExpired() (removed, scanned int) {
 	c.mutex.Lock()
-	defer c.mutex.Unlock()
-	for k, v := range c.items {
-		if v.Expiration > 0 && now > v.Expiration {
-			c.delete(k)
+	defer c.unlock()
+	// A shard retired by Reshard leaves its Data to the new ones
+	if c.paused.Load() || c.movedTo != nil {
+		return 0, 0
+	}
+	start := time.Now()
+	t := c.now().UnixNano()
+	now := t - c.grace() // Stale Data is kept for its grace
+	idle := 0
+	if c.expirations != nil {
+		for e, ok := c.expirations.peek(); ok && now > e.at; e, ok = c.expirations.peek() {
+			c.expire(e.key)
+			removed++
 		}
 	}
+	if c.expirations == nil || c.idleTimeout > 0 {
+		for k, v := range c.items {
+			if e := v.deadline(); c.expirations == nil && e > 0 && now > e {
+				c.expire(k)
+				removed++
+			} else if c.isIdle(v, t) {
+				c.remove(k, EventEvict)
+				idle++
+			}
+		}
+	}
+	c.stats.expired.Add(uint64(removed))
+	c.stats.evictions.Add(uint64(idle))
+	c.stats.sweep(time.Since(start), removed+idle)
+	return removed + idle, len(c.items) + removed + idle
 }
 
 // To Set the Data
+// opts set per Data metadata, like WithTags or WithPriority
+// With DefaultExpiration, a TTLer or Expirer v picks its own Expiration
+
+func (c *Cache) Set(k string, v interface{}, d time.Duration, opts ...SetOption) {
+	k = c.key(k)
+	c = c.lockFor(k)
+	defer c.unlock()
+	if len(opts) == 0 {
+		c.set(k, v, d)
+		return
+	}
+	item := c.newItem(v, d)
+	for _, opt := range opts {
+		opt(&item)
+	}
+	c.put(k, item)
+}
+
+// setKey ... Set a key in canonical form, taking the lock
+func (c *Cache) setKey(k string, v interface{}, d time.Duration) error {
+	c = c.lockFor(k)
+	defer c.unlock()
+	return c.set(k, v, d)
+}
+
+// set ... Set without taking the lock
+func (c *Cache) set(k string, v interface{}, d time.Duration) error {
+	return c.setSized(k, v, d, 0)
+}
+
+// setSized ... Set with a size given by the caller, zero computes it
+func (c *Cache) setSized(k string, v interface{}, d time.Duration, size int64) error {
+	item := c.newItem(v, d)
+	item.size = size
+	return c.put(k, item)
+}
+
+// newItem ... Item of v Expiring after d, or when v asks if it is a
+// TTLer or Expirer and d is DefaultExpiration, sliding if the Cache
+// makes all Data so
+func (c *Cache) newItem(v interface{}, d time.Duration) Item {
+	d = c.valueTTL(v, d)
+	item := Item{
+		Object:     v,
+		Expiration: c.expiration(d),
+	}
+	if c.slidingByDefault && item.Expiration > 0 {
+		item.Sliding = c.resolve(d)
+	}
+	return item
+}
 
-func (c *Cache) Set(k string, v interface{}, d time.Duration) {
-	var e int64
+// resolve ... Replace DefaultExpiration by the default of the Cache
+// and clamp the result within its MinTTL and MaxTTL
+func (c *Cache) resolve(d time.Duration) time.Duration {
 	if d == DefaultExpiration {
 		d = c.defaultExpiration
 	}
-	if d > 0 {
-		e = time.Now().Add(d).UnixNano()
+	return c.clamp(d)
+}
+
+// expiration ... Turn a duration given to Set into an Expiration
+func (c *Cache) expiration(d time.Duration) int64 {
+	if d = c.resolve(d); d > 0 {
+		return c.now().Add(c.jitter(d)).UnixNano()
 	}
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
-	c.items[k] = Item{
-		Object:     v,
-		Expiration: e,
+	return 0
+}
+
+// put ... Store item and evict the victims of the policy
+// beyond maxEntries or maxBytes, Return ErrValueTooLarge if item is
+// over maxValueSize and rejected
+func (c *Cache) put(k string, item Item) error {
+	item, err := c.prepare(k, item)
+	if err != nil {
+		return err
+	}
+	return c.place(k, item)
+}
+
+// prepare ... Seal and size item for k, Return the error that keeps it
+// out of the Cache. Nothing is stored yet, but an item spilled by the
+// OversizePolicy must be placed or released
+func (c *Cache) prepare(k string, item Item) (Item, error) {
+	if c.keyFunc != nil && len(item.Deps) > 0 {
+		item.Deps = c.keys(item.Deps)
+	}
+	if c.transform != nil {
+		if _, ok := item.Object.(sealed); !ok {
+			b, err := seal(c.transform, item.Object)
+			if err != nil {
+				c.stats.rejections.Add(1)
+				return item, fmt.Errorf("item %s: %w", k, err)
+			}
+			item.Object = sealed{b}
+		}
+	}
+	if item.size <= 0 {
+		item.size = c.sizeOf(k, item.Object)
+	}
+	if c.maxValueSize > 0 && item.size > c.maxValueSize {
+		size, ok := item.size, false
+		if item, ok = c.oversize(k, item); !ok {
+			c.stats.rejections.Add(1)
+			return item, errValueTooLarge(k, size)
+		}
+	}
+	return item, nil
+}
+
+// place ... Store item prepared for k
+func (c *Cache) place(k string, item Item) error {
+	if item.CreatedAt == 0 {
+		item.CreatedAt = c.now().UnixNano()
+	}
+	if item.accessed == nil {
+		item.accessed = newAccessed(item)
+	}
+	if item.reads != nil {
+		c.limitReads(&item)
+	}
+	old, found := c.items[k]
+	if found {
+		item.pins = old.pins
+	}
+	if !found && !c.admit(k, item) {
+		c.stats.rejections.Add(1)
+		// A spill of the OversizePolicy is not kept either
+		release(item.Object, false)
+		return nil
+	}
+	if c.overflow != nil && item.size > c.overflow.threshold {
+		item = c.overflow.spill(k, item)
+	}
+	if found {
+		c.unindex(k, old)
+		c.invalidateDependents(k)
+		if sp, ok := old.Object.(spilled); ok && item.Object != interface{}(sp) {
+			release(sp, false)
+		}
+	}
+	c.changed(k, &item)
+	c.items[k] = item
+	c.lockFree.changed()
+	c.index(k, item)
+	if c.bloom != nil {
+		c.bloom.add(k)
+	}
+	if !found && c.prefixes != nil {
+		c.prefixes.insert(k)
+	}
+	c.stats.sets.Add(1)
+	if c.aof != nil {
+		c.aof.set(k, item)
+	}
+	if c.watchers != nil {
+		c.notify(EventSet, k, c.open(item.Object))
+	}
+	if nl := c.namespaceOf(k); nl != nil {
+		if !found {
+			nl.count++
+		}
+		nl.sets++
+		if nl.policy != nil && item.pins == 0 {
+			nl.policy.OnSet(k)
+			c.evictNamespace(nl)
+		}
+	}
+	if c.policy == nil || item.pins > 0 {
+		return nil
+	}
+	if item.Priority != PriorityNormal {
+		c.prioritize()
+	}
+	c.policy.OnSet(k)
+	c.evictOverBudget()
+	return nil
+}
+
+// evictOverBudget ... Evict the victims of the policy until the Cache
+// fits its limits again
+func (c *Cache) evictOverBudget() {
+	for len(c.items) > 0 && c.overBudget() {
+		victim, ok := c.policy.Victim()
+		if !ok {
+			break
+		}
+		c.remove(victim, EventEvict)
+		c.stats.evictions.Add(1)
 	}
+}
 
+// admit ... Ask the admission policy whether new item may take the
+// place of the next victim, when storing it would go over the limits
+func (c *Cache) admit(k string, item Item) bool {
+	if c.admission == nil {
+		return true
+	}
+	c.admission.Record(k)
+	full := c.maxEntries > 0 && len(c.items)+1 > c.maxEntries ||
+		c.maxBytes > 0 && c.totalBytes+item.size > c.maxBytes
+	if !full {
+		return true
+	}
+	victim, ok := c.policy.Victim()
+	return !ok || c.admission.Admit(k, victim)
+}
+
+// overBudget ... Report whether the Cache holds more than its limits
+func (c *Cache) overBudget() bool {
+	if c.maxEntries > 0 && len(c.items) > c.maxEntries {
+		return true
+	}
+	return c.maxBytes > 0 && c.totalBytes > c.maxBytes
 }
 
 // To Get the Data
 
 func (c *Cache) Get(k string) (interface{}, bool) {
+	k = c.key(k)
+	v, found, _ := c.read(k)
+	return v, found
+}
+
+// read ... Get, also reporting whether a miss is an early one, see
+// WithEarlyExpiration
+func (c *Cache) read(k string) (v interface{}, found, early bool) {
+	v, found, early, _ = c.readWithin(k, 0)
+	return v, found, early
+}
+
+// readWithin ... read, ok false if the lock was not had within timeout,
+// zero waits as long as it takes. With a timeout the work left for after
+// the read runs in a goRoutine, so it does not wait on the lock either
+func (c *Cache) readWithin(k string, timeout time.Duration) (v interface{}, found, early, ok bool) {
+	if c.bloom != nil && !c.bloom.mayContain(k) {
+		c.stats.read(false)
+		return nil, false, false, true
+	}
+	if v, found, ok := c.readUnlocked(k); ok {
+		return v, found, false, true
+	}
+	var write bool
+	if timeout > 0 {
+		if write, ok = c.tryLockForRead(timeout); !ok {
+			return nil, false, false, false
+		}
+	} else {
+		write = c.lockForRead()
+	}
+	if sc := c.movedTo; sc != nil {
+		c.unlockForRead(write)
+		return sc.shardOf(k).readWithin(k, timeout)
+	}
+	after := func(f func(string)) {
+		if timeout > 0 {
+			go f(k)
+		} else {
+			f(k)
+		}
+	}
+	v, found = c.get(k)
+	if found && c.expiresEarly(c.items[k]) {
+		v, found, early = nil, false, true
+	}
+	c.countRead(k, found)
+	slide := found && c.items[k].Sliding > 0
+	refresh := found && !slide && c.needsRefresh(k)
+	_, stale := c.items[k]
+	stale = stale && !found && c.lazyExpiration
+	c.unlockForRead(write)
+	if stale {
+		after(c.deleteIfExpired)
+	}
+	if slide {
+		after(c.slide)
+	}
+	if refresh {
+		after(c.refreshAhead)
+	}
+	return v, found, early, true
+}
+
+// GetWithExpiration ... Get the Data and the time it Expires,
+// the zero time.Time if it never does
+func (c *Cache) GetWithExpiration(k string) (interface{}, time.Time, bool) {
+	k = c.key(k)
+	c, write := c.lockForReadOf(k)
+	defer c.unlockForRead(write)
+	v, found := c.get(k)
+	c.countRead(k, found)
+	if !found {
+		return nil, time.Time{}, false
+	}
+	if e := c.items[k].Expiration; e > 0 {
+		return v, time.Unix(0, e), true
+	}
+	return v, time.Time{}, true
+}
+
+// lockForRead ... Take the lock a read needs, Return whether it is the
+// write lock for unlockForRead
+// Reads update the eviction policies, so a Cache bounded as a whole or
+// in a namespace takes the write lock, unless its policy reads under the
+// read lock as NewClockPolicy does
+// SetMaxEntries and ConfigureNamespace may start a policy between the
+// check and the read lock, so a read that guessed wrong trades its read
+// lock for the write lock
+func (c *Cache) lockForRead() (write bool) {
+	if c.tracksReads.Load() {
+		c.lock()
+		return true
+	}
+	c.rLock()
+	if !c.readsNeedWriteLock() {
+		return false
+	}
+	c.mutex.RUnlock()
+	c.lock()
+	return true
+}
+
+// readsNeedWriteLock ... Report whether a read changes state only the
+// write lock guards: a namespace, an admission policy, Data with a read
+// limit or an eviction policy that is no readLockPolicy
+func (c *Cache) readsNeedWriteLock() bool {
+	if c.namespaces != nil || c.admission != nil || c.readLimited {
+		return true
+	}
+	if c.policy == nil {
+		return false
+	}
+	_, ok := c.policy.(readLockPolicy)
+	return !ok
+}
+
+// unlockForRead ... Release the lock lockForRead took
+// It is not a returned func so the read path does not allocate
+func (c *Cache) unlockForRead(write bool) {
+	if write {
+		c.unlock()
+	} else {
+		c.mutex.RUnlock()
+	}
+}
+
+// get ... Get without taking the lock
+func (c *Cache) get(k string) (interface{}, bool) {
+	if c.admission != nil {
+		c.admission.Record(k)
+	}
 	item, found := c.items[k]
 	if !found {
 		return nil, false
 	}
-	if item.Expired() {
+	if c.expired(item) || c.idleTimeout > 0 && c.isIdle(item, c.now().UnixNano()) {
+		return nil, false
+	}
+	if !c.spend(k, item) {
 		return nil, false
 	}
-	return item.Object, true
+	if c.policy != nil {
+		c.policy.OnGet(k)
+	}
+	if nl := c.namespaceOf(k); nl != nil && nl.policy != nil {
+		nl.policy.OnGet(k)
+	}
+	return c.value(item)
+}
+
+// value ... Return the Data of live item as a read sees it, marking it
+// accessed
+func (c *Cache) value(item Item) (interface{}, bool) {
+	if item.accessed != nil {
+		item.accessed.Store(c.now().UnixNano())
+	}
+	switch item.Object.(type) {
+	case spilled, sealed:
+		v, err := c.reveal(item.Object)
+		return v, err == nil
+	}
+	return c.copyOf(item)
 }
 
 // Add Data if it did not Exist yet
 func (c *Cache) Add(k string, v interface{}, d time.Duration) error {
-	c.mutex.Lock()
-	_, found := c.Get(k)
+	k = c.key(k)
+	c = c.lockFor(k)
+	_, found := c.get(k)
 	if found {
-		c.mutex.Unlock()
-		return fmt.Errorf("item %s already exists", k)
+		c.unlock()
+		return errKeyExists(k)
 	}
-	c.Set(k, v, d)
-	c.mutex.Unlock()
-	return nil
+	err := c.set(k, v, d)
+	c.unlock()
+	return err
 }
 
 func (c *Cache) Replace(k string, v interface{}, d time.Duration) error {
-	c.mutex.Lock()
-	_, found := c.Get(k)
+	k = c.key(k)
+	c = c.lockFor(k)
+	_, found := c.get(k)
 	if !found {
-		c.mutex.Unlock()
-		return fmt.Errorf("Item %s doesnt Exist", k)
+		err := c.missing(k)
+		c.unlock()
+		return err
 	}
-	c.Set(k, v, d)
-	c.mutex.Unlock()
-	return nil
+	err := c.set(k, v, d)
+	c.unlock()
+	return err
+}
+
+// Touch ... Reset the Expiration of existing Data to d from now
+// without replacing it, Return false if it does not Exist
+func (c *Cache) Touch(k string, d time.Duration) bool {
+	k = c.key(k)
+	c = c.lockFor(k)
+	defer c.unlock()
+	if _, found := c.get(k); !found {
+		return false
+	}
+	item := c.items[k]
+	item.Expiration = c.expiration(d)
+	c.update(k, item)
+	return true
 }
 
 //Delete ... obviousely
 func (c *Cache) Delete(k string) {
-	c.mutex.Lock()
+	k = c.key(k)
+	c = c.lockFor(k)
 	c.delete(k)
-	c.mutex.Unlock()
+	inv := c.invalidator
+	c.unlock()
+	publish(inv, c.instanceID, Invalidation{Key: k})
 }
 
-// Save ... Let Cache Write In WriteIO
+// Save ... Let Cache Write In WriteIO, in the format of the Codec after
+// a versioned header naming it
+// Only the copy of the map holds the lock, the encoding runs outside it
+// so Sets go on while a big Cache is written
 func (c *Cache) Save(w io.Writer) (err error) {
-	enc := gob.NewEncoder(w)
-	defer func() {
-		if x := recover(); x != nil {
-			err = fmt.Errorf("Error registering item types with Gob lib")
-		}
-	}()
+	return encodeSnapshot(w, c.codec, c.copyItems())
+}
+
+// copyItems ... Copy the items under the read lock, reading spilled Data
+// back after it is released; Data whose file went away in between was
+// Deleted since and is left out
+func (c *Cache) copyItems() map[string]Item {
 	c.mutex.RLock()
-	defer c.mutex.RUnlock()
-	for _, v := range c.items {
-		gob.Register(v.Object)
+	items := make(map[string]Item, len(c.items))
+	for k, v := range c.items {
+		items[k] = v
+	}
+	c.mutex.RUnlock()
+	for k, v := range items {
+		sp, ok := v.Object.(spilled)
+		if !ok {
+			continue
+		}
+		obj, err := sp.read()
+		if err != nil {
+			delete(items, k)
+			continue
+		}
+		v.Object = obj
+		items[k] = v
 	}
-	err = enc.Encode(&c.items)
-	return
+	return items
 }
 
 //SaveToFile ... obviously Too
+// The file is replaced atomically, checksummed, gzipped if Options.Compress
+// is set and encrypted with WithSnapshotEncryption
 func (c *Cache) SaveToFile(file string) error {
-	f, err := os.Create(file)
-	if err != nil {
-		return err
-	}
-	if err = c.Save(f); err != nil {
-		f.Close()
-		return err
-	}
-	return f.Close()
+	return writeFileAtomic(file, false, sealFile(c.compress, c.snapshotKey, c.Save))
 }
 
 //Load ... Load Data IN ioReader
-// We use gob to deserializatize the data in ioReader
+// We use the Codec (gob by default) to deserializatize the data in ioReader
 // And Find the object with key in ReturnedItem
+// Loaded Data is kept only where the Cache has no live Data, see LoadWithPolicy
 func (c *Cache) Load(r io.Reader) error {
-	dec := gob.NewDecoder(r)
-	items := map[string]Item{}
-	err := dec.Decode(&items)
-	if err == nil {
-		c.mutex.Lock()
-		defer c.mutex.Unlock()
-		for k, v := range items {
-			ov, found := c.items[k]
-			if !found || ov.Expired() {
-				c.items[k] = v
-			}
-		}
-	}
-	return err
+	return c.LoadWithPolicy(r, KeepExisting)
 }
 
-//LoadFile ... Load Cache From File
+// LoadFile ... Load Cache From File, failing with ErrCorruptSnapshot
+// if it was damaged
 func (c *Cache) LoadFile(file string) error {
-	f, err := os.Open(file)
-	if err != nil {
-		return err
-	}
-	if err = c.Load(f); err != nil {
-		f.Close()
-		return err
-	}
-	return f.Close()
+	return readFile(file, openFile(c.snapshotKey, c.Load))
 }
 
 //Count ... Return Number of Data In Cache
+// Expired Data not yet removed by the GC is counted, see CountValid
 func (c *Cache) Count() int {
 	c.mutex.Lock()
-	defer c.mutex.Unlock()
+	defer c.unlock()
 	return len(c.items)
 }
 
 //Flush .. Flush the Cache
 func (c *Cache) Flush() {
 	c.mutex.Lock()
-	defer c.mutex.Unlock()
-	c.items = map[string]Item{}
+	c.flush()
+	inv := c.invalidator
+	c.unlock()
+	publish(inv, c.instanceID, Invalidation{Flush: true})
+}
+
+func (c *Cache) flush() {
+	for k, v := range c.items {
+		object := release(v.Object, c.onEvicted != nil)
+		if c.onEvicted != nil {
+			c.evicted = append(c.evicted, keyValue{k, c.open(object)})
+		}
+	}
+	c.items = make(map[string]Item, c.initialCapacity)
+	c.lockFree.changed()
+	c.totalBytes = 0
+	c.tags = nil
+	c.dependents = nil
+	c.tombstones = nil
+	c.diffFloor = c.versions.Add(1)
+	if c.expirations != nil {
+		c.expirations = newExpirationIndex()
+	}
+	if c.prefixes != nil {
+		c.prefixes = &radixNode{}
+	}
+	if c.policy != nil {
+		c.policy = c.newPolicy()
+	}
+	if c.admission != nil {
+		c.admission = c.newAdmission()
+	}
+	if c.bloom != nil {
+		c.bloom.reset()
+	}
+	for _, nl := range c.namespaces {
+		nl.count, nl.bytes = 0, 0
+		if nl.policy != nil {
+			nl.policy = nl.newPolicy()
+		}
+	}
+	if c.aof != nil {
+		c.aof.flush()
+	}
+	if c.watchers != nil {
+		c.notify(EventFlush, "", nil)
+	}
 }
 
+// StopGc ... Stop the GC goRoutine and, for good, those taking
+// snapshots and syncing the append log; later calls do nothing
+// StartGC can restart the GC alone
 func (c *Cache) StopGc() {
-	c.stopGc <- true
+	c.gc.stop()
+	c.stopOnce.Do(func() { close(c.stopGc) })
 }
 
 //NewCache ... Create a New Cache System And goRoutine
 func NewCache(defaultExpiration, gcInterval time.Duration) *Cache {
+	return NewCacheWithOptions(Options{
+		DefaultExpiration: defaultExpiration,
+		GcInterval:        gcInterval,
+	})
+}
+
+// NewCacheWithOptions ... Create a New Cache System from Options And goRoutine
+func NewCacheWithOptions(opts Options) *Cache {
+	c := newCache(opts)
+	c.snapshot = newSnapshotConfig(opts)
+	if c.snapshot != nil {
+		if err := c.snapshot.restore(c.LoadFile); err != nil {
+			c.warn("snapshot restore failed", "file", c.snapshot.file, "err", err)
+		}
+		go c.snapshot.run(c.clock, c.stopGc, c.SaveToFile, c.logger)
+	}
+	if opts.Invalidator != nil {
+		c.invalidator = opts.Invalidator
+		c.instanceID = newInstanceID()
+		c.unsubscribe = subscribe(c.invalidator, c.instanceID, func(k string) {
+			c.mutex.Lock()
+			c.delete(k)
+			c.unlock()
+		}, func() {
+			c.mutex.Lock()
+			c.flush()
+			c.unlock()
+		})
+	}
+	if opts.AppendLog != "" {
+		c.aofErr = openAppendLog(c, opts.AppendLog)
+		if c.aofErr != nil {
+			c.warn("append log open failed", "file", opts.AppendLog, "err", c.aofErr)
+		}
+		go runAppendLogs(c.clock, opts.LogSyncInterval, opts.LogCompactInterval, c.stopGc, []*Cache{c})
+	}
+	if opts.AutoStopGC {
+		c.gc.run = weakGcLoop(c, c.clock, c.gcReset, (*Cache).GcInterval, (*Cache).gcTick)
+	}
+	if !opts.NoGC {
+		c.gc.start()
+	}
+	return c
+}
+
+// newCache ... Create a Cache without starting its GC goRoutine
+func newCache(opts Options) *Cache {
+	if opts.GcInterval <= 0 {
+		opts.GcInterval = DefaultGcInterval
+	}
 	c := &Cache{
-		defaultExpiration: defaultExpiration,
-		gcInterval:        gcInterval,
-		items:             map[string]Item{},
+		defaultExpiration: opts.DefaultExpiration,
+		gcInterval:        opts.GcInterval,
+		items:             make(map[string]Item, opts.InitialCapacity),
+		versions:          new(atomic.Uint64),
+		initialCapacity:   opts.InitialCapacity,
 		stopGc:            make(chan bool),
+		gcReset:           make(chan time.Duration, 1),
+		onEvicted:         opts.OnEvicted,
+		gcMinInterval:     opts.AdaptiveGcMin,
+		gcMaxInterval:     opts.AdaptiveGcMax,
+		slidingByDefault:  opts.SlidingExpiration,
+		lazyExpiration:    opts.LazyExpiration || opts.NoGC,
+		clock:             opts.Clock,
+	}
+	c.gc.run = c.gcLoop
+	if c.clock == nil {
+		c.clock = SystemClock
+	}
+	c.stats.window = newStatsWindow(c.clock, opts.StatsBucket, opts.StatsBuckets)
+	c.codec = opts.Codec
+	if c.codec == nil {
+		c.codec = GobCodec
+	}
+	c.lockWait.enabled.Store(opts.LockWaitStats)
+	if opts.ExpirationIndex {
+		c.expirations = newExpirationIndex()
+	}
+	c.sweepBatch = opts.SweepBatch
+	c.sweepPause = opts.SweepPause
+	c.persistFile = opts.PersistFile
+	c.compress = opts.Compress
+	c.snapshotKey = opts.SnapshotKey
+	c.overflow = newOverflowConfig(opts)
+	c.maxValueSize, c.oversizePolicy = opts.MaxValueSize, opts.OversizePolicy
+	c.keyFunc = opts.KeyFunc
+	c.hot = newHotKeys(opts.HotKeys)
+	c.breaker = newBreaker(opts, c.clock)
+	c.ttlJitter = opts.TTLJitter
+	c.earlyBeta = opts.EarlyExpiration
+	c.loadBatchSize = opts.LoadBatch
+	c.logger = opts.Logger
+	if opts.BloomKeys > 0 {
+		c.bloom = newBloomFilter(opts.BloomKeys, opts.BloomFalsePositiveRate)
+	}
+	c.minTTL, c.maxTTL = opts.MinTTL, opts.MaxTTL
+	c.idleTimeout = opts.IdleTimeout
+	if c.maxTTL > 0 && c.minTTL > c.maxTTL {
+		c.minTTL = c.maxTTL
+	}
+	c.sizer = opts.Sizer
+	c.cloner = opts.CopyOnRead
+	c.transform = opts.ValueTransform
+	if opts.PrefixIndex {
+		c.prefixes = &radixNode{}
+	}
+	if opts.MaxEntries > 0 {
+		c.maxEntries = opts.MaxEntries
+	}
+	if opts.MaxBytes > 0 {
+		c.maxBytes = opts.MaxBytes
+	}
+	// Kept for SetMaxEntries to bound an unbounded Cache later
+	c.newPolicy = opts.EvictionPolicy
+	if c.newPolicy == nil {
+		c.newPolicy = NewLRUPolicy
+	}
+	if opts.NoLRUPromoteOnGet {
+		c.newPolicy = withoutPromotion(c.newPolicy)
+	}
+	if c.maxEntries > 0 || c.maxBytes > 0 {
+		c.policy = c.newPolicy()
+		if opts.Admission != nil {
+			c.newAdmission = opts.Admission
+			c.admission = c.newAdmission()
+		}
+		c.tracksReads.Store(c.readsNeedWriteLock())
+	}
+	if opts.LockFreeReads {
+		c.lockFree = &readView{}
+		c.lockFree.publish(c)
 	}
-	go c.gcLoop()
 	return c
 }
@@ -0,0 +1,42 @@
+package GoCache
+
+// WithInitialCapacity ... Size the map of the Cache for n Data up front,
+// so filling it does not grow it step by step. Flush sizes the new map
+// the same way
+func WithInitialCapacity(n int) Option {
+	return func(o *Options) { o.InitialCapacity = n }
+}
+
+// Shrink ... Copy the Data into maps sized for what the Cache holds now
+// Go maps never give back the memory of their deleted entries, so after
+// deleting most of a large Cache this is what returns it to the runtime
+// It costs a copy of every Data under the lock
+func (c *Cache) Shrink() {
+	c.lock()
+	defer c.unlock()
+	n := len(c.items)
+	if n < c.initialCapacity {
+		n = c.initialCapacity
+	}
+	items := make(map[string]Item, n)
+	for k, v := range c.items {
+		items[k] = v
+	}
+	c.items = items
+	c.lockFree.changed()
+	if x := c.expirations; x != nil {
+		keys := make(map[string]*expEntry, len(x.keys))
+		for k, e := range x.keys {
+			keys[k] = e
+		}
+		x.keys = keys
+		x.heap = append(expHeap(nil), x.heap...)
+	}
+}
+
+// Shrink ... Shrink every shard, one after the other
+func (sc *ShardedCache) Shrink() {
+	for _, c := range sc.shardSet() {
+		c.Shrink()
+	}
+}
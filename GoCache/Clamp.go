@@ -0,0 +1,26 @@
+package GoCache
+
+import "time"
+
+// WithMaxTTL ... Cap every Expiration at d, NoExpiration included, so no
+// caller can keep Data for longer than that. Zero leaves it uncapped
+func WithMaxTTL(d time.Duration) Option {
+	return func(o *Options) { o.MaxTTL = d }
+}
+
+// WithMinTTL ... Raise every Expiration below d to d, so no caller can
+// Set Data that is gone before it is read. Zero leaves it as is
+func WithMinTTL(d time.Duration) Option {
+	return func(o *Options) { o.MinTTL = d }
+}
+
+// clamp ... Bring a resolved duration within minTTL and maxTTL
+func (c *Cache) clamp(d time.Duration) time.Duration {
+	if c.maxTTL > 0 && (d < 0 || d > c.maxTTL) {
+		return c.maxTTL
+	}
+	if d > 0 && d < c.minTTL {
+		return c.minTTL
+	}
+	return d
+}
@@ -0,0 +1,53 @@
+package GoCache
+
+import "time"
+
+// Clock ... Source of time for Expiration and the GC loop, swap it with
+// WithClock to test Expiration without sleeping, see package clocktest
+type Clock interface {
+	Now() time.Time
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker ... The part of time.Ticker the GC loop uses
+type Ticker interface {
+	C() <-chan time.Time
+	Reset(d time.Duration)
+	Stop()
+}
+
+// SystemClock ... Clock backed by package time, the default
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) NewTicker(d time.Duration) Ticker {
+	return systemTicker{time.NewTicker(d)}
+}
+
+type systemTicker struct {
+	*time.Ticker
+}
+
+func (t systemTicker) C() <-chan time.Time { return t.Ticker.C }
+
+// WithClock ... Read time from clock instead of package time
+func WithClock(clock Clock) Option {
+	return func(o *Options) { o.Clock = clock }
+}
+
+// now ... Return the time of the Clock of the Cache
+func (c *Cache) now() time.Time {
+	return c.clock.Now()
+}
+
+// expired ... Item.Expired by the Clock of the Cache
+func (c *Cache) expired(item Item) bool {
+	if c.paused.Load() {
+		return false
+	}
+	e := item.deadline()
+	return e > 0 && c.clock.Now().UnixNano() > e
+}
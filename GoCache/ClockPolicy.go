@@ -0,0 +1,103 @@
+package GoCache
+
+import "sync/atomic"
+
+// readLockPolicy ... An EvictionPolicy whose OnGet is safe under the
+// read lock of the Cache, from many goRoutines at once, so a Cache using
+// it keeps reads on the read lock. Its other methods still run under the
+// write lock
+type readLockPolicy interface {
+	EvictionPolicy
+	onGetUnderReadLock()
+}
+
+// NewClockPolicy ... Evict with the CLOCK algorithm, second chance LRU:
+// every Data has a reference bit a read sets, and a hand going round
+// the Data evicts the first one whose bit is clear, clearing the bits it
+// passes
+// A read only sets a bit, atomically, so reads of a Cache bounded with
+// it stay on the read lock and go on in parallel like those of an
+// unbounded one. The order is coarser than NewLRUPolicy: Data read once
+// since the hand last passed is as safe as Data read a thousand times.
+// Victim moves the hand, so asking for a victim is not free of effects
+func NewClockPolicy() EvictionPolicy {
+	return &clockPolicy{index: map[string]int{}}
+}
+
+type clockSlot struct {
+	key  string
+	ref  uint32 // Set by reads, atomically
+	used bool   // false for a slot free for reuse
+}
+
+// clockPolicy ... Slots in a ring, Deleted ones are reused by later Sets
+type clockPolicy struct {
+	slots []clockSlot
+	index map[string]int // Slot of each key
+	free  []int
+	hand  int
+}
+
+func (p *clockPolicy) onGetUnderReadLock() {}
+
+func (p *clockPolicy) OnGet(k string) {
+	if i, ok := p.index[k]; ok {
+		if ref := &p.slots[i].ref; atomic.LoadUint32(ref) == 0 {
+			atomic.StoreUint32(ref, 1)
+		}
+	}
+}
+
+func (p *clockPolicy) OnSet(k string) {
+	if i, ok := p.index[k]; ok {
+		p.slots[i].ref = 1
+		return
+	}
+	// New Data starts with a clear bit, it is referenced once read
+	slot := clockSlot{key: k, used: true}
+	if n := len(p.free); n > 0 {
+		i := p.free[n-1]
+		p.free = p.free[:n-1]
+		p.slots[i] = slot
+		p.index[k] = i
+		return
+	}
+	p.index[k] = len(p.slots)
+	p.slots = append(p.slots, slot)
+}
+
+func (p *clockPolicy) OnDelete(k string) {
+	i, ok := p.index[k]
+	if !ok {
+		return
+	}
+	p.slots[i] = clockSlot{}
+	p.free = append(p.free, i)
+	delete(p.index, k)
+	// The hand moves on from the evicted slot, or the next Set, which
+	// reuses it, would be the next victim
+	if i == p.hand {
+		p.hand++
+	}
+}
+
+func (p *clockPolicy) Victim() (string, bool) {
+	if len(p.index) == 0 {
+		return "", false
+	}
+	// Two turns at most: the first clears every bit it passes
+	for n := 0; n < 2*len(p.slots); n++ {
+		if p.hand >= len(p.slots) {
+			p.hand = 0
+		}
+		s := &p.slots[p.hand]
+		if s.used {
+			if s.ref == 0 {
+				return s.key, true
+			}
+			s.ref = 0
+		}
+		p.hand++
+	}
+	return "", false
+}
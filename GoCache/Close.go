@@ -0,0 +1,26 @@
+package GoCache
+
+// Close ... Stop the GC and Invalidations, save to Options.PersistFile if set, take
+// a last snapshot if WithSnapshot is used, close the append log and Flush
+// the Cache so OnEvicted sees every Data it held
+// It is safe to call more than once, later calls return the first result
+func (c *Cache) Close() error {
+	c.closeOnce.Do(func() {
+		c.StopGc()
+		c.stopInvalidations()
+		if c.persistFile != "" {
+			c.closeErr = c.SaveToFile(c.persistFile)
+		}
+		if c.snapshot != nil {
+			if err := c.snapshot.take(c.SaveToFile); c.closeErr == nil {
+				c.closeErr = err
+			}
+		}
+		if err := c.closeLog(); c.closeErr == nil {
+			c.closeErr = err
+		}
+		c.closeExpiredEvents()
+		c.Flush()
+	})
+	return c.closeErr
+}
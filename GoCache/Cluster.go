@@ -0,0 +1,99 @@
+package GoCache
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ClusterCache ... Spread keys over remote cache nodes by consistent
+// hashing, so adding or removing a node only moves the keys of its
+// share of the ring
+type ClusterCache struct {
+	mutex sync.RWMutex
+	nodes map[string]Tier
+	ring  *hashRing
+}
+
+// NewClusterCache ... Create a ClusterCache without nodes, each node
+// AddNode adds gets replicas points on the ring
+func NewClusterCache(replicas int) *ClusterCache {
+	return &ClusterCache{nodes: map[string]Tier{}, ring: newHashRing(replicas)}
+}
+
+// AddNode ... Add node under name, or replace the node of that name
+func (cc *ClusterCache) AddNode(name string, node Tier) {
+	cc.mutex.Lock()
+	defer cc.mutex.Unlock()
+	if _, found := cc.nodes[name]; !found {
+		cc.ring.add(name)
+	}
+	cc.nodes[name] = node
+}
+
+// RemoveNode ... Remove the node of name, its keys move to the others
+func (cc *ClusterCache) RemoveNode(name string) {
+	cc.mutex.Lock()
+	defer cc.mutex.Unlock()
+	if _, found := cc.nodes[name]; found {
+		delete(cc.nodes, name)
+		cc.ring.remove(name)
+	}
+}
+
+// Nodes ... Return the sorted names of the nodes
+func (cc *ClusterCache) Nodes() []string {
+	cc.mutex.RLock()
+	defer cc.mutex.RUnlock()
+	names := make([]string, 0, len(cc.nodes))
+	for name := range cc.nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NodeFor ... Return the name of the node owning k, false without nodes
+func (cc *ClusterCache) NodeFor(k string) (string, bool) {
+	cc.mutex.RLock()
+	defer cc.mutex.RUnlock()
+	return cc.ring.locate(k)
+}
+
+func (cc *ClusterCache) node(k string) (Tier, error) {
+	cc.mutex.RLock()
+	defer cc.mutex.RUnlock()
+	name, ok := cc.ring.locate(k)
+	if !ok {
+		return nil, fmt.Errorf("cluster has no nodes")
+	}
+	return cc.nodes[name], nil
+}
+
+// Get ... Get the Data from the node owning k
+func (cc *ClusterCache) Get(k string) (interface{}, bool, error) {
+	node, err := cc.node(k)
+	if err != nil {
+		return nil, false, err
+	}
+	return node.Get(k)
+}
+
+// Set ... Set the Data on the node owning k
+func (cc *ClusterCache) Set(k string, v interface{}, d time.Duration) error {
+	node, err := cc.node(k)
+	if err != nil {
+		return err
+	}
+	return node.Set(k, v, d)
+}
+
+// Delete ... Delete the Data from the node owning k
+func (cc *ClusterCache) Delete(k string) error {
+	node, err := cc.node(k)
+	if err != nil {
+		return err
+	}
+	return node.Delete(k)
+}
@@ -0,0 +1,60 @@
+package GoCache
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Codec ... Format Save writes and Load reads
+// Expiration, Sliding and Tags of every Item are kept
+type Codec interface {
+	Encode(w io.Writer, items map[string]Item) error
+	Decode(r io.Reader) (map[string]Item, error)
+}
+
+var (
+	// GobCodec ... The default Codec, keeps the Go types of the Data
+	// but they must be gob encodable
+	GobCodec Codec = gobCodec{}
+	// JSONCodec ... Readable by any tool, but Data comes back as the
+	// types encoding/json decodes into: float64, string, bool, nil,
+	// []interface{} and map[string]interface{}
+	JSONCodec Codec = jsonCodec{}
+)
+
+type gobCodec struct{}
+
+// Encode ... Register the types of the Data with gob and encode items
+func (gobCodec) Encode(w io.Writer, items map[string]Item) (err error) {
+	enc := gob.NewEncoder(w)
+	defer func() {
+		if x := recover(); x != nil {
+			err = fmt.Errorf("Error registering item types with Gob lib")
+		}
+	}()
+	for _, v := range items {
+		gob.Register(v.Object)
+	}
+	err = enc.Encode(&items)
+	return
+}
+
+func (gobCodec) Decode(r io.Reader) (map[string]Item, error) {
+	items := map[string]Item{}
+	err := gob.NewDecoder(r).Decode(&items)
+	return items, err
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Encode(w io.Writer, items map[string]Item) error {
+	return json.NewEncoder(w).Encode(items)
+}
+
+func (jsonCodec) Decode(r io.Reader) (map[string]Item, error) {
+	items := map[string]Item{}
+	err := json.NewDecoder(r).Decode(&items)
+	return items, err
+}
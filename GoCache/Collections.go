@@ -0,0 +1,187 @@
+package GoCache
+
+import "sort"
+
+// List ... Data kept by ListPush, a Get of it shares it with the Cache,
+// use ListRange for a copy
+type List []interface{}
+
+// Set ... Data kept by SetAdd, a Get of it shares it with the Cache,
+// use SetMembers for a copy
+type Set map[string]struct{}
+
+// collection ... Return the live item at k and its Data, or a New item
+// with DefaultExpiration and found false, the caller holds the lock
+func (c *Cache) collection(k string) (item Item, v interface{}, found bool) {
+	item, found = c.items[k]
+	if !found || c.expired(item) {
+		return Item{Expiration: c.expiration(DefaultExpiration)}, nil, false
+	}
+	return item, c.open(item.Object), true
+}
+
+// store ... Put the changed collection v back at k, Deleting k if v is
+// empty, the caller holds the lock
+func (c *Cache) store(k string, item Item, v interface{}, n int) {
+	if n == 0 {
+		c.delete(k)
+		return
+	}
+	item.Object = v
+	item.size = 0 // Weigh it again
+	c.put(k, item)
+}
+
+// ListPush ... Append values to the List at k, creating it with
+// DefaultExpiration if missing, and Return its length
+func (c *Cache) ListPush(k string, values ...interface{}) (int, error) {
+	k = c.key(k)
+	c = c.lockFor(k)
+	defer c.unlock()
+	item, v, found := c.collection(k)
+	l, ok := v.(List)
+	if found && !ok {
+		return 0, errNotA(k, "List")
+	}
+	l = append(l, values...)
+	c.store(k, item, l, len(l))
+	return len(l), nil
+}
+
+// ListPop ... Remove and Return the last value of the List at k,
+// Deleting the List once empty
+func (c *Cache) ListPop(k string) (interface{}, error) {
+	return c.listPop(k, true)
+}
+
+// ListPopFront ... Remove and Return the first value of the List at k,
+// Deleting the List once empty
+func (c *Cache) ListPopFront(k string) (interface{}, error) {
+	return c.listPop(k, false)
+}
+
+func (c *Cache) listPop(k string, back bool) (interface{}, error) {
+	k = c.key(k)
+	c = c.lockFor(k)
+	defer c.unlock()
+	item, v, found := c.collection(k)
+	if !found {
+		return nil, c.missing(k)
+	}
+	l, ok := v.(List)
+	if !ok {
+		return nil, errNotA(k, "List")
+	}
+	var x interface{}
+	if back {
+		x, l = l[len(l)-1], l[:len(l)-1]
+	} else {
+		x, l = l[0], l[1:]
+	}
+	c.store(k, item, l, len(l))
+	return x, nil
+}
+
+// ListRange ... Return a copy of the List at k
+func (c *Cache) ListRange(k string) ([]interface{}, error) {
+	k = c.key(k)
+	c = c.lockFor(k)
+	defer c.unlock()
+	_, v, found := c.collection(k)
+	if !found {
+		return nil, c.missing(k)
+	}
+	l, ok := v.(List)
+	if !ok {
+		return nil, errNotA(k, "List")
+	}
+	return append([]interface{}(nil), l...), nil
+}
+
+// SetAdd ... Add members to the Set at k, creating it with
+// DefaultExpiration if missing, and Return how many were New
+func (c *Cache) SetAdd(k string, members ...string) (int, error) {
+	k = c.key(k)
+	c = c.lockFor(k)
+	defer c.unlock()
+	item, v, found := c.collection(k)
+	s, ok := v.(Set)
+	if found && !ok {
+		return 0, errNotA(k, "Set")
+	}
+	if s == nil {
+		s = Set{}
+	}
+	added := 0
+	for _, m := range members {
+		if _, in := s[m]; !in {
+			s[m] = struct{}{}
+			added++
+		}
+	}
+	c.store(k, item, s, len(s))
+	return added, nil
+}
+
+// SetRemove ... Remove members from the Set at k, Return how many were
+// in it, the Set is Deleted once empty
+func (c *Cache) SetRemove(k string, members ...string) (int, error) {
+	k = c.key(k)
+	c = c.lockFor(k)
+	defer c.unlock()
+	item, v, found := c.collection(k)
+	if !found {
+		return 0, nil
+	}
+	s, ok := v.(Set)
+	if !ok {
+		return 0, errNotA(k, "Set")
+	}
+	removed := 0
+	for _, m := range members {
+		if _, in := s[m]; in {
+			delete(s, m)
+			removed++
+		}
+	}
+	c.store(k, item, s, len(s))
+	return removed, nil
+}
+
+// SetIsMember ... Report whether m is in the Set at k
+func (c *Cache) SetIsMember(k, m string) (bool, error) {
+	k = c.key(k)
+	c = c.lockFor(k)
+	defer c.unlock()
+	_, v, found := c.collection(k)
+	if !found {
+		return false, nil
+	}
+	s, ok := v.(Set)
+	if !ok {
+		return false, errNotA(k, "Set")
+	}
+	_, in := s[m]
+	return in, nil
+}
+
+// SetMembers ... Return the members of the Set at k, sorted
+func (c *Cache) SetMembers(k string) ([]string, error) {
+	k = c.key(k)
+	c = c.lockFor(k)
+	defer c.unlock()
+	_, v, found := c.collection(k)
+	if !found {
+		return nil, nil
+	}
+	s, ok := v.(Set)
+	if !ok {
+		return nil, errNotA(k, "Set")
+	}
+	members := make([]string, 0, len(s))
+	for m := range s {
+		members = append(members, m)
+	}
+	sort.Strings(members)
+	return members, nil
+}
@@ -0,0 +1,65 @@
+package GoCache
+
+import (
+	"reflect"
+	"time"
+)
+
+// CompareAndSwap ... Set new with Expiration d only if the live Data
+// at k equals old, Return whether it was swapped: false too if the
+// Cache rejects new, like WithMaxValueSize does
+func (c *Cache) CompareAndSwap(k string, old, new interface{}, d time.Duration) bool {
+	k = c.key(k)
+	c = c.lockFor(k)
+	defer c.unlock()
+	v, found := c.get(k)
+	if !found || !equal(v, old) {
+		return false
+	}
+	return c.set(k, new, d) == nil
+}
+
+// LoadOrStore ... Return the live Data at k with loaded true, or Set v
+// with Expiration d and Return it with loaded false, in one step
+// like sync.Map.LoadOrStore. If the Cache rejects v, like
+// WithMaxValueSize does, actual is nil and the error says why
+func (c *Cache) LoadOrStore(k string, v interface{}, d time.Duration) (actual interface{}, loaded bool, err error) {
+	k = c.key(k)
+	c = c.lockFor(k)
+	defer c.unlock()
+	if old, found := c.get(k); found {
+		c.countRead(k, true)
+		return old, true, nil
+	}
+	c.stats.read(false)
+	if err := c.set(k, v, d); err != nil {
+		return nil, false, err
+	}
+	return v, false, nil
+}
+
+// CompareAndDelete ... Delete the Data at k only if it equals old,
+// Return whether it was deleted
+func (c *Cache) CompareAndDelete(k string, old interface{}) bool {
+	k = c.key(k)
+	c = c.lockFor(k)
+	defer c.unlock()
+	v, found := c.get(k)
+	if !found || !equal(v, old) {
+		return false
+	}
+	c.delete(k)
+	return true
+}
+
+// equal ... Compare with ==, values of types that cannot be compared
+// are never equal instead of panicking
+func equal(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if reflect.TypeOf(a) != reflect.TypeOf(b) || !reflect.TypeOf(a).Comparable() {
+		return false
+	}
+	return a == b
+}
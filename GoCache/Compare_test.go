@@ -0,0 +1,25 @@
+package GoCache
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLoadOrStoreOversize(t *testing.T) {
+	c := New(WithMaxValueSize(64), WithNoGC())
+	big := strings.Repeat("x", 1024)
+	actual, loaded, err := c.LoadOrStore("k", big, NoExpiration)
+	if !errors.Is(err, ErrValueTooLarge) || actual != nil || loaded {
+		t.Fatalf("LoadOrStore of an oversize value = %v, %v, %v, want nil, false, ErrValueTooLarge", actual, loaded, err)
+	}
+	if _, found := c.Get("k"); found {
+		t.Fatal("oversize value rejected by LoadOrStore is in the Cache")
+	}
+	if actual, loaded, err = c.LoadOrStore("k", "small", NoExpiration); err != nil || actual != "small" || loaded {
+		t.Fatalf("LoadOrStore(small) = %v, %v, %v, want small, false, nil", actual, loaded, err)
+	}
+	if actual, loaded, err = c.LoadOrStore("k", big, NoExpiration); err != nil || actual != "small" || !loaded {
+		t.Fatalf("LoadOrStore over stored Data = %v, %v, %v, want small, true, nil", actual, loaded, err)
+	}
+}
@@ -0,0 +1,97 @@
+package GoCache
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// flight ... A loader call in progress for one key
+type flight struct {
+	wg  sync.WaitGroup
+	val interface{}
+	err error
+}
+
+// GetOrCompute ... Get the Data, or on a miss call loader and Set what it
+// returns with Expiration d. Concurrent misses on the same key wait for
+// a single loader call and share its result, errors are not cached
+// The time loader takes is kept as the ComputeCost of the Data
+func (c *Cache) GetOrCompute(k string, loader func() (interface{}, error), d time.Duration) (interface{}, error) {
+	k = c.key(k)
+	v, found, early := c.read(k)
+	if found {
+		return v, nil
+	}
+	return c.load(k, func() (interface{}, error) {
+		// Another flight may have filled k since the miss above, an early
+		// miss found live Data on purpose though
+		if !early {
+			if v, found := c.getKey(k); found {
+				return v, nil
+			}
+		}
+		start := c.now()
+		v, err := c.callLoader(k, loader)
+		if err == nil {
+			c.setWithComputeCost(k, v, d, c.now().Sub(start))
+		} else if circuitOpen(err) {
+			if v, found := c.staleValue(k); found {
+				return v, nil
+			}
+		}
+		return v, err
+	})
+}
+
+// load ... Run fn for k unless a call for k is in progress already,
+// in which case wait for that one and return its result
+func (c *Cache) load(k string, fn func() (interface{}, error)) (interface{}, error) {
+	f, leader := c.beginFlight(k)
+	if !leader {
+		f.wg.Wait()
+		return f.val, f.err
+	}
+	c.runFlight(k, f, fn)
+	return f.val, f.err
+}
+
+// loadAsync ... Run fn for k in a goRoutine unless a call for k is in
+// progress already, without waiting for it
+func (c *Cache) loadAsync(k string, fn func() (interface{}, error)) {
+	f, leader := c.beginFlight(k)
+	if leader {
+		go c.runFlight(k, f, fn)
+	}
+}
+
+// beginFlight ... Return the flight of k, leader is true if the caller
+// created it and must run it
+func (c *Cache) beginFlight(k string) (f *flight, leader bool) {
+	c.flightMutex.Lock()
+	defer c.flightMutex.Unlock()
+	if c.flights == nil {
+		c.flights = map[string]*flight{}
+	}
+	if f, ok := c.flights[k]; ok {
+		return f, false
+	}
+	f = &flight{}
+	f.wg.Add(1)
+	c.flights[k] = f
+	return f, true
+}
+
+// runFlight ... Call fn, record its result in f and release the waiters
+func (c *Cache) runFlight(k string, f *flight, fn func() (interface{}, error)) {
+	defer func() {
+		if x := recover(); x != nil {
+			f.val, f.err = nil, fmt.Errorf("loader of item %s panicked: %v", k, x)
+		}
+		c.flightMutex.Lock()
+		delete(c.flights, k)
+		c.flightMutex.Unlock()
+		f.wg.Done()
+	}()
+	f.val, f.err = fn()
+}
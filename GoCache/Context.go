@@ -0,0 +1,51 @@
+package GoCache
+
+import (
+	"context"
+	"time"
+)
+
+// The Ctx variants fail with ctx.Err() once ctx is done instead of
+// waiting on the lock, loader and remote backed modes can pass ctx on
+
+// GetCtx ... Get the Data unless ctx is done
+func (c *Cache) GetCtx(ctx context.Context, k string) (interface{}, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, false, err
+	}
+	v, found := c.Get(k)
+	return v, found, nil
+}
+
+// SetCtx ... Set the Data unless ctx is done
+func (c *Cache) SetCtx(ctx context.Context, k string, v interface{}, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	c.Set(k, v, d)
+	return nil
+}
+
+// DeleteCtx ... Delete the Data unless ctx is done
+func (c *Cache) DeleteCtx(ctx context.Context, k string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	c.Delete(k)
+	return nil
+}
+
+// GetCtx ... GetCtx in the shard of k
+func (sc *ShardedCache) GetCtx(ctx context.Context, k string) (interface{}, bool, error) {
+	return sc.shard(k).GetCtx(ctx, k)
+}
+
+// SetCtx ... SetCtx in the shard of k
+func (sc *ShardedCache) SetCtx(ctx context.Context, k string, v interface{}, d time.Duration) error {
+	return sc.shard(k).SetCtx(ctx, k, v, d)
+}
+
+// DeleteCtx ... DeleteCtx in the shard of k
+func (sc *ShardedCache) DeleteCtx(ctx context.Context, k string) error {
+	return sc.shard(k).DeleteCtx(ctx, k)
+}
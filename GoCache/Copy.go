@@ -0,0 +1,85 @@
+package GoCache
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"time"
+)
+
+// Cloner ... Return a deep copy of v
+type Cloner func(v interface{}) (interface{}, error)
+
+// GobCloner ... The default Cloner, a gob round trip: Data comes back
+// as its own Go type, but that type must be gob encodable, and unexported
+// fields are lost
+func GobCloner(v interface{}) (interface{}, error) {
+	b, err := gobEncodeValue(v)
+	if err != nil {
+		return nil, err
+	}
+	return gobDecodeValue(b)
+}
+
+// gobEncodeValue ... Encode v with gob, registering its type so it comes
+// back as itself
+func gobEncodeValue(v interface{}) (b []byte, err error) {
+	defer func() {
+		if x := recover(); x != nil {
+			err = fmt.Errorf("Error registering %T with Gob lib", v)
+		}
+	}()
+	gob.Register(v)
+	var buf bytes.Buffer
+	err = gob.NewEncoder(&buf).Encode(&v)
+	return buf.Bytes(), err
+}
+
+// gobDecodeValue ... Decode what gobEncodeValue encoded
+func gobDecodeValue(b []byte) (v interface{}, err error) {
+	err = gob.NewDecoder(bytes.NewReader(b)).Decode(&v)
+	return v, err
+}
+
+// WithCopyOnRead ... Make every read of the Cache Return a copy of the
+// Data made by cloner, GobCloner if nil, so callers cannot change the
+// Data in place. Data that fails to copy reads as missing
+func WithCopyOnRead(cloner Cloner) Option {
+	return func(o *Options) {
+		if cloner == nil {
+			cloner = GobCloner
+		}
+		o.CopyOnRead = cloner
+	}
+}
+
+// SetWithCopyOnRead ... Set Data that every read copies, with the
+// Cloner of WithCopyOnRead or GobCloner
+func (c *Cache) SetWithCopyOnRead(k string, v interface{}, d time.Duration) {
+	k = c.key(k)
+	c = c.lockFor(k)
+	defer c.unlock()
+	item := Item{
+		Object:     v,
+		Expiration: c.expiration(d),
+		copyOnRead: true,
+	}
+	if c.slidingByDefault && item.Expiration > 0 {
+		item.Sliding = c.resolve(d)
+	}
+	c.put(k, item)
+}
+
+// copyOf ... Return what a read of item hands out: a copy if it or the
+// Cache asks for it, and whether that worked
+func (c *Cache) copyOf(item Item) (interface{}, bool) {
+	if c.cloner == nil && !item.copyOnRead {
+		return item.Object, true
+	}
+	cloner := c.cloner
+	if cloner == nil {
+		cloner = GobCloner
+	}
+	v, err := cloner(item.Object)
+	return v, err == nil
+}
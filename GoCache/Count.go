@@ -0,0 +1,20 @@
+package GoCache
+
+// CountValid ... Return Number of live Data In Cache, leaving out the
+// Expired Data Count still includes. It walks only the Expired Data
+// with Options.ExpirationIndex and the whole Cache without
+func (c *Cache) CountValid() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	now := c.now().UnixNano()
+	if c.expirations != nil {
+		return len(c.items) - c.expirations.countDue(now)
+	}
+	n := 0
+	for _, item := range c.items {
+		if e := item.deadline(); e <= 0 || now <= e {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,57 @@
+package GoCache
+
+import "time"
+
+// SetWithDeps ... Set Data derived from the Data at deps: Deleting,
+// Expiring, Evicting or Setting again any of them Deletes it too, and so
+// on down its own dependents. deps need not Exist yet. Each shard of a
+// ShardedCache keeps its own dependencies, so there, through WithDeps,
+// only deps in the shard of k are followed
+func (c *Cache) SetWithDeps(k string, v interface{}, d time.Duration, deps ...string) {
+	k = c.key(k)
+	c = c.lockFor(k)
+	defer c.unlock()
+	item := c.newItem(v, d)
+	item.Deps = deps
+	c.put(k, item)
+}
+
+// dependOn ... File k as a dependent of deps
+func (c *Cache) dependOn(k string, deps []string) {
+	for _, dep := range deps {
+		if c.dependents == nil {
+			c.dependents = map[string]map[string]struct{}{}
+		}
+		if c.dependents[dep] == nil {
+			c.dependents[dep] = map[string]struct{}{}
+		}
+		c.dependents[dep][k] = struct{}{}
+	}
+}
+
+// undepend ... Take k out of the dependents of deps
+func (c *Cache) undepend(k string, deps []string) {
+	for _, dep := range deps {
+		delete(c.dependents[dep], k)
+		if len(c.dependents[dep]) == 0 {
+			delete(c.dependents, dep)
+		}
+	}
+}
+
+// invalidateDependents ... Delete the Data depending on k, the caller
+// holds the lock. A cycle ends at the first key already gone
+func (c *Cache) invalidateDependents(k string) {
+	deps := c.dependents[k]
+	if len(deps) == 0 {
+		return
+	}
+	// Each remove undepends, so walk a copy
+	keys := make([]string, 0, len(deps))
+	for dep := range deps {
+		keys = append(keys, dep)
+	}
+	for _, dep := range keys {
+		c.remove(dep, EventDelete)
+	}
+}
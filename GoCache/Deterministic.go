@@ -0,0 +1,78 @@
+package GoCache
+
+import (
+	"encoding/gob"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// SaveDeterministic ... Save with the Data in key order, so the same
+// Data always gives the same bytes, for golden files and content
+// addressed storage
+// With GobCodec the stream names the gob-sorted format, which Load reads
+// like any other but builds from before it cannot. JSONCodec sorts by
+// itself, a custom Codec is used as is and must keep a stable order
+func (c *Cache) SaveDeterministic(w io.Writer) error {
+	return encodeSnapshot(w, deterministic(c.codec), c.copyItems())
+}
+
+// SaveDeterministic ... Save all shards with the Data in key order, like
+// Cache.SaveDeterministic
+func (sc *ShardedCache) SaveDeterministic(w io.Writer) error {
+	items := map[string]Item{}
+	for _, c := range sc.shardSet() {
+		for k, v := range c.copyItems() {
+			items[k] = v
+		}
+	}
+	return encodeSnapshot(w, deterministic(sc.shardSet()[0].codec), items)
+}
+
+// deterministic ... Return the Codec encoding like codec in key order
+func deterministic(codec Codec) Codec {
+	if codec == GobCodec {
+		return sortedGobCodec{}
+	}
+	return codec
+}
+
+// sortedGobCodec ... Gob of the items as a slice sorted by key, since
+// gob writes a map in the random order of its range
+type sortedGobCodec struct{}
+
+type sortedEntry struct {
+	Key  string
+	Item Item
+}
+
+func (sortedGobCodec) Name() string { return "gob-sorted" }
+
+func (sortedGobCodec) Encode(w io.Writer, items map[string]Item) (err error) {
+	entries := make([]sortedEntry, 0, len(items))
+	for k, v := range items {
+		entries = append(entries, sortedEntry{k, v})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	defer func() {
+		if x := recover(); x != nil {
+			err = fmt.Errorf("Error registering item types with Gob lib")
+		}
+	}()
+	for _, e := range entries {
+		gob.Register(e.Item.Object)
+	}
+	return gob.NewEncoder(w).Encode(entries)
+}
+
+func (sortedGobCodec) Decode(r io.Reader) (map[string]Item, error) {
+	var entries []sortedEntry
+	if err := gob.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, err
+	}
+	items := make(map[string]Item, len(entries))
+	for _, e := range entries {
+		items[e.Key] = e.Item
+	}
+	return items, nil
+}
@@ -0,0 +1,176 @@
+package GoCache
+
+// SnapshotID ... A version of the Data of a Cache, every change takes
+// the next one
+type SnapshotID uint64
+
+// Removals a Cache remembers for Diff, past them it forgets them all
+// and older Diffs Reset
+const maxTombstones = 1 << 16
+
+// Diff ... The changes of a Cache from one SnapshotID to another
+type Diff struct {
+	From SnapshotID
+	To   SnapshotID // Pass it to the next Diff
+	// Reset ... From is older than the Cache remembers, or it was
+	// Flushed since: the receiver drops all its Data before Set
+	Reset   bool
+	Set     map[string]Item // Data Set or changed, as Items returns it
+	Deleted []string        // Keys Deleted or Evicted
+}
+
+// Version ... Return the SnapshotID of the last change
+func (c *Cache) Version() SnapshotID {
+	return SnapshotID(c.versions.Load())
+}
+
+// Snapshot ... Return the live Data, as Items does, and the SnapshotID
+// it is consistent with, to start Diffs from
+func (c *Cache) Snapshot() (map[string]Item, SnapshotID) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.liveItems(), c.Version()
+}
+
+// Diff ... Return the changes since the SnapshotID of a Snapshot or of
+// an earlier Diff. Expired Data is not reported as Deleted, the
+// receiver Expires it by the Expiration it got with it
+func (c *Cache) Diff(since SnapshotID) Diff {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	d := Diff{From: since, To: c.Version(), Set: map[string]Item{}}
+	c.diff(&d)
+	return d
+}
+
+// diff ... Add the changes of the Cache since d.From to d, the caller
+// holds the lock
+func (c *Cache) diff(d *Diff) {
+	if uint64(d.From) < c.diffFloor {
+		d.Reset = true
+		for k, v := range c.liveItems() {
+			d.Set[k] = v
+		}
+		return
+	}
+	for k, v := range c.items {
+		if v.version > uint64(d.From) {
+			d.Set[k] = c.view(v)
+		}
+	}
+	for k, at := range c.tombstones {
+		if at > uint64(d.From) {
+			d.Deleted = append(d.Deleted, k)
+		}
+	}
+}
+
+// ApplyDiff ... Bring the Cache, a standby of the one d comes from, up
+// to the To of d
+func (c *Cache) ApplyDiff(d Diff) {
+	c.lock()
+	defer c.unlock()
+	if d.Reset {
+		c.flush()
+	}
+	for _, k := range d.Deleted {
+		c.delete(k)
+	}
+	for k, item := range d.Set {
+		c.put(k, item)
+	}
+}
+
+// liveItems ... Items without the lock
+func (c *Cache) liveItems() map[string]Item {
+	items := make(map[string]Item, len(c.items))
+	for k, v := range c.items {
+		if !c.expired(v) {
+			items[k] = c.view(v)
+		}
+	}
+	return items
+}
+
+// changed ... Give item at k the next SnapshotID
+func (c *Cache) changed(k string, item *Item) {
+	item.version = c.versions.Add(1)
+	delete(c.tombstones, k)
+}
+
+// removed ... Remember the removal of k for Diff, unless it Expired
+func (c *Cache) removed(k string, why EventType) {
+	if why == EventExpire {
+		return
+	}
+	if len(c.tombstones) >= maxTombstones {
+		c.tombstones = nil
+		c.diffFloor = c.versions.Add(1)
+	}
+	if c.tombstones == nil {
+		c.tombstones = map[string]uint64{}
+	}
+	c.tombstones[k] = c.versions.Add(1)
+}
+
+// Version ... Return the SnapshotID of the last change in any shard
+func (sc *ShardedCache) Version() SnapshotID {
+	return sc.shardSet()[0].Version()
+}
+
+// Snapshot ... Return the live Data of all shards and the SnapshotID it
+// is consistent with, holding every shard still while it copies
+func (sc *ShardedCache) Snapshot() (map[string]Item, SnapshotID) {
+	shards := sc.shardSet()
+	for _, c := range shards {
+		c.mutex.RLock()
+	}
+	defer rUnlockAll(shards)
+	items := map[string]Item{}
+	for _, c := range shards {
+		for k, v := range c.liveItems() {
+			items[k] = v
+		}
+	}
+	return items, sc.Version()
+}
+
+// Diff ... Return the changes of all shards since a SnapshotID, see
+// Cache.Diff
+func (sc *ShardedCache) Diff(since SnapshotID) Diff {
+	shards := sc.shardSet()
+	for _, c := range shards {
+		c.mutex.RLock()
+	}
+	defer rUnlockAll(shards)
+	d := Diff{From: since, To: sc.Version(), Set: map[string]Item{}}
+	for _, c := range shards {
+		c.diff(&d)
+	}
+	return d
+}
+
+// ApplyDiff ... Bring the ShardedCache up to the To of d, shard by shard
+func (sc *ShardedCache) ApplyDiff(d Diff) {
+	shards := sc.shardSet()
+	parts := make(map[*Cache]*Diff, len(shards))
+	for _, c := range shards {
+		parts[c] = &Diff{From: d.From, To: d.To, Reset: d.Reset, Set: map[string]Item{}}
+	}
+	for _, k := range d.Deleted {
+		p := parts[shardIn(shards, sc.hasher, sc.key(k))]
+		p.Deleted = append(p.Deleted, k)
+	}
+	for k, item := range d.Set {
+		parts[shardIn(shards, sc.hasher, sc.key(k))].Set[k] = item
+	}
+	for _, c := range shards {
+		c.ApplyDiff(*parts[c])
+	}
+}
+
+func rUnlockAll(shards []*Cache) {
+	for _, c := range shards {
+		c.mutex.RUnlock()
+	}
+}
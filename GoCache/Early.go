@@ -0,0 +1,55 @@
+package GoCache
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// WithEarlyExpiration ... Let Get miss on Data before it Expires, with a
+// chance that grows as its Expiration nears, faster for Data with a
+// higher ComputeCost: the XFetch algorithm. One caller then recomputes
+// it early while the others are still served, instead of all of them
+// reloading at once when it Expires
+// beta scales how early, 1 is the usual choice, zero turns it off
+// Only Data with a ComputeCost takes part, see GetOrCompute and
+// SetWithComputeCost
+func WithEarlyExpiration(beta float64) Option {
+	return func(o *Options) { o.EarlyExpiration = beta }
+}
+
+// SetWithComputeCost ... Set the Data along with the time it took to
+// compute, for WithEarlyExpiration
+func (c *Cache) SetWithComputeCost(k string, v interface{}, d, cost time.Duration) {
+	c.setWithComputeCost(c.key(k), v, d, cost)
+}
+
+// setWithComputeCost ... SetWithComputeCost of a key in canonical form
+func (c *Cache) setWithComputeCost(k string, v interface{}, d, cost time.Duration) {
+	c = c.lockFor(k)
+	defer c.unlock()
+	item := Item{
+		Object:      v,
+		Expiration:  c.expiration(d),
+		ComputeCost: cost,
+	}
+	if c.slidingByDefault && item.Expiration > 0 {
+		item.Sliding = c.resolve(d)
+	}
+	c.put(k, item)
+}
+
+// SetWithComputeCost ... Set the Data and its ComputeCost in its shard
+func (sc *ShardedCache) SetWithComputeCost(k string, v interface{}, d, cost time.Duration) {
+	sc.shard(k).SetWithComputeCost(k, v, d, cost)
+}
+
+// expiresEarly ... Roll whether a read of live item misses early:
+// now - cost * beta * ln(rand) reaching its Expiration
+func (c *Cache) expiresEarly(item Item) bool {
+	if c.earlyBeta <= 0 || item.ComputeCost <= 0 || item.deadline() <= 0 {
+		return false
+	}
+	gap := -float64(item.ComputeCost) * c.earlyBeta * math.Log(1-rand.Float64())
+	return float64(c.now().UnixNano())+gap >= float64(item.Expiration)
+}
@@ -0,0 +1,71 @@
+package GoCache
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors the Cache returns wrapped with the key, test for them with errors.Is
+var (
+	// ErrKeyNotFound ... No live Data at the key; errors.Is also matches
+	// it for ErrExpired
+	ErrKeyNotFound = errors.New("key not found")
+	// ErrKeyExists ... Live Data at the key already, from Add
+	ErrKeyExists = errors.New("key already exists")
+	// ErrExpired ... The Data at the key Expired and was not removed yet
+	ErrExpired error = expiredError{}
+	// ErrTypeMismatch ... The Data at the key is not of the type asked for
+	ErrTypeMismatch = errors.New("type mismatch")
+	// ErrValueTooLarge ... The Data is over WithMaxValueSize
+	ErrValueTooLarge = errors.New("value too large")
+	// ErrCircuitOpen ... The loader of the key failed too often of late,
+	// see WithCircuitBreaker
+	ErrCircuitOpen = errors.New("circuit open")
+	// ErrLockTimeout ... The lock of the Cache was not had in time, see
+	// TryGet
+	ErrLockTimeout = errors.New("lock timeout")
+)
+
+type expiredError struct{}
+
+func (expiredError) Error() string { return "key expired" }
+
+// Is ... An Expired key is not found too
+func (expiredError) Is(target error) bool { return target == ErrKeyNotFound }
+
+// GetE ... Get the Data, or an error matching ErrKeyNotFound if there is
+// none: ErrExpired when Expired Data is still In Cache
+// With lazy Expiration the Get removes it first, reporting ErrKeyNotFound
+func (c *Cache) GetE(k string) (interface{}, error) {
+	k = c.key(k)
+	if v, found := c.getKey(k); found {
+		return v, nil
+	}
+	c = c.rLockFor(k)
+	defer c.mutex.RUnlock()
+	return nil, c.missing(k)
+}
+
+// GetE ... GetE in the shard of k
+func (sc *ShardedCache) GetE(k string) (interface{}, error) {
+	return sc.shard(k).GetE(k)
+}
+
+// missing ... The error for a key without live Data, the caller holds
+// the lock
+func (c *Cache) missing(k string) error {
+	if item, found := c.items[k]; found && c.expired(item) {
+		return fmt.Errorf("item %s: %w", k, ErrExpired)
+	}
+	return fmt.Errorf("item %s: %w", k, ErrKeyNotFound)
+}
+
+// errKeyExists ... The error Add returns for k
+func errKeyExists(k string) error {
+	return fmt.Errorf("item %s: %w", k, ErrKeyExists)
+}
+
+// errNotA ... The error for Data at k that is not a kind
+func errNotA(k, kind string) error {
+	return fmt.Errorf("item %s is not a %s: %w", k, kind, ErrTypeMismatch)
+}
@@ -0,0 +1,43 @@
+package GoCache
+
+type keyValue struct {
+	key   string
+	value interface{}
+}
+
+// OnEvicted ... Set a function called with the key and value of Data
+// removed by Delete, DeleteExpired, Flush or eviction, nil turns it off
+// It runs after the Cache lock is released, so it may use the Cache
+func (c *Cache) OnEvicted(f func(string, interface{})) {
+	c.mutex.Lock()
+	defer c.unlock()
+	c.onEvicted = f
+}
+
+// unlock ... Delete the Data out of reads, release the write lock, then
+// pass the Data removed while it was held to the OnExpired callbacks, the ExpiredEvents
+// channels and onEvicted
+func (c *Cache) unlock() {
+	if c.spent != nil {
+		c.removeSpent()
+	}
+	evicted, f := c.evicted, c.onEvicted
+	expired := c.expiredCalls
+	items, subs := c.expiredItems, c.expiredSubs
+	c.evicted, c.expiredCalls, c.expiredItems = nil, nil, nil
+	if c.lockFree != nil && c.movedTo == nil {
+		c.lockFree.publish(c)
+	}
+	c.mutex.Unlock()
+	for _, call := range expired {
+		call.f(call.kv.key, call.kv.value)
+	}
+	for _, item := range items {
+		for _, s := range subs {
+			s.send(item)
+		}
+	}
+	for _, kv := range evicted {
+		f(kv.key, kv.value)
+	}
+}
@@ -0,0 +1,142 @@
+package GoCache
+
+import (
+	"sort"
+	"sync/atomic"
+)
+
+// VictimLister ... An EvictionPolicy that can tell its next victims,
+// what Victim would return were they evicted one after another, without
+// evicting them or changing its state. The policies of this package all
+// are; EvictionOrder is empty for one that is not
+type VictimLister interface {
+	Victims(n int) []string
+}
+
+// EvictionOrder ... Return the next n keys the eviction policy would
+// evict, first to go first, without evicting them: the LRU tail, the
+// least used keys, where the CLOCK hand stops next. For debugging why
+// Data was evicted, as reads and writes change the order. It is empty
+// for an unbounded Cache, whose Data is never evicted. Namespaces with
+// their own bounds evict by their own order, not this one
+func (c *Cache) EvictionOrder(n int) []string {
+	if n <= 0 {
+		return nil
+	}
+	c.rLock()
+	defer c.mutex.RUnlock()
+	if l, ok := c.policy.(VictimLister); ok {
+		return l.Victims(n)
+	}
+	return nil
+}
+
+// EvictionOrder ... The EvictionOrder of the shards interleaved, the
+// first victim of every shard, then the second: each shard evicts on its
+// own when it is full, so which one goes first depends on the Sets
+func (sc *ShardedCache) EvictionOrder(n int) []string {
+	if n <= 0 {
+		return nil
+	}
+	shards := sc.shardSet()
+	orders := make([][]string, len(shards))
+	for i, c := range shards {
+		orders[i] = c.EvictionOrder(n)
+	}
+	var keys []string
+	for rank := 0; rank < n && len(keys) < n; rank++ {
+		for _, order := range orders {
+			if rank < len(order) && len(keys) < n {
+				keys = append(keys, order[rank])
+			}
+		}
+	}
+	return keys
+}
+
+func (p *lruPolicy) Victims(n int) []string {
+	var keys []string
+	for e := p.ll.Back(); e != nil && len(keys) < n; e = e.Prev() {
+		keys = append(keys, e.Value.(string))
+	}
+	return keys
+}
+
+func (p *lfuPolicy) Victims(n int) []string {
+	entries := make(lfuHeap, len(p.heap))
+	copy(entries, p.heap)
+	sort.Slice(entries, entries.Less)
+	if len(entries) > n {
+		entries = entries[:n]
+	}
+	keys := make([]string, len(entries))
+	for i, e := range entries {
+		keys[i] = e.key
+	}
+	return keys
+}
+
+// Victims ... Turn the hand over a copy of the bits, as Victim and
+// OnDelete of each victim would
+func (p *clockPolicy) Victims(n int) []string {
+	refs := make([]uint32, len(p.slots))
+	used := make([]bool, len(p.slots))
+	for i := range p.slots {
+		refs[i] = atomic.LoadUint32(&p.slots[i].ref)
+		used[i] = p.slots[i].used
+	}
+	var keys []string
+	hand, left := p.hand, len(p.index)
+	for len(keys) < n && left > 0 {
+		victim := -1
+		for turn := 0; turn < 2*len(used) && victim < 0; turn++ {
+			if hand >= len(used) {
+				hand = 0
+			}
+			if used[hand] && refs[hand] == 0 {
+				victim = hand
+			} else {
+				refs[hand] = 0
+			}
+			hand++
+		}
+		if victim < 0 {
+			break
+		}
+		keys = append(keys, p.slots[victim].key)
+		used[victim] = false
+		left--
+	}
+	return keys
+}
+
+// Victims ... The least recently used keys: Victim picks the oldest of
+// a random sample, so this is the order it comes close to
+func (p *sampledPolicy) Victims(n int) []string {
+	entries := make([]sampledEntry, len(p.entries))
+	copy(entries, p.entries)
+	sort.Slice(entries, func(i, j int) bool { return entries[i].used < entries[j].used })
+	if len(entries) > n {
+		entries = entries[:n]
+	}
+	keys := make([]string, len(entries))
+	for i, e := range entries {
+		keys[i] = e.key
+	}
+	return keys
+}
+
+func (p *priorityPolicy) Victims(n int) []string {
+	var keys []string
+	for _, pr := range p.priorities {
+		l, ok := p.levels[pr].(VictimLister)
+		if !ok {
+			break
+		}
+		keys = append(keys, l.Victims(n-len(keys))...)
+		if len(keys) == n {
+			break
+		}
+	}
+	return keys
+}
@@ -0,0 +1,85 @@
+package GoCache
+
+import "container/heap"
+
+// expEntry ... A key due to Expire at the given UnixNano
+type expEntry struct {
+	key   string
+	at    int64
+	index int
+}
+
+// expirationIndex ... Min heap of the Data that can Expire, so a GC
+// sweep only looks at the Data that is due instead of the whole map
+type expirationIndex struct {
+	heap expHeap
+	keys map[string]*expEntry
+}
+
+func newExpirationIndex() *expirationIndex {
+	return &expirationIndex{keys: map[string]*expEntry{}}
+}
+
+// set ... File k as Expiring at, zero takes it out
+func (x *expirationIndex) set(k string, at int64) {
+	e, ok := x.keys[k]
+	switch {
+	case at <= 0 && ok:
+		heap.Remove(&x.heap, e.index)
+		delete(x.keys, k)
+	case at <= 0:
+	case ok:
+		e.at = at
+		heap.Fix(&x.heap, e.index)
+	default:
+		e = &expEntry{key: k, at: at}
+		x.keys[k] = e
+		heap.Push(&x.heap, e)
+	}
+}
+
+// peek ... Return the entry Expiring first
+func (x *expirationIndex) peek() (*expEntry, bool) {
+	if len(x.heap) == 0 {
+		return nil, false
+	}
+	return x.heap[0], true
+}
+
+type expHeap []*expEntry
+
+func (h expHeap) Len() int           { return len(h) }
+func (h expHeap) Less(i, j int) bool { return h[i].at < h[j].at }
+func (h expHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+func (h *expHeap) Push(x interface{}) {
+	e := x.(*expEntry)
+	e.index = len(*h)
+	*h = append(*h, e)
+}
+func (h *expHeap) Pop() interface{} {
+	old := *h
+	e := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return e
+}
+
+// countDue ... Return the number of keys due before now, walking only
+// the part of the heap that is due
+func (x *expirationIndex) countDue(now int64) int {
+	n := 0
+	stack := []int{0}
+	for len(stack) > 0 {
+		i := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if i >= len(x.heap) || x.heap[i].at >= now {
+			continue
+		}
+		n++
+		stack = append(stack, 2*i+1, 2*i+2)
+	}
+	return n
+}
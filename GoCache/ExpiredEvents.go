@@ -0,0 +1,162 @@
+package GoCache
+
+import (
+	"sync"
+	"time"
+)
+
+// OverflowPolicy ... What an ExpiredEvents channel does when it is full
+type OverflowPolicy int
+
+const (
+	// DropOldest ... Discard the oldest unread ExpiredItem to make room,
+	// so expiring never waits on the reader
+	DropOldest OverflowPolicy = iota
+	// Block ... Wait for the reader, so no ExpiredItem is lost but the GC,
+	// or the call that found the Data Expired, stalls behind a slow reader
+	Block
+)
+
+// ExpiredItem ... Data removed by the GC or lazy Expiration
+type ExpiredItem struct {
+	Key       string
+	Value     interface{}
+	ExpiredAt time.Time // Expiration of the Data, not when it was removed
+}
+
+// expiredSub ... One ExpiredEvents channel
+type expiredSub struct {
+	mutex  sync.RWMutex // Held for reading by senders, for writing by close
+	items  chan ExpiredItem
+	policy OverflowPolicy
+	done   chan struct{} // Closed first, so a Blocked sender gives up
+}
+
+// ExpiredEvents ... Return a channel of the Data Expired from now on,
+// holding up to buffer items, at least 1, and full by policy. Items are
+// sent after the lock is released, in the order they Expired. The
+// channel is closed by Close
+func (c *Cache) ExpiredEvents(buffer int, policy OverflowPolicy) <-chan ExpiredItem {
+	if buffer < 1 {
+		buffer = 1
+	}
+	s := &expiredSub{
+		items:  make(chan ExpiredItem, buffer),
+		policy: policy,
+		done:   make(chan struct{}),
+	}
+	c.lock()
+	c.expiredSubs = append(c.expiredSubs, s)
+	c.unlock()
+	return s.items
+}
+
+// send ... Deliver item by the policy of s, dropped once s is closed
+func (s *expiredSub) send(item ExpiredItem) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	select {
+	case <-s.done:
+		return
+	default:
+	}
+	if s.policy == Block {
+		select {
+		case s.items <- item:
+		case <-s.done:
+		}
+		return
+	}
+	for {
+		select {
+		case s.items <- item:
+			return
+		default:
+		}
+		select {
+		case <-s.items:
+		default:
+		}
+	}
+}
+
+func (s *expiredSub) close() {
+	close(s.done)
+	s.mutex.Lock()
+	close(s.items)
+	s.mutex.Unlock()
+}
+
+// queueExpired ... Keep the Expired Data at k for unlock to send to the
+// ExpiredEvents channels, the caller holds the lock
+func (c *Cache) queueExpired(k string, item Item) {
+	if len(c.expiredSubs) == 0 {
+		return
+	}
+	c.expiredItems = append(c.expiredItems, ExpiredItem{
+		Key:       k,
+		Value:     c.open(item.Object),
+		ExpiredAt: time.Unix(0, item.Expiration),
+	})
+}
+
+// closeExpiredEvents ... Close every ExpiredEvents channel
+func (c *Cache) closeExpiredEvents() {
+	c.lock()
+	subs := c.expiredSubs
+	c.expiredSubs = nil
+	c.unlock()
+	for _, s := range subs {
+		s.close()
+	}
+}
+
+// ExpiredEvents ... Return one channel of the Data Expired in all shards,
+// see Cache.ExpiredEvents. Each shard feeds it in its own goRoutine, so
+// the order holds within a shard only. The channel is closed by Close
+func (sc *ShardedCache) ExpiredEvents(buffer int, policy OverflowPolicy) <-chan ExpiredItem {
+	if buffer < 1 {
+		buffer = 1
+	}
+	out := &expiredSub{
+		items:  make(chan ExpiredItem, buffer),
+		policy: policy,
+		done:   make(chan struct{}),
+	}
+	// Reshard feeds out from the shards it makes, so it must not swap
+	// them before out is listed
+	sc.resharding.Lock()
+	defer sc.resharding.Unlock()
+	sc.mutex.Lock()
+	sc.expiredSubs = append(sc.expiredSubs, out)
+	sc.mutex.Unlock()
+	feedExpired(out, sc.shardSet())
+	return out.items
+}
+
+// feedExpired ... Send the Data Expired in shards to out
+func feedExpired(out *expiredSub, shards []*Cache) {
+	for _, c := range shards {
+		items := c.ExpiredEvents(1, Block)
+		go func() {
+			for item := range items {
+				out.send(item)
+			}
+		}()
+	}
+}
+
+// closeExpiredEvents ... Close every ExpiredEvents channel, then those of
+// the shards, which ends the goRoutines feeding them
+func (sc *ShardedCache) closeExpiredEvents() {
+	sc.mutex.Lock()
+	subs := sc.expiredSubs
+	sc.expiredSubs = nil
+	sc.mutex.Unlock()
+	for _, s := range subs {
+		s.close()
+	}
+	for _, c := range sc.shardSet() {
+		c.closeExpiredEvents()
+	}
+}
@@ -0,0 +1,121 @@
+package GoCache
+
+import (
+	"sort"
+	"time"
+)
+
+// NextExpiration ... Return the live key to Expire first and when, ok
+// false if no live Data Expires. Schedulers refresh it before at. It
+// walks only the Expired Data with Options.ExpirationIndex and the
+// whole Cache without
+func (c *Cache) NextExpiration() (key string, at time.Time, ok bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	due := c.expiring(0, true)
+	if len(due) == 0 {
+		return "", time.Time{}, false
+	}
+	return due[0].key, time.Unix(0, due[0].at), true
+}
+
+// ExpiringWithin ... Return the live keys due to Expire in the next d,
+// first to Expire first, so they can be refreshed before they lapse.
+// Like NextExpiration it is cheap with Options.ExpirationIndex
+func (c *Cache) ExpiringWithin(d time.Duration) []string {
+	if d < 0 {
+		return nil
+	}
+	c.mutex.RLock()
+	due := c.expiring(d, false)
+	c.mutex.RUnlock()
+	return expiringKeys(due)
+}
+
+// expiring ... The live Data Expiring within d sorted by when, or only
+// the first of it with first. The caller holds the lock
+func (c *Cache) expiring(d time.Duration, first bool) []expEntry {
+	now := c.now().UnixNano()
+	until := now + int64(d)
+	var due []expEntry
+	if x := c.expirations; x != nil {
+		// Children of the heap Expire no sooner than their parent: below a
+		// live entry there is no sooner one, below one past until nothing
+		stack := []int{0}
+		for len(stack) > 0 {
+			i := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if i >= len(x.heap) {
+				continue
+			}
+			e := x.heap[i]
+			if e.at < now {
+				stack = append(stack, 2*i+1, 2*i+2)
+				continue
+			}
+			if first || e.at <= until {
+				due = append(due, expEntry{key: e.key, at: e.at})
+			}
+			if !first && e.at <= until {
+				stack = append(stack, 2*i+1, 2*i+2)
+			}
+		}
+	} else {
+		for k, item := range c.items {
+			if e := item.deadline(); e > 0 && e >= now && (first || e <= until) {
+				due = append(due, expEntry{key: k, at: e})
+			}
+		}
+	}
+	sortExpiring(due)
+	if first && len(due) > 1 {
+		due = due[:1]
+	}
+	return due
+}
+
+func sortExpiring(due []expEntry) {
+	sort.Slice(due, func(i, j int) bool {
+		if due[i].at != due[j].at {
+			return due[i].at < due[j].at
+		}
+		return due[i].key < due[j].key
+	})
+}
+
+func expiringKeys(due []expEntry) []string {
+	if len(due) == 0 {
+		return nil
+	}
+	keys := make([]string, len(due))
+	for i, e := range due {
+		keys[i] = e.key
+	}
+	return keys
+}
+
+// NextExpiration ... The soonest NextExpiration of the shards
+func (sc *ShardedCache) NextExpiration() (key string, at time.Time, ok bool) {
+	for _, c := range sc.shardSet() {
+		if k, t, found := c.NextExpiration(); found && (!ok || t.Before(at) || t.Equal(at) && k < key) {
+			key, at, ok = k, t, true
+		}
+	}
+	return key, at, ok
+}
+
+// ExpiringWithin ... ExpiringWithin of the shards put together, first
+// to Expire first
+func (sc *ShardedCache) ExpiringWithin(d time.Duration) []string {
+	if d < 0 {
+		return nil
+	}
+	var due []expEntry
+	for _, c := range sc.shardSet() {
+		c.mutex.RLock()
+		due = append(due, c.expiring(d, false)...)
+		c.mutex.RUnlock()
+	}
+	sortExpiring(due)
+	return expiringKeys(due)
+}
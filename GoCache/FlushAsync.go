@@ -0,0 +1,104 @@
+package GoCache
+
+import "sync"
+
+// FlushAsync ... Flush, handing the Data to OnEvicted on up to workers
+// goRoutines instead of the caller's, so a slow callback does not hold
+// up the Flush. Return a channel closed once every call has returned
+func (c *Cache) FlushAsync(workers int) <-chan struct{} {
+	evicted, f, inv := c.flushDetached()
+	publish(inv, c.instanceID, Invalidation{Flush: true})
+	return drainEvicted(f, evicted, workers)
+}
+
+// flushDetached ... flush and Return the Data removed and the OnEvicted
+// callback to pass it to, which unlock then leaves to the caller, and
+// the Invalidator to tell
+func (c *Cache) flushDetached() ([]keyValue, func(string, interface{}), Invalidator) {
+	c.mutex.Lock()
+	c.flush()
+	evicted, f, inv := c.evicted, c.onEvicted, c.invalidator
+	c.evicted = nil
+	c.unlock()
+	return evicted, f, inv
+}
+
+// drainEvicted ... Call f with every evicted on up to workers
+// goRoutines, at least one, Return a channel closed when all are done
+func drainEvicted(f func(string, interface{}), evicted []keyValue, workers int) <-chan struct{} {
+	done := make(chan struct{})
+	if f == nil || len(evicted) == 0 {
+		close(done)
+		return done
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(evicted) {
+		workers = len(evicted)
+	}
+	kvs := make(chan keyValue)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for kv := range kvs {
+				f(kv.key, kv.value)
+			}
+		}()
+	}
+	go func() {
+		for _, kv := range evicted {
+			kvs <- kv
+		}
+		close(kvs)
+		wg.Wait()
+		close(done)
+	}()
+	return done
+}
+
+// FlushExpiredOnly ... Delete all the Expired Data at once, the stale
+// Data kept by EnableStaleWhileRevalidate included, and nothing else:
+// unlike DeleteExpired it leaves idle Data alone and is no GC sweep in
+// the Stats. Return how many were Deleted
+func (c *Cache) FlushExpiredOnly() int {
+	c.lock()
+	defer c.unlock()
+	n := 0
+	for k, item := range c.items {
+		if c.expired(item) {
+			c.expire(k)
+			n++
+		}
+	}
+	c.stats.expired.Add(uint64(n))
+	return n
+}
+
+// FlushAsync ... Flush every shard, handing the Data to OnEvicted on up
+// to workers goRoutines shared by all shards, see Cache.FlushAsync
+func (sc *ShardedCache) FlushAsync(workers int) <-chan struct{} {
+	var all []keyValue
+	var f func(string, interface{})
+	for _, c := range sc.shardSet() {
+		evicted, onEvicted, _ := c.flushDetached()
+		all = append(all, evicted...)
+		if onEvicted != nil {
+			f = onEvicted
+		}
+	}
+	publish(sc.getInvalidator(), sc.instanceID, Invalidation{Flush: true})
+	return drainEvicted(f, all, workers)
+}
+
+// FlushExpiredOnly ... FlushExpiredOnly every shard, Return how many
+// were Deleted
+func (sc *ShardedCache) FlushExpiredOnly() int {
+	n := 0
+	for _, c := range sc.shardSet() {
+		n += c.FlushExpiredOnly()
+	}
+	return n
+}
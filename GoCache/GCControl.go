@@ -0,0 +1,83 @@
+package GoCache
+
+import (
+	"context"
+	"sync"
+)
+
+// gcRunner ... Starts and stops a GC goRoutine any number of times, in
+// any order
+type gcRunner struct {
+	mutex  sync.Mutex
+	run    func(stop <-chan struct{}) // The GC loop, returns once stop is closed
+	cancel context.CancelFunc         // nil while stopped
+}
+
+// start ... Start the loop unless it runs
+func (g *gcRunner) start() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if g.cancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	g.cancel = cancel
+	go g.run(ctx.Done())
+}
+
+// stop ... Stop the loop if it runs
+// It does not wait for a sweep in progress, so OnEvicted may call it
+func (g *gcRunner) stop() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if g.cancel != nil {
+		g.cancel()
+		g.cancel = nil
+	}
+}
+
+func (g *gcRunner) running() bool {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.cancel != nil
+}
+
+// StartGC ... Start the GC goRoutine if it is stopped, at the interval
+// it last swept at
+// Unlike the GC, the snapshots and append log syncing StopGc ends do not
+// come back
+func (c *Cache) StartGC() { c.gc.start() }
+
+// StopGC ... Stop the GC goRoutine if it runs, StartGC starts it again
+// StopGc stops it too, and for good what else runs in the background
+func (c *Cache) StopGC() { c.gc.stop() }
+
+// GCEnabled ... Report whether the GC goRoutine runs
+func (c *Cache) GCEnabled() bool { return c.gc.running() }
+
+// StartGC ... Start the GC goRoutine of all shards if it is stopped
+func (sc *ShardedCache) StartGC() { sc.gc.start() }
+
+// StopGC ... Stop the GC goRoutine of all shards if it runs
+func (sc *ShardedCache) StopGC() { sc.gc.stop() }
+
+// GCEnabled ... Report whether the GC goRoutine runs
+func (sc *ShardedCache) GCEnabled() bool { return sc.gc.running() }
+
+// StartGC ... Start the GC goRoutine if it is stopped
+func (c *TypedCache[K, V]) StartGC() { c.gc.start() }
+
+// StopGC ... Stop the GC goRoutine if it runs
+func (c *TypedCache[K, V]) StopGC() { c.gc.stop() }
+
+// GCEnabled ... Report whether the GC goRoutine runs
+func (c *TypedCache[K, V]) GCEnabled() bool { return c.gc.running() }
+
+// StartGC ... Start the GC goRoutine if it is stopped
+func (bc *BytesCache) StartGC() { bc.gc.start() }
+
+// StopGC ... Stop the GC goRoutine if it runs
+func (bc *BytesCache) StopGC() { bc.gc.stop() }
+
+// GCEnabled ... Report whether the GC goRoutine runs
+func (bc *BytesCache) GCEnabled() bool { return bc.gc.running() }
@@ -0,0 +1,265 @@
+package GoCache
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CacheHandler ... Return an http.Handler to inspect and purge c at runtime
+//
+//	GET    /keys?prefix=p  list the live keys, optionally under a prefix
+//	GET    /keys?match=g   list the live keys matching a glob, see KeysMatching
+//	GET    /keys/{key}     the value and expiration of a key
+//	PUT    /keys/{key}     Set the body as value, ?ttl=30s sets the Expiration
+//	                       a JSON body is decoded when Content-Type says so
+//	DELETE /keys/{key}     Delete a key
+//	POST   /flush          Flush the Cache
+//	GET    /stats          the Stats of the Cache, ?namespace=n those of a
+//	                       configured namespace, see NamespaceStats
+//	GET    /top            the keys hit most, see TopKeys, ?by=size the
+//	                       largest, ?n=10 how many
+//	GET    /victims        the next keys to be evicted, see EvictionOrder,
+//	                       ?n=10 how many
+//	GET    /dump           the live Data as JSON lines, see ExportJSONL
+//	GET    /snapshot       a snapshot file, as SaveToFile writes it
+//	POST   /restore        Load the body, a snapshot file or JSON lines if
+//	                       Content-Type is application/x-ndjson
+//	                       ?policy=keep|loaded|newer settles existing keys,
+//	                       loaded by default
+//	                       ?dry_run=1 only checks a snapshot file and
+//	                       answers what it holds, see ValidateSnapshot
+//
+// Mount it under a prefix with http.StripPrefix
+func CacheHandler(c *Cache) http.Handler {
+	return &cacheHandler{c}
+}
+
+type cacheHandler struct {
+	c *Cache
+}
+
+func (h *cacheHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	switch {
+	case path == "keys":
+		if !allow(w, r, http.MethodGet) {
+			return
+		}
+		if match := r.URL.Query().Get("match"); match != "" {
+			keys, err := h.c.KeysMatching(match)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			writeJSON(w, http.StatusOK, keys)
+			return
+		}
+		writeJSON(w, http.StatusOK, h.c.KeysWithPrefix(r.URL.Query().Get("prefix")))
+	case strings.HasPrefix(path, "keys/"):
+		h.serveKey(w, r, strings.TrimPrefix(path, "keys/"))
+	case path == "flush":
+		if !allow(w, r, http.MethodPost) {
+			return
+		}
+		h.c.Flush()
+		w.WriteHeader(http.StatusNoContent)
+	case path == "stats":
+		if !allow(w, r, http.MethodGet) {
+			return
+		}
+		if name := r.URL.Query().Get("namespace"); name != "" {
+			s, ok := h.c.NamespaceStats(name)
+			if !ok {
+				http.Error(w, "namespace "+name+" is not configured", http.StatusNotFound)
+				return
+			}
+			writeJSON(w, http.StatusOK, s)
+			return
+		}
+		writeJSON(w, http.StatusOK, h.c.Stats())
+	case path == "top":
+		if !allow(w, r, http.MethodGet) {
+			return
+		}
+		h.top(w, r)
+	case path == "victims":
+		if !allow(w, r, http.MethodGet) {
+			return
+		}
+		n, ok := countParam(w, r)
+		if !ok {
+			return
+		}
+		victims := h.c.EvictionOrder(n)
+		if victims == nil {
+			victims = []string{}
+		}
+		writeJSON(w, http.StatusOK, victims)
+	case path == "dump":
+		if !allow(w, r, http.MethodGet) {
+			return
+		}
+		w.Header().Set("Content-Type", ndjsonType)
+		h.c.ExportJSONL(w)
+	case path == "snapshot":
+		if !allow(w, r, http.MethodGet) {
+			return
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		sealFile(h.c.compress, h.c.snapshotKey, h.c.Save)(w)
+	case path == "restore":
+		if !allow(w, r, http.MethodPost) {
+			return
+		}
+		h.restore(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *cacheHandler) serveKey(w http.ResponseWriter, r *http.Request, k string) {
+	switch r.Method {
+	case http.MethodGet:
+		v, e, found := h.c.GetWithExpiration(k)
+		if !found {
+			http.NotFound(w, r)
+			return
+		}
+		res := map[string]interface{}{"key": k, "value": v}
+		if !e.IsZero() {
+			res["expiration"] = e
+		}
+		writeJSON(w, http.StatusOK, res)
+	case http.MethodPut:
+		d := DefaultExpiration
+		if ttl := r.URL.Query().Get("ttl"); ttl != "" {
+			var err error
+			if d, err = time.ParseDuration(ttl); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var v interface{} = string(body)
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+			if err := json.Unmarshal(body, &v); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		h.c.Set(k, v, d)
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		h.c.Delete(k)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.Header().Set("Allow", "GET, PUT, DELETE")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// countParam ... The n of the request, 10 if absent, ok false once a bad
+// one is answered
+func countParam(w http.ResponseWriter, r *http.Request) (n int, ok bool) {
+	n = 10
+	if s := r.URL.Query().Get("n"); s != "" {
+		var err error
+		if n, err = strconv.Atoi(s); err != nil || n <= 0 {
+			http.Error(w, "n must be a positive number", http.StatusBadRequest)
+			return 0, false
+		}
+	}
+	return n, true
+}
+
+func (h *cacheHandler) top(w http.ResponseWriter, r *http.Request) {
+	n, ok := countParam(w, r)
+	if !ok {
+		return
+	}
+	by := ByHits
+	switch r.URL.Query().Get("by") {
+	case "", "hits":
+	case "size":
+		by = BySize
+	default:
+		http.Error(w, "by must be hits or size", http.StatusBadRequest)
+		return
+	}
+	top := h.c.TopKeys(n, by)
+	if top == nil {
+		top = []KeyStat{}
+	}
+	writeJSON(w, http.StatusOK, top)
+}
+
+// ndjsonType ... Content-Type of JSON lines
+const ndjsonType = "application/x-ndjson"
+
+var restorePolicies = map[string]LoadPolicy{
+	"keep":   KeepExisting,
+	"loaded": PreferLoaded,
+	"newer":  PreferNewerTimestamp,
+}
+
+func (h *cacheHandler) restore(w http.ResponseWriter, r *http.Request) {
+	var err error
+	jsonl := strings.HasPrefix(r.Header.Get("Content-Type"), ndjsonType)
+	if dry, _ := strconv.ParseBool(r.URL.Query().Get("dry_run")); dry {
+		if jsonl {
+			http.Error(w, "dry_run checks snapshot files only", http.StatusBadRequest)
+			return
+		}
+		info, err := h.c.ValidateSnapshot(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeJSON(w, http.StatusOK, info)
+		return
+	}
+	if jsonl {
+		err = h.c.ImportJSONL(r.Body)
+	} else {
+		policy := PreferLoaded
+		if name := r.URL.Query().Get("policy"); name != "" {
+			var ok bool
+			if policy, ok = restorePolicies[name]; !ok {
+				http.Error(w, "unknown policy "+name, http.StatusBadRequest)
+				return
+			}
+		}
+		err = openFile(h.c.snapshotKey, func(r io.Reader) error {
+			return h.c.LoadWithPolicy(r, policy)
+		})(r.Body)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// allow ... Reply 405 unless r uses method
+func allow(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method == method {
+		return true
+	}
+	w.Header().Set("Allow", method)
+	http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	return false
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,82 @@
+package GoCache
+
+// Hash ... Data kept by HSet, fields to values, a Get of it shares it
+// with the Cache, use HGetAll for a copy
+type Hash map[string]interface{}
+
+// hash ... Return the live Hash at k like collection does, the caller
+// holds the lock
+func (c *Cache) hash(k string) (item Item, h Hash, found bool, err error) {
+	item, v, found := c.collection(k)
+	h, ok := v.(Hash)
+	if found && !ok {
+		return item, nil, true, errNotA(k, "Hash")
+	}
+	return item, h, found, nil
+}
+
+// HSet ... Set field of the Hash at k to v, creating it with
+// DefaultExpiration if missing, Return whether field is New
+func (c *Cache) HSet(k, field string, v interface{}) (bool, error) {
+	k = c.key(k)
+	c = c.lockFor(k)
+	defer c.unlock()
+	item, h, _, err := c.hash(k)
+	if err != nil {
+		return false, err
+	}
+	if h == nil {
+		h = Hash{}
+	}
+	_, old := h[field]
+	h[field] = v
+	c.store(k, item, h, len(h))
+	return !old, nil
+}
+
+// HGet ... Get field of the Hash at k
+func (c *Cache) HGet(k, field string) (interface{}, bool, error) {
+	k = c.key(k)
+	c = c.lockFor(k)
+	defer c.unlock()
+	_, h, _, err := c.hash(k)
+	v, found := h[field]
+	return v, found, err
+}
+
+// HDel ... Delete fields of the Hash at k, Return how many it had, the
+// Hash is Deleted once empty
+func (c *Cache) HDel(k string, fields ...string) (int, error) {
+	k = c.key(k)
+	c = c.lockFor(k)
+	defer c.unlock()
+	item, h, found, err := c.hash(k)
+	if !found || err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, f := range fields {
+		if _, in := h[f]; in {
+			delete(h, f)
+			n++
+		}
+	}
+	c.store(k, item, h, len(h))
+	return n, nil
+}
+
+// HGetAll ... Return a copy of the Hash at k, nil if missing
+func (c *Cache) HGetAll(k string) (map[string]interface{}, error) {
+	k = c.key(k)
+	c = c.lockFor(k)
+	defer c.unlock()
+	_, h, found, err := c.hash(k)
+	if !found || err != nil {
+		return nil, err
+	}
+	res := make(map[string]interface{}, len(h))
+	for f, v := range h {
+		res[f] = v
+	}
+	return res, nil
+}
@@ -0,0 +1,17 @@
+package GoCache
+
+import "time"
+
+// WithIdleTimeout ... Evict Data no read has found for d, whatever its
+// Expiration. Idle Data reads as missing until the GC removes it, which
+// then walks all keys every tick, even with an expiration index
+// Zero turns it off
+func WithIdleTimeout(d time.Duration) Option {
+	return func(o *Options) { o.IdleTimeout = d }
+}
+
+// isIdle ... Report whether item was last read more than idleTimeout
+// before now, in UnixNano
+func (c *Cache) isIdle(item Item, now int64) bool {
+	return c.idleTimeout > 0 && item.pins == 0 && !c.paused.Load() && item.accessed != nil && now-item.accessed.Load() > int64(c.idleTimeout)
+}
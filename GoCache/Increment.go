@@ -0,0 +1,61 @@
+package GoCache
+
+import "fmt"
+
+// Increment ... Add n to the number stored at k and Return the new value
+// The Data keeps its type and Expiration, an error is returned if it
+// does not Exist or is not a number
+func (c *Cache) Increment(k string, n int64) (interface{}, error) {
+	k = c.key(k)
+	c = c.lockFor(k)
+	defer c.unlock()
+	item, found := c.items[k]
+	if !found || c.expired(item) {
+		return nil, c.missing(k)
+	}
+	v, err := addInt(c.open(item.Object), n)
+	if err != nil {
+		return nil, fmt.Errorf("item %s: %w", k, err)
+	}
+	item.Object = v
+	c.put(k, item)
+	return v, nil
+}
+
+// Decrement ... Subtract n from the number stored at k and Return the new value
+func (c *Cache) Decrement(k string, n int64) (interface{}, error) {
+	return c.Increment(k, -n)
+}
+
+// addInt ... Return v+n in the type of v
+func addInt(v interface{}, n int64) (interface{}, error) {
+	switch x := v.(type) {
+	case int:
+		return x + int(n), nil
+	case int8:
+		return x + int8(n), nil
+	case int16:
+		return x + int16(n), nil
+	case int32:
+		return x + int32(n), nil
+	case int64:
+		return x + n, nil
+	case uint:
+		return x + uint(n), nil
+	case uint8:
+		return x + uint8(n), nil
+	case uint16:
+		return x + uint16(n), nil
+	case uint32:
+		return x + uint32(n), nil
+	case uint64:
+		return x + uint64(n), nil
+	case uintptr:
+		return x + uintptr(n), nil
+	case float32:
+		return x + float32(n), nil
+	case float64:
+		return x + float64(n), nil
+	}
+	return nil, fmt.Errorf("value of type %T is not a number: %w", v, ErrTypeMismatch)
+}
@@ -0,0 +1,230 @@
+package GoCache
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"sync"
+)
+
+// Invalidation ... A Delete or Flush done on one Cache, for the others
+// holding the same keys
+type Invalidation struct {
+	Source string // Instance that sent it, which ignores it
+	Key    string `json:",omitempty"`
+	Flush  bool   `json:",omitempty"`
+}
+
+// Invalidator ... Carries Invalidations between Cache instances
+type Invalidator interface {
+	// Publish ... Send inv to every subscriber, the sender included
+	Publish(inv Invalidation) error
+	// Subscribe ... Call f for every Invalidation until cancel is called
+	Subscribe(f func(Invalidation)) (cancel func())
+}
+
+// WithInvalidator ... Publish every Delete and Flush on inv and apply
+// the ones other instances publish there. Evictions, Expirations and
+// Close are not published
+func WithInvalidator(inv Invalidator) Option {
+	return func(o *Options) { o.Invalidator = inv }
+}
+
+// newInstanceID ... Random name of a Cache for Invalidation.Source
+func newInstanceID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// subscribe ... Apply the Invalidations of others on inv until the
+// returned cancel is called
+func subscribe(inv Invalidator, id string, del func(string), flush func()) func() {
+	return inv.Subscribe(func(m Invalidation) {
+		switch {
+		case m.Source == id:
+		case m.Flush:
+			flush()
+		default:
+			del(m.Key)
+		}
+	})
+}
+
+// publish ... Send m from c if it has an Invalidator, errors are
+// dropped since the change is already done here
+func publish(inv Invalidator, id string, m Invalidation) {
+	if inv != nil {
+		m.Source = id
+		inv.Publish(m)
+	}
+}
+
+// stopInvalidations ... Stop publishing and applying Invalidations
+func (c *Cache) stopInvalidations() {
+	c.mutex.Lock()
+	cancel := c.unsubscribe
+	c.invalidator, c.unsubscribe = nil, nil
+	c.mutex.Unlock()
+	if cancel != nil {
+		cancel()
+	}
+}
+
+// ChannelBus ... Invalidator between Caches of one process, each
+// subscriber gets the Invalidations through its own buffered channel
+type ChannelBus struct {
+	mutex  sync.Mutex
+	nextID int
+	subs   map[int]chan Invalidation
+}
+
+// NewChannelBus ... Create an empty ChannelBus
+func NewChannelBus() *ChannelBus {
+	return &ChannelBus{subs: map[int]chan Invalidation{}}
+}
+
+// Publish ... Queue inv for every subscriber, waiting while one is behind
+func (b *ChannelBus) Publish(inv Invalidation) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	for _, ch := range b.subs {
+		ch <- inv
+	}
+	return nil
+}
+
+// Subscribe ... Call f from a goRoutine for every Invalidation
+func (b *ChannelBus) Subscribe(f func(Invalidation)) func() {
+	ch := make(chan Invalidation, 1024)
+	b.mutex.Lock()
+	id := b.nextID
+	b.nextID++
+	b.subs[id] = ch
+	b.mutex.Unlock()
+	go func() {
+		for inv := range ch {
+			f(inv)
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.mutex.Lock()
+			delete(b.subs, id)
+			b.mutex.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// TransportInvalidator ... Invalidator over a transport of your own:
+// Publish hands the encoded Invalidation to send, and Deliver takes
+// the messages the transport receives
+type TransportInvalidator struct {
+	send  func([]byte) error
+	mutex sync.Mutex
+	subs  map[int]func(Invalidation)
+	next  int
+}
+
+// NewTransportInvalidator ... Create a TransportInvalidator sending with send
+func NewTransportInvalidator(send func(msg []byte) error) *TransportInvalidator {
+	return &TransportInvalidator{send: send, subs: map[int]func(Invalidation){}}
+}
+
+// Publish ... Encode inv and send it
+func (t *TransportInvalidator) Publish(inv Invalidation) error {
+	msg, err := json.Marshal(inv)
+	if err != nil {
+		return err
+	}
+	return t.send(msg)
+}
+
+// Subscribe ... Call f for every Invalidation Deliver gets
+func (t *TransportInvalidator) Subscribe(f func(Invalidation)) func() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	id := t.next
+	t.next++
+	t.subs[id] = f
+	return func() {
+		t.mutex.Lock()
+		defer t.mutex.Unlock()
+		delete(t.subs, id)
+	}
+}
+
+// Deliver ... Decode msg received by the transport and pass it to the
+// subscribers
+func (t *TransportInvalidator) Deliver(msg []byte) error {
+	var inv Invalidation
+	if err := json.Unmarshal(msg, &inv); err != nil {
+		return err
+	}
+	t.mutex.Lock()
+	subs := make([]func(Invalidation), 0, len(t.subs))
+	for _, f := range t.subs {
+		subs = append(subs, f)
+	}
+	t.mutex.Unlock()
+	for _, f := range subs {
+		f(inv)
+	}
+	return nil
+}
+
+// UDPInvalidator ... Invalidator over UDP multicast, for the processes
+// of one network. Delivery is best effort, like UDP
+type UDPInvalidator struct {
+	*TransportInvalidator
+	send *net.UDPConn
+	recv *net.UDPConn
+}
+
+// NewUDPInvalidator ... Join the multicast group addr, like
+// 239.0.0.1:9999, and send Invalidations to it
+func NewUDPInvalidator(addr string) (*UDPInvalidator, error) {
+	group, err := net.ResolveUDPAddr("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+	recv, err := net.ListenMulticastUDP("udp", nil, group)
+	if err != nil {
+		return nil, err
+	}
+	send, err := net.DialUDP("udp", nil, group)
+	if err != nil {
+		recv.Close()
+		return nil, err
+	}
+	u := &UDPInvalidator{send: send, recv: recv}
+	u.TransportInvalidator = NewTransportInvalidator(func(msg []byte) error {
+		_, err := u.send.Write(msg)
+		return err
+	})
+	go u.receive()
+	return u, nil
+}
+
+func (u *UDPInvalidator) receive() {
+	buf := make([]byte, 64*1024)
+	for {
+		n, _, err := u.recv.ReadFromUDP(buf)
+		if err != nil {
+			return
+		}
+		u.Deliver(buf[:n])
+	}
+}
+
+// Close ... Leave the group
+func (u *UDPInvalidator) Close() error {
+	err := u.send.Close()
+	if rerr := u.recv.Close(); err == nil {
+		err = rerr
+	}
+	return err
+}
@@ -0,0 +1,19 @@
+package GoCache
+
+// Items ... Return a copy of the live Data In Cache
+// The map is the caller's own, the Objects in it are shared with the Cache
+func (c *Cache) Items() map[string]Item {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.liveItems()
+}
+
+// Range ... Call f for every live Data until it returns false
+// f runs on a snapshot without the lock held, so it may use the Cache
+func (c *Cache) Range(f func(k string, v interface{}) bool) {
+	for k, item := range c.Items() {
+		if !f(k, item.Object) {
+			return
+		}
+	}
+}
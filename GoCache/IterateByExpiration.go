@@ -0,0 +1,73 @@
+package GoCache
+
+import (
+	"sort"
+	"time"
+)
+
+// IterateByExpiration ... Call fn for every live Data that can Expire,
+// first to Expire first, or last first if not asc, until it returns
+// false, so batch jobs can persist or refresh it in deadline order.
+// Data that never Expires is not visited. As with Range fn runs on a
+// snapshot without the lock held, so it may use the Cache. It walks
+// only the Data that can Expire with Options.ExpirationIndex
+func (c *Cache) IterateByExpiration(asc bool, fn func(k string, v interface{}, exp time.Time) bool) {
+	c.mutex.RLock()
+	due := c.byExpiration()
+	c.mutex.RUnlock()
+	iterateExpiring(due, asc, fn)
+}
+
+// expiringValue ... A live Data and the UnixNano it Expires at
+type expiringValue struct {
+	key string
+	at  int64
+	v   interface{}
+}
+
+// byExpiration ... The live Data that can Expire, the caller holds the
+// lock
+func (c *Cache) byExpiration() []expiringValue {
+	var due []expiringValue
+	if x := c.expirations; x != nil {
+		due = make([]expiringValue, 0, len(x.heap))
+		for _, e := range x.heap {
+			if item, found := c.items[e.key]; found && !c.expired(item) {
+				due = append(due, expiringValue{key: e.key, at: e.at, v: c.open(item.Object)})
+			}
+		}
+		return due
+	}
+	for k, item := range c.items {
+		if e := item.deadline(); e > 0 && !c.expired(item) {
+			due = append(due, expiringValue{key: k, at: e, v: c.open(item.Object)})
+		}
+	}
+	return due
+}
+
+func iterateExpiring(due []expiringValue, asc bool, fn func(k string, v interface{}, exp time.Time) bool) {
+	sort.Slice(due, func(i, j int) bool {
+		if due[i].at != due[j].at {
+			return due[i].at < due[j].at == asc
+		}
+		return due[i].key < due[j].key == asc
+	})
+	for _, e := range due {
+		if !fn(e.key, e.v, time.Unix(0, e.at)) {
+			return
+		}
+	}
+}
+
+// IterateByExpiration ... IterateByExpiration over the shards put
+// together, in one deadline order
+func (sc *ShardedCache) IterateByExpiration(asc bool, fn func(k string, v interface{}, exp time.Time) bool) {
+	var due []expiringValue
+	for _, c := range sc.shardSet() {
+		c.mutex.RLock()
+		due = append(due, c.byExpiration()...)
+		c.mutex.RUnlock()
+	}
+	iterateExpiring(due, asc, fn)
+}
@@ -0,0 +1,141 @@
+package GoCache
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// JSON lines format: one {"key", "value", "expires_at"} object per live
+// Data, sorted by key so two exports diff cleanly
+// expires_at is an RFC 3339 time, null for Data that never Expires
+// Values come back as the types encoding/json decodes into, like JSONCodec
+
+type jsonlRecord struct {
+	Key       string      `json:"key"`
+	Value     interface{} `json:"value"`
+	ExpiresAt *time.Time  `json:"expires_at"`
+}
+
+// ExportJSONL ... Write the live Data In Cache as JSON lines
+// Like Save, the lock is only held to copy the items
+func (c *Cache) ExportJSONL(w io.Writer) error {
+	return writeJSONL(w, c.jsonlRecords())
+}
+
+// ImportJSONL ... Read JSON lines written by ExportJSONL and Set them
+// with their Expiration, empty lines and Expired Data are skipped
+func (c *Cache) ImportJSONL(r io.Reader) error {
+	records, err := readJSONL(r)
+	if err != nil {
+		return err
+	}
+	c.mutex.Lock()
+	defer c.unlock()
+	c.importJSONL(records)
+	return nil
+}
+
+// ExportJSONL ... Write the live Data of all shards as JSON lines,
+// copying one shard at a time
+func (sc *ShardedCache) ExportJSONL(w io.Writer) error {
+	var records []jsonlRecord
+	for _, c := range sc.shardSet() {
+		records = append(records, c.jsonlRecords()...)
+	}
+	return writeJSONL(w, records)
+}
+
+// ImportJSONL ... Read JSON lines written by ExportJSONL into the shards
+// of their keys
+func (sc *ShardedCache) ImportJSONL(r io.Reader) error {
+	records, err := readJSONL(r)
+	if err != nil {
+		return err
+	}
+	sc.importJSONL(records)
+	return nil
+}
+
+// importJSONL ... importJSONL of records in the shard of each key
+func (sc *ShardedCache) importJSONL(records []jsonlRecord) {
+	byShard := map[*Cache][]jsonlRecord{}
+	for _, rec := range records {
+		c := sc.shard(rec.Key)
+		byShard[c] = append(byShard[c], rec)
+	}
+	for c, recs := range byShard {
+		if moved := c.lockOrMoved(); moved != nil {
+			moved.importJSONL(recs)
+			continue
+		}
+		c.importJSONL(recs)
+		c.unlock()
+	}
+}
+
+// jsonlRecords ... The live Data of the Cache as records, unsorted
+func (c *Cache) jsonlRecords() []jsonlRecord {
+	items := c.copyItems()
+	records := make([]jsonlRecord, 0, len(items))
+	for k, v := range items {
+		if c.expired(v) {
+			continue
+		}
+		rec := jsonlRecord{Key: k, Value: c.open(v.Object)}
+		if v.Expiration > 0 {
+			t := time.Unix(0, v.Expiration).UTC()
+			rec.ExpiresAt = &t
+		}
+		records = append(records, rec)
+	}
+	return records
+}
+
+// importJSONL ... Put the records that have not Expired yet
+func (c *Cache) importJSONL(records []jsonlRecord) {
+	for _, rec := range records {
+		item := Item{Object: rec.Value}
+		if rec.ExpiresAt != nil {
+			item.Expiration = rec.ExpiresAt.UnixNano()
+		}
+		if c.expired(item) {
+			continue
+		}
+		c.put(c.key(rec.Key), item)
+	}
+}
+
+func writeJSONL(w io.Writer, records []jsonlRecord) error {
+	sort.Slice(records, func(i, j int) bool { return records[i].Key < records[j].Key })
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	enc.SetEscapeHTML(false)
+	for _, rec := range records {
+		if err := enc.Encode(rec); err != nil {
+			return fmt.Errorf("item %s: %v", rec.Key, err)
+		}
+	}
+	return bw.Flush()
+}
+
+func readJSONL(r io.Reader) ([]jsonlRecord, error) {
+	var records []jsonlRecord
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1<<30)
+	for n := 1; sc.Scan(); n++ {
+		line := sc.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var rec jsonlRecord
+		if err := json.Unmarshal(line, &rec); err != nil {
+			return nil, fmt.Errorf("line %d: %v", n, err)
+		}
+		records = append(records, rec)
+	}
+	return records, sc.Err()
+}
@@ -0,0 +1,29 @@
+package GoCache
+
+import (
+	"math/rand"
+	"time"
+)
+
+// WithTTLJitter ... Move every Expiration by a random amount within
+// fraction of its TTL either way, so Data Set together does not Expire
+// together and reload all at once. fraction is capped at 1
+func WithTTLJitter(fraction float64) Option {
+	return func(o *Options) { o.TTLJitter = fraction }
+}
+
+// jitter ... Return d moved by up to ttlJitter of it either way
+func (c *Cache) jitter(d time.Duration) time.Duration {
+	f := c.ttlJitter
+	if f <= 0 {
+		return d
+	}
+	if f > 1 {
+		f = 1
+	}
+	if d = d + time.Duration((rand.Float64()*2-1)*f*float64(d)); d <= 0 {
+		// Keep Data that was meant to Expire from never Expiring
+		return 1
+	}
+	return d
+}
@@ -0,0 +1,113 @@
+package GoCache
+
+import "time"
+
+// ShardHasher ... Picks the shard of a key in a ShardedCache, both
+// methods must give the same hash for the same bytes
+// The shard of a key must not change across restarts when WithAppendLog
+// is used, as each shard replays its own log, which rules out seeded
+// hashes like hash/maphash. The Sum64String and Sum64 of an xxhash
+// package fit
+type ShardHasher interface {
+	HashString(k string) uint64
+	HashBytes(k []byte) uint64
+}
+
+// FNVHasher ... The default ShardHasher, 32 bit FNV-1a
+var FNVHasher ShardHasher = fnvHasher{}
+
+type fnvHasher struct{}
+
+func (fnvHasher) HashString(k string) uint64 { return fnv32(k) }
+func (fnvHasher) HashBytes(k []byte) uint64  { return fnv32(k) }
+
+func fnv32[K string | []byte](k K) uint64 {
+	h := uint32(2166136261)
+	for i := 0; i < len(k); i++ {
+		h ^= uint32(k[i])
+		h *= 16777619
+	}
+	return uint64(h)
+}
+
+// WithShardHasher ... Pick the shards of a ShardedCache with h
+func WithShardHasher(h ShardHasher) Option {
+	return func(o *Options) { o.ShardHasher = h }
+}
+
+// GetKeyBytes ... Get with a []byte key, such as a binary UUID
+// When the read is a bare lookup, no eviction policy, namespace bound,
+// admission, idle timeout, refresh ahead, early Expiration or Sliding
+// Data to update, the key is never copied into a string, so it does not
+// allocate. Otherwise, or with WithKeyFunc, it is Get(string(k))
+func (c *Cache) GetKeyBytes(k []byte) (interface{}, bool) {
+	if c.keyFunc != nil {
+		return c.Get(string(k))
+	}
+	if c.bloom != nil && !c.bloom.mayContainBytes(k) {
+		c.stats.read(false)
+		return nil, false
+	}
+	if !c.tracksReads.Load() {
+		c.rLock()
+		if c.movedTo == nil && c.bareReads() {
+			item, found := c.items[string(k)]
+			if !found || !c.expired(item) && item.Sliding == 0 {
+				var v interface{}
+				if found {
+					v, found = c.value(item)
+				}
+				c.stats.read(found)
+				if found && c.hot != nil {
+					c.hot.hit(string(k))
+				}
+				c.mutex.RUnlock()
+				return v, found
+			}
+		}
+		c.mutex.RUnlock()
+	}
+	return c.Get(string(k))
+}
+
+// bareReads ... Report whether a read needs nothing but the lookup and
+// the stats, so GetKeyBytes can do it under the read lock
+func (c *Cache) bareReads() bool {
+	return c.policy == nil && c.namespaces == nil && c.admission == nil && !c.readLimited &&
+		c.idleTimeout == 0 && c.refresh == nil && c.earlyBeta <= 0
+}
+
+// SetKeyBytes ... Set with a []byte key, which is copied into a string to
+// be stored
+func (c *Cache) SetKeyBytes(k []byte, v interface{}, d time.Duration, opts ...SetOption) {
+	c.Set(string(k), v, d, opts...)
+}
+
+// DeleteKeyBytes ... Delete with a []byte key
+func (c *Cache) DeleteKeyBytes(k []byte) {
+	c.Delete(string(k))
+}
+
+// shardBytes ... Pick the shard of k by its hash
+func (sc *ShardedCache) shardBytes(k []byte) *Cache {
+	shards := sc.shardSet()
+	if shards[0].keyFunc != nil {
+		return sc.shard(string(k))
+	}
+	return shards[sc.hasher.HashBytes(k)%uint64(len(shards))]
+}
+
+// GetKeyBytes ... GetKeyBytes in the shard of k, hashed without a copy
+func (sc *ShardedCache) GetKeyBytes(k []byte) (interface{}, bool) {
+	return sc.shardBytes(k).GetKeyBytes(k)
+}
+
+// SetKeyBytes ... SetKeyBytes in the shard of k
+func (sc *ShardedCache) SetKeyBytes(k []byte, v interface{}, d time.Duration, opts ...SetOption) {
+	sc.shardBytes(k).SetKeyBytes(k, v, d, opts...)
+}
+
+// DeleteKeyBytes ... Delete with a []byte key
+func (sc *ShardedCache) DeleteKeyBytes(k []byte) {
+	sc.Delete(string(k))
+}
@@ -0,0 +1,77 @@
+package GoCache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// WithKeyFunc ... Pass every key through f before it is used, so keys
+// spelled differently by different callers, "User:1" and " user:1",
+// reach the same Data. It covers the keys of every read and write, the
+// keys of SetWithDeps, and those of Load, ImportJSONL and ImportText;
+// Keys, Items and the events report the keys as f made them. Namespace
+// keys are built first and then passed through f, and their prefix is
+// matched in the form f gives it, so f must keep prefixes: f(p+k)
+// starts with f(p). Other prefixes, of KeysWithPrefix, DeletePrefix and
+// Watch, are matched against the keys as given. Every key passes
+// through f once, f must be cheap and safe for concurrent use.
+// strings.ToLower and strings.TrimSpace are such functions,
+// ChainKeyFuncs combines them
+//
+//	GoCache.WithKeyFunc(GoCache.ChainKeyFuncs(strings.TrimSpace, strings.ToLower))
+func WithKeyFunc(f func(string) string) Option {
+	return func(o *Options) { o.KeyFunc = f }
+}
+
+// ChainKeyFuncs ... A key func applying fs in order
+func ChainKeyFuncs(fs ...func(string) string) func(string) string {
+	return func(k string) string {
+		for _, f := range fs {
+			k = f(k)
+		}
+		return k
+	}
+}
+
+// HashLongKeys ... A key func keeping keys up to max bytes and
+// replacing longer ones by their first bytes, a '#' and a hash of the
+// whole key, max bytes in all, to bound the memory keys take. max is at
+// least 33
+func HashLongKeys(max int) func(string) string {
+	if max < 33 {
+		max = 33
+	}
+	return func(k string) string {
+		if len(k) <= max {
+			return k
+		}
+		sum := sha256.Sum256([]byte(k))
+		return k[:max-33] + "#" + hex.EncodeToString(sum[:16])
+	}
+}
+
+// key ... The canonical form of k by the KeyFunc of the Cache
+func (c *Cache) key(k string) string {
+	if c.keyFunc == nil {
+		return k
+	}
+	return c.keyFunc(k)
+}
+
+// key ... The canonical form of k, the shards share Options and so
+// their KeyFunc
+func (sc *ShardedCache) key(k string) string {
+	return sc.shardSet()[0].key(k)
+}
+
+// keys ... keys in canonical form, a new slice when any changed
+func (c *Cache) keys(keys []string) []string {
+	if c.keyFunc == nil {
+		return keys
+	}
+	out := make([]string, len(keys))
+	for i, k := range keys {
+		out[i] = c.keyFunc(k)
+	}
+	return out
+}
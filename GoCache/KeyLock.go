@@ -0,0 +1,27 @@
+package GoCache
+
+import "sync"
+
+// keyLockStripes ... Number of mutexes LockKey spreads keys over
+const keyLockStripes = 256
+
+// LockKey ... Lock the mutex of k and Return the func unlocking it
+// Keys share a fixed set of mutexes, so two keys may wait on each other
+// now and then, but never on the Cache lock
+// The lock only orders callers of LockKey, Get and Set ignore it
+func (c *Cache) LockKey(k string) func() {
+	k = c.key(k)
+	c.keyLocksOnce.Do(func() { c.keyLocks = new(keyLocks) })
+	m := &c.keyLocks[hash64(k)%keyLockStripes]
+	m.Lock()
+	return m.Unlock
+}
+
+// WithKeyLock ... Call fn holding the lock of k
+func (c *Cache) WithKeyLock(k string, fn func()) {
+	defer c.LockKey(k)()
+	fn()
+}
+
+// keyLocks ... The stripes of LockKey
+type keyLocks [keyLockStripes]sync.Mutex
@@ -0,0 +1,56 @@
+package GoCache
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// Keys ... Return the sorted keys of the live Data In Cache
+func (c *Cache) Keys() []string {
+	return c.keysWhere(func(string) bool { return true })
+}
+
+// KeysWithPrefix ... Return the sorted live keys starting with prefix
+func (c *Cache) KeysWithPrefix(prefix string) []string {
+	c.mutex.RLock()
+	if c.prefixes == nil {
+		c.mutex.RUnlock()
+		return c.keysWhere(func(k string) bool { return strings.HasPrefix(k, prefix) })
+	}
+	keys := c.prefixes.withPrefix(prefix)
+	live := keys[:0]
+	for _, k := range keys {
+		if !c.expired(c.items[k]) {
+			live = append(live, k)
+		}
+	}
+	c.mutex.RUnlock()
+	sort.Strings(live)
+	return live
+}
+
+// KeysMatching ... Return the sorted live keys matching the glob
+// pattern, in the syntax of path.Match
+func (c *Cache) KeysMatching(pattern string) ([]string, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+	return c.keysWhere(func(k string) bool {
+		ok, _ := path.Match(pattern, k)
+		return ok
+	}), nil
+}
+
+func (c *Cache) keysWhere(match func(string) bool) []string {
+	c.mutex.RLock()
+	keys := make([]string, 0, len(c.items))
+	for k, v := range c.items {
+		if !c.expired(v) && match(k) {
+			keys = append(keys, k)
+		}
+	}
+	c.mutex.RUnlock()
+	sort.Strings(keys)
+	return keys
+}
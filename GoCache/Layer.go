@@ -0,0 +1,146 @@
+package GoCache
+
+import (
+	"sync"
+	"time"
+)
+
+// Layer ... Write buffer over a Cache: reads see the writes of the Layer
+// first and go through to the Cache for the rest, writes stay in the
+// Layer until Commit. Meant for one request or speculative computation,
+// it is safe for concurrent use
+// Buffered writes do not Expire within the Layer, their Expiration
+// starts at Commit
+type Layer struct {
+	parent layerParent
+	mutex  sync.Mutex
+	tx     *Tx
+}
+
+// layerParent ... What a Layer reads through to and Commits into
+type layerParent interface {
+	getKey(k string) (interface{}, bool) // Get of a key in canonical form
+	key(k string) string
+	commitTx(tx *Tx) error
+}
+
+// Layer ... Return an empty Layer over c
+func (c *Cache) Layer() *Layer {
+	return newLayer(c)
+}
+
+// Layer ... Return an empty Layer over sc
+func (sc *ShardedCache) Layer() *Layer {
+	return newLayer(sc)
+}
+
+func newLayer(parent layerParent) *Layer {
+	return &Layer{parent: parent, tx: newTx(parent.getKey, parent.key)}
+}
+
+// Get ... Get the Data as the Layer left it, or from the Cache
+func (l *Layer) Get(k string) (interface{}, bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.tx.Get(k)
+}
+
+// Set ... Set the Data in the Layer, with Expiration d once Committed
+func (l *Layer) Set(k string, v interface{}, d time.Duration) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.tx.Set(k, v, d)
+}
+
+// Delete ... Hide the Data from the Layer, Deleting it from the Cache
+// once Committed
+func (l *Layer) Delete(k string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.tx.Delete(k)
+}
+
+// Commit ... Apply all writes of the Layer to the Cache at once and empty
+// the Layer, which can then be used again. If the Cache rejects one of
+// the writes, like ErrValueTooLarge, none is applied and its error is
+// returned
+func (l *Layer) Commit() error {
+	l.mutex.Lock()
+	tx := l.tx
+	l.tx = newTx(l.parent.getKey, l.parent.key)
+	l.mutex.Unlock()
+	if len(tx.writes) == 0 {
+		return nil
+	}
+	return l.parent.commitTx(tx)
+}
+
+// Discard ... Drop all writes of the Layer, leaving the Cache alone
+func (l *Layer) Discard() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.tx = newTx(l.parent.getKey, l.parent.key)
+}
+
+// getKey ... Get a key in canonical form
+func (c *Cache) getKey(k string) (interface{}, bool) {
+	v, found, _ := c.read(k)
+	return v, found
+}
+
+// getKey ... Get a key in canonical form from its shard
+func (sc *ShardedCache) getKey(k string) (interface{}, bool) {
+	return sc.shardOf(k).getKey(k)
+}
+
+// commitTx ... Apply the writes of tx under a single lock
+func (c *Cache) commitTx(tx *Tx) error {
+	c.lock()
+	deleted, err := tx.commit(func(string) *Cache { return c })
+	inv := c.invalidator
+	c.unlock()
+	for _, k := range deleted {
+		publish(inv, c.instanceID, Invalidation{Key: k})
+	}
+	return err
+}
+
+// commitTx ... Apply the writes of tx holding the locks of the shards it
+// touches, taken in shard order, again if a Reshard swapped them first
+func (sc *ShardedCache) commitTx(tx *Tx) error {
+	var shards []*Cache
+	touched := map[*Cache]bool{}
+	for {
+		set := sc.shards.Load()
+		shards = *set
+		for k := range tx.writes {
+			touched[shardIn(shards, sc.hasher, k)] = true
+		}
+		for _, c := range shards {
+			if touched[c] {
+				c.lock()
+			}
+		}
+		if sc.shards.Load() == set {
+			break
+		}
+		// A Reshard swapped the shards while they were locked
+		for _, c := range shards {
+			if touched[c] {
+				c.unlock()
+			}
+		}
+		touched = map[*Cache]bool{}
+	}
+	deleted, err := tx.commit(func(k string) *Cache { return shardIn(shards, sc.hasher, k) })
+	for _, c := range shards {
+		if touched[c] {
+			c.unlock()
+		}
+	}
+	inv := sc.getInvalidator()
+	for _, k := range deleted {
+		publish(inv, sc.instanceID, Invalidation{Key: k})
+	}
+	return err
+}
@@ -0,0 +1,20 @@
+package GoCache
+
+// EnableLazyExpiration ... Let Get Delete the Expired Data it runs into
+// instead of leaving it for the next GC sweep
+func (c *Cache) EnableLazyExpiration(enable bool) {
+	c.mutex.Lock()
+	defer c.unlock()
+	c.lazyExpiration = enable
+}
+
+// deleteIfExpired ... Delete the Data at k if it is still Expired
+// once the write lock is held
+func (c *Cache) deleteIfExpired(k string) {
+	c = c.lockFor(k)
+	defer c.unlock()
+	if item, found := c.items[k]; found && c.pastGrace(item) {
+		c.expire(k)
+		c.stats.expired.Add(1)
+	}
+}
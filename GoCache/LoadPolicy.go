@@ -0,0 +1,87 @@
+package GoCache
+
+import "io"
+
+// LoadPolicy ... How Load settles a key that both the Cache and the
+// loaded Data hold. Expired Data on either side always gives way
+type LoadPolicy int
+
+const (
+	// KeepExisting ... The live Data of the Cache wins, what Load does
+	KeepExisting LoadPolicy = iota
+	// PreferLoaded ... The loaded Data wins
+	PreferLoaded
+	// PreferNewerTimestamp ... The Data with the later Item.CreatedAt wins,
+	// the Cache on a tie
+	PreferNewerTimestamp
+)
+
+// LoadWithPolicy ... Load Data written by Save, settling keys the Cache
+// already holds by policy
+// A stream of SaveStream is merged a batch at a time, so reads and writes
+// go on while it loads; WithBatchedLoad does the same for Save streams
+func (c *Cache) LoadWithPolicy(r io.Reader, policy LoadPolicy) error {
+	c.loading.Add(1)
+	defer c.loading.Add(-1)
+	return decodeSnapshot(r, c.codec, func(items map[string]Item) {
+		c.mergeBatches(items, policy)
+	})
+}
+
+// IsLoading ... Report whether a Load runs, for readiness checks
+func (c *Cache) IsLoading() bool {
+	return c.loading.Load() > 0
+}
+
+// mergeBatches ... merge items under one lock, or a loadBatchSize at a
+// time with WithBatchedLoad, releasing the lock in between
+func (c *Cache) mergeBatches(items map[string]Item, policy LoadPolicy) {
+	n := c.loadBatchSize
+	if n <= 0 || len(items) <= n {
+		c.mergeBatch(items, policy)
+		return
+	}
+	batch := make(map[string]Item, n)
+	for k, v := range items {
+		batch[k] = v
+		if len(batch) == n {
+			c.mergeBatch(batch, policy)
+			batch = make(map[string]Item, n)
+		}
+	}
+	if len(batch) > 0 {
+		c.mergeBatch(batch, policy)
+	}
+}
+
+// mergeBatch ... merge items under the lock, in the shards of the
+// current set if a Reshard retired c
+func (c *Cache) mergeBatch(items map[string]Item, policy LoadPolicy) {
+	if sc := c.lockOrMoved(); sc != nil {
+		sc.mergeBatches(items, policy)
+		return
+	}
+	defer c.unlock()
+	c.merge(items, policy)
+}
+
+// merge ... Put the loaded items that win over the Data of the Cache by policy
+func (c *Cache) merge(items map[string]Item, policy LoadPolicy) {
+	for k, v := range items {
+		k = c.key(k)
+		ov, found := c.items[k]
+		if found && !c.expired(ov) && !c.expired(v) {
+			switch policy {
+			case KeepExisting:
+				continue
+			case PreferNewerTimestamp:
+				if v.CreatedAt <= ov.CreatedAt {
+					continue
+				}
+			}
+		}
+		if !found || c.expired(ov) || !c.expired(v) {
+			c.put(k, v)
+		}
+	}
+}
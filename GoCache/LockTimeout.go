@@ -0,0 +1,130 @@
+package GoCache
+
+import (
+	"fmt"
+	"time"
+)
+
+// The Try variants give up with an error matching ErrLockTimeout when
+// the lock of the Cache is not had within timeout, where the plain ones
+// wait behind a long GC sweep, Save copy or bulk Load as long as it
+// takes, so latency sensitive paths can fall back on the source of
+// truth instead. A timeout of zero or less waits as the plain ones do
+
+// tryLockWait ... Longest sleep between two tries for the lock
+const tryLockWait = time.Millisecond
+
+// tryWithin ... Call try until it returns true or timeout has passed,
+// sleeping ever longer in between, Return whether it did
+func tryWithin(timeout time.Duration, try func() bool) bool {
+	if try() {
+		return true
+	}
+	deadline := time.Now().Add(timeout)
+	for wait := time.Microsecond; ; wait *= 2 {
+		left := time.Until(deadline)
+		if left <= 0 {
+			return false
+		}
+		if wait > tryLockWait {
+			wait = tryLockWait
+		}
+		if wait > left {
+			wait = left
+		}
+		time.Sleep(wait)
+		if try() {
+			return true
+		}
+	}
+}
+
+// tryLock ... Take the write lock within timeout, or wait for it if
+// timeout is not positive
+func (c *Cache) tryLock(timeout time.Duration) bool {
+	if timeout <= 0 {
+		c.lock()
+		return true
+	}
+	return tryWithin(timeout, c.mutex.TryLock)
+}
+
+// tryLockForRead ... lockForRead within timeout
+func (c *Cache) tryLockForRead(timeout time.Duration) (write, ok bool) {
+	start := time.Now()
+	if c.tracksReads.Load() {
+		return true, tryWithin(timeout, c.mutex.TryLock)
+	}
+	if !tryWithin(timeout, c.mutex.TryRLock) {
+		return false, false
+	}
+	if !c.readsNeedWriteLock() {
+		return false, true
+	}
+	c.mutex.RUnlock()
+	return true, tryWithin(timeout-time.Since(start), c.mutex.TryLock)
+}
+
+func errLockTimeout(k string) error {
+	return fmt.Errorf("item %s: %w", k, ErrLockTimeout)
+}
+
+// TryGet ... Get within timeout, or fail with ErrLockTimeout
+func (c *Cache) TryGet(k string, timeout time.Duration) (interface{}, bool, error) {
+	k = c.key(k)
+	v, found, _, ok := c.readWithin(k, timeout)
+	if !ok {
+		return nil, false, errLockTimeout(k)
+	}
+	return v, found, nil
+}
+
+// TrySet ... Set within timeout, or fail with ErrLockTimeout and Set
+// nothing. The error of a Set the Cache refused, such as
+// ErrValueTooLarge, is returned too
+func (c *Cache) TrySet(k string, v interface{}, d, timeout time.Duration, opts ...SetOption) error {
+	k = c.key(k)
+	c, ok := c.tryLockFor(k, timeout)
+	if !ok {
+		return errLockTimeout(k)
+	}
+	defer c.unlock()
+	if len(opts) == 0 {
+		return c.set(k, v, d)
+	}
+	item := c.newItem(v, d)
+	for _, opt := range opts {
+		opt(&item)
+	}
+	return c.put(k, item)
+}
+
+// TryDelete ... Delete within timeout, or fail with ErrLockTimeout and
+// Delete nothing
+func (c *Cache) TryDelete(k string, timeout time.Duration) error {
+	k = c.key(k)
+	c, ok := c.tryLockFor(k, timeout)
+	if !ok {
+		return errLockTimeout(k)
+	}
+	c.delete(k)
+	inv := c.invalidator
+	c.unlock()
+	publish(inv, c.instanceID, Invalidation{Key: k})
+	return nil
+}
+
+// TryGet ... TryGet in the shard of k
+func (sc *ShardedCache) TryGet(k string, timeout time.Duration) (interface{}, bool, error) {
+	return sc.shard(k).TryGet(k, timeout)
+}
+
+// TrySet ... TrySet in the shard of k
+func (sc *ShardedCache) TrySet(k string, v interface{}, d, timeout time.Duration, opts ...SetOption) error {
+	return sc.shard(k).TrySet(k, v, d, timeout, opts...)
+}
+
+// TryDelete ... TryDelete in the shard of k
+func (sc *ShardedCache) TryDelete(k string, timeout time.Duration) error {
+	return sc.shard(k).TryDelete(k, timeout)
+}
@@ -0,0 +1,68 @@
+package GoCache
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// LockWaitStats ... Time spent waiting for the Cache lock in Set and Get
+type LockWaitStats struct {
+	Acquisitions uint64
+	TotalWait    time.Duration
+	MaxWait      time.Duration
+}
+
+type lockWaitStats struct {
+	enabled      atomic.Bool
+	acquisitions atomic.Uint64
+	totalWait    atomic.Int64
+	maxWait      atomic.Int64
+}
+
+func (s *lockWaitStats) record(wait time.Duration) {
+	s.acquisitions.Add(1)
+	s.totalWait.Add(int64(wait))
+	for {
+		max := s.maxWait.Load()
+		if int64(wait) <= max || s.maxWait.CompareAndSwap(max, int64(wait)) {
+			return
+		}
+	}
+}
+
+// EnableLockWaitStats ... Switch the lock wait timing on or off
+// It is off by default since timing every acquisition costs time itself
+func (c *Cache) EnableLockWaitStats(enable bool) {
+	c.lockWait.enabled.Store(enable)
+}
+
+// LockWaitStats ... Return the lock wait recorded so far
+func (c *Cache) LockWaitStats() LockWaitStats {
+	return LockWaitStats{
+		Acquisitions: c.lockWait.acquisitions.Load(),
+		TotalWait:    time.Duration(c.lockWait.totalWait.Load()),
+		MaxWait:      time.Duration(c.lockWait.maxWait.Load()),
+	}
+}
+
+// lock ... Take the write lock, timing the wait if enabled
+func (c *Cache) lock() {
+	if !c.lockWait.enabled.Load() {
+		c.mutex.Lock()
+		return
+	}
+	start := time.Now()
+	c.mutex.Lock()
+	c.lockWait.record(time.Since(start))
+}
+
+// rLock ... Take the read lock, timing the wait if enabled
+func (c *Cache) rLock() {
+	if !c.lockWait.enabled.Load() {
+		c.mutex.RLock()
+		return
+	}
+	start := time.Now()
+	c.mutex.RLock()
+	c.lockWait.record(time.Since(start))
+}
@@ -0,0 +1,42 @@
+package GoCache
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLockWaitStats(t *testing.T) {
+	c := NewCache(0, time.Hour)
+	defer c.StopGc()
+	c.EnableLockWaitStats(true)
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				c.Set("k", j, NoExpiration)
+				c.Get("k")
+			}
+		}()
+	}
+	wg.Wait()
+	s := c.LockWaitStats()
+	if s.Acquisitions == 0 || s.TotalWait == 0 {
+		t.Fatalf("LockWaitStats() = %+v under contention, want wait recorded", s)
+	}
+	if s.MaxWait > s.TotalWait {
+		t.Fatalf("MaxWait %v over TotalWait %v", s.MaxWait, s.TotalWait)
+	}
+}
+
+func TestLockWaitStatsDisabled(t *testing.T) {
+	c := NewCache(0, time.Hour)
+	defer c.StopGc()
+	c.Set("k", 1, NoExpiration)
+	c.Get("k")
+	if s := c.LockWaitStats(); s != (LockWaitStats{}) {
+		t.Fatalf("LockWaitStats() = %+v while disabled, want zero", s)
+	}
+}
@@ -0,0 +1,53 @@
+package GoCache
+
+import "time"
+
+// Logger ... Where the Cache reports what happens in its goRoutines,
+// as a message and key value pairs. *slog.Logger is one
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+}
+
+// WithLogger ... Log GC sweeps at debug level, and eviction storms,
+// failed snapshots, append log errors and failed background loads as
+// warnings, which otherwise go unseen
+func WithLogger(l Logger) Option {
+	return func(o *Options) { o.Logger = l }
+}
+
+// A sweep interval in which more Data was Evicted than the Cache holds,
+// and at least this much, is logged as an eviction storm
+const evictionStormMin = 100
+
+func (c *Cache) debug(msg string, args ...interface{}) {
+	if c.logger != nil {
+		c.logger.Debug(msg, args...)
+	}
+}
+
+func (c *Cache) warn(msg string, args ...interface{}) {
+	if c.logger != nil {
+		c.logger.Warn(msg, args...)
+	}
+}
+
+// sweepLog ... Logs the GC ticks of one GC goRoutine
+type sweepLog struct {
+	evictions uint64 // Stats.Evictions at the last tick
+}
+
+// observe ... Log a tick that removed removed of scanned Data in took,
+// leaving items, and warn if evictions went up by more than items since
+// the last one
+func (s *sweepLog) observe(l Logger, removed, scanned, items int, evictions uint64, took time.Duration) {
+	evicted := evictions - s.evictions
+	s.evictions = evictions
+	if l == nil {
+		return
+	}
+	l.Debug("gc sweep", "removed", removed, "scanned", scanned, "items", items, "took", took)
+	if evicted >= evictionStormMin && evicted > uint64(items) {
+		l.Warn("eviction storm", "evicted", evicted, "items", items)
+	}
+}
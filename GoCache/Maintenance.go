@@ -0,0 +1,44 @@
+package GoCache
+
+// PauseExpiration ... Stop Data from Expiring until ResumeExpiration:
+// reads serve Data past its Expiration or idle timeout, GC sweeps and
+// lazy Expiration remove nothing. For planned maintenance of the source
+// of truth, so the Cache absorbs the load meanwhile. Expirations are not
+// moved: Data that lapsed during the pause Expires when it ends.
+// Eviction to stay within bounds goes on
+func (c *Cache) PauseExpiration() {
+	c.paused.Store(true)
+}
+
+// ResumeExpiration ... Let Data Expire again, see PauseExpiration
+func (c *Cache) ResumeExpiration() {
+	c.paused.Store(false)
+}
+
+// ExpirationPaused ... Report whether Expiration is paused
+func (c *Cache) ExpirationPaused() bool {
+	return c.paused.Load()
+}
+
+// PauseExpiration ... PauseExpiration in every shard
+func (sc *ShardedCache) PauseExpiration() {
+	sc.resharding.Lock()
+	defer sc.resharding.Unlock()
+	for _, c := range sc.shardSet() {
+		c.PauseExpiration()
+	}
+}
+
+// ResumeExpiration ... ResumeExpiration in every shard
+func (sc *ShardedCache) ResumeExpiration() {
+	sc.resharding.Lock()
+	defer sc.resharding.Unlock()
+	for _, c := range sc.shardSet() {
+		c.ResumeExpiration()
+	}
+}
+
+// ExpirationPaused ... Report whether Expiration is paused in the shards
+func (sc *ShardedCache) ExpirationPaused() bool {
+	return sc.shardSet()[0].ExpirationPaused()
+}
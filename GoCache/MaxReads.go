@@ -0,0 +1,66 @@
+package GoCache
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// SetWithMaxReads ... Set Data that is Deleted once it has been read n
+// times, n of 1 makes a one time token. Reads of the Cache take the
+// write lock from then on. The Deletion reaches OnEvicted and watchers
+// like any other, a Save keeps no count
+func (c *Cache) SetWithMaxReads(k string, v interface{}, d time.Duration, n int) {
+	k = c.key(k)
+	c = c.lockFor(k)
+	defer c.unlock()
+	item := c.newItem(v, d)
+	if n > 0 {
+		item.reads = new(atomic.Int64)
+		item.reads.Store(int64(n))
+	}
+	c.put(k, item)
+}
+
+// limitReads ... Give item being stored a read count of its own, so one
+// copied from another Cache by Merge or ApplyDiff shares none, and make
+// reads take the write lock from now on
+func (c *Cache) limitReads(item *Item) {
+	reads := new(atomic.Int64)
+	reads.Store(item.reads.Load())
+	item.reads = reads
+	if !c.readLimited {
+		c.readLimited = true
+		c.tracksReads.Store(true)
+	}
+}
+
+// SetWithMaxReads ... Set Data read at most n times in its shard
+func (sc *ShardedCache) SetWithMaxReads(k string, v interface{}, d time.Duration, n int) {
+	sc.shard(k).SetWithMaxReads(k, v, d, n)
+}
+
+// spend ... Count a read of item at k, Return false if it had none left
+// The last read queues k for unlock to Delete, the caller holds the
+// write lock
+func (c *Cache) spend(k string, item Item) bool {
+	if item.reads == nil {
+		return true
+	}
+	left := item.reads.Add(-1)
+	if left == 0 {
+		c.spent = append(c.spent, k)
+	}
+	return left >= 0
+}
+
+// removeSpent ... Delete the Data read as many times as it may be, the
+// caller holds the write lock
+func (c *Cache) removeSpent() {
+	for _, k := range c.spent {
+		// A Set since the last read may have replaced it
+		if item, found := c.items[k]; found && item.reads != nil && item.reads.Load() <= 0 {
+			c.remove(k, EventDelete)
+		}
+	}
+	c.spent = nil
+}
@@ -0,0 +1,84 @@
+package GoCache
+
+import (
+	"fmt"
+	"time"
+)
+
+// OversizePolicy ... What a Cache with WithMaxValueSize does with Data
+// over the limit
+type OversizePolicy int
+
+const (
+	// OversizeReject ... Do not store it, the default
+	OversizeReject OversizePolicy = iota
+	// OversizeTruncate ... Store the first bytes of a string or []byte
+	// up to the limit, reject other Data
+	OversizeTruncate
+	// OversizeSpill ... Keep it in a file as WithDiskOverflow does,
+	// reject it if the Cache has no overflow dir or the write fails
+	OversizeSpill
+)
+
+// WithMaxValueSize ... Refuse to keep Data whose approximate size, or
+// weight by WithSizer or SetWithSize, is over bytes in memory: Set
+// rejects it, leaving the Data at the key as it was, and SetE, Add and
+// Replace return ErrValueTooLarge. WithOversizePolicy may truncate or
+// spill it instead
+func WithMaxValueSize(bytes int) Option {
+	return func(o *Options) { o.MaxValueSize = int64(bytes) }
+}
+
+// WithOversizePolicy ... Handle Data over WithMaxValueSize by p
+func WithOversizePolicy(p OversizePolicy) Option {
+	return func(o *Options) { o.OversizePolicy = p }
+}
+
+// SetE ... Set the Data, or Return the error that kept it out:
+// ErrValueTooLarge, or why the ValueTransform could not seal it
+func (c *Cache) SetE(k string, v interface{}, d time.Duration, opts ...SetOption) error {
+	k = c.key(k)
+	c = c.lockFor(k)
+	defer c.unlock()
+	item := c.newItem(v, d)
+	for _, opt := range opts {
+		opt(&item)
+	}
+	return c.put(k, item)
+}
+
+// SetE ... SetE in the shard of k
+func (sc *ShardedCache) SetE(k string, v interface{}, d time.Duration, opts ...SetOption) error {
+	return sc.shard(k).SetE(k, v, d, opts...)
+}
+
+// oversize ... Apply the OversizePolicy to item at k of size over
+// maxValueSize, Return false if it is to be rejected
+func (c *Cache) oversize(k string, item Item) (Item, bool) {
+	switch c.oversizePolicy {
+	case OversizeTruncate:
+		switch x := item.Object.(type) {
+		case string:
+			item.Object = x[:c.maxValueSize]
+		case []byte:
+			item.Object = x[:c.maxValueSize:c.maxValueSize]
+		default:
+			return item, false
+		}
+		item.size = c.sizeOf(k, item.Object)
+		return item, item.size <= c.maxValueSize
+	case OversizeSpill:
+		if c.overflow == nil {
+			return item, false
+		}
+		item = c.overflow.spill(k, item)
+		_, ok := item.Object.(spilled)
+		return item, ok
+	}
+	return item, false
+}
+
+// errValueTooLarge ... The error for Data at k of size
+func errValueTooLarge(k string, size int64) error {
+	return fmt.Errorf("item %s of %d bytes: %w", k, size, ErrValueTooLarge)
+}
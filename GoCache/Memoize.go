@@ -0,0 +1,29 @@
+package GoCache
+
+import (
+	"fmt"
+	"time"
+)
+
+// MemoizeFunc ... Return fn cached in c: results are kept for d under
+// the key name:arg, so give every memoized function its own name
+// Concurrent calls with the same argument share one call of fn,
+// errors are not cached
+func MemoizeFunc[A comparable, R any](c *Cache, name string, d time.Duration, fn func(A) (R, error)) func(A) (R, error) {
+	prefix := name + NamespaceSeparator
+	return func(a A) (R, error) {
+		v, err := c.GetOrCompute(prefix+fmt.Sprintf("%#v", a), func() (interface{}, error) {
+			return fn(a)
+		}, d)
+		if err != nil {
+			var zero R
+			return zero, err
+		}
+		r, ok := v.(R)
+		if !ok && v != nil {
+			var zero R
+			return zero, fmt.Errorf("memoized %s: cached value of type %T is not %T: %w", name, v, zero, ErrTypeMismatch)
+		}
+		return r, nil
+	}
+}
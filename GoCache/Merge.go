@@ -0,0 +1,68 @@
+package GoCache
+
+// MergePolicy ... How Merge settles a key both Caches hold live: Return
+// the Item to store, theirs or one combining both, and true, or false to
+// keep mine as it is. The Items are as Items returns them
+type MergePolicy func(k string, mine, theirs Item) (Item, bool)
+
+// MergeNewest ... The Data with the later Item.CreatedAt wins, mine on a tie
+var MergeNewest MergePolicy = func(_ string, mine, theirs Item) (Item, bool) {
+	return theirs, theirs.CreatedAt > mine.CreatedAt
+}
+
+// MergeLongestTTL ... The Data Expiring last wins, Data that never
+// Expires over any, mine on a tie
+var MergeLongestTTL MergePolicy = func(_ string, mine, theirs Item) (Item, bool) {
+	if mine.Expiration == 0 {
+		return theirs, false
+	}
+	return theirs, theirs.Expiration == 0 || theirs.Expiration > mine.Expiration
+}
+
+// Merge ... Put the live Data of other into the Cache, settling keys
+// both hold by policy. other is copied first, so neither is locked
+// while the other is, and other is left as it was
+func (c *Cache) Merge(other *Cache, policy MergePolicy) {
+	c.mergeItems(other.Items(), policy)
+}
+
+// mergeItems ... Put items, settling the keys the Cache holds live by
+// policy
+func (c *Cache) mergeItems(items map[string]Item, policy MergePolicy) {
+	if sc := c.lockOrMoved(); sc != nil {
+		sc.mergeItems(items, policy)
+		return
+	}
+	defer c.unlock()
+	for k, theirs := range items {
+		mine, found := c.items[k]
+		if !found || c.expired(mine) {
+			c.put(k, theirs)
+			continue
+		}
+		if won, ok := policy(k, c.view(mine), theirs); ok {
+			c.put(k, won)
+		}
+	}
+}
+
+// Merge ... Put the live Data of other into the ShardedCache, settling
+// keys both hold by policy, see Cache.Merge
+func (sc *ShardedCache) Merge(other *ShardedCache, policy MergePolicy) {
+	sc.mergeItems(other.Items(), policy)
+}
+
+// mergeItems ... mergeItems of items in the shard of each key
+func (sc *ShardedCache) mergeItems(items map[string]Item, policy MergePolicy) {
+	parts := make(map[*Cache]map[string]Item, len(sc.shardSet()))
+	for k, item := range items {
+		c := sc.shardOf(k)
+		if parts[c] == nil {
+			parts[c] = map[string]Item{}
+		}
+		parts[c][k] = item
+	}
+	for c, items := range parts {
+		c.mergeItems(items, policy)
+	}
+}
@@ -0,0 +1,98 @@
+package GoCache
+
+import "sort"
+
+// WithMeta ... Attach meta to the Data, such as the request or tenant
+// it came from, to find it by with KeysWithMeta, RangeWithMeta and
+// DeleteWithMeta. GetItem and Items return it as Item.Meta. meta is
+// copied, keep it small: it is kept in memory and in snapshots next to
+// the Data but not counted in its size
+func WithMeta(meta map[string]string) SetOption {
+	m := make(map[string]string, len(meta))
+	for k, v := range meta {
+		m[k] = v
+	}
+	return func(item *Item) { item.Meta = m }
+}
+
+// hasMeta ... Report whether the Meta of item holds every pair of match
+func (item Item) hasMeta(match map[string]string) bool {
+	for k, v := range match {
+		if mv, found := item.Meta[k]; !found || mv != v {
+			return false
+		}
+	}
+	return true
+}
+
+// KeysWithMeta ... Return the sorted live keys whose Meta holds every
+// pair of match
+func (c *Cache) KeysWithMeta(match map[string]string) []string {
+	c.mutex.RLock()
+	var keys []string
+	for k, item := range c.items {
+		if !c.expired(item) && item.hasMeta(match) {
+			keys = append(keys, k)
+		}
+	}
+	c.mutex.RUnlock()
+	sort.Strings(keys)
+	return keys
+}
+
+// RangeWithMeta ... Call f for every live Data whose Meta holds every
+// pair of match until it returns false, as Range does
+func (c *Cache) RangeWithMeta(match map[string]string, f func(k string, item Item) bool) {
+	for k, item := range c.Items() {
+		if item.hasMeta(match) && !f(k, item) {
+			return
+		}
+	}
+}
+
+// DeleteWithMeta ... Delete all Data whose Meta holds every pair of
+// match, Return how many. match must not be empty
+func (c *Cache) DeleteWithMeta(match map[string]string) int {
+	if len(match) == 0 {
+		return 0
+	}
+	c.lock()
+	defer c.unlock()
+	n := 0
+	for k, item := range c.items {
+		if item.hasMeta(match) {
+			c.delete(k)
+			n++
+		}
+	}
+	return n
+}
+
+// KeysWithMeta ... Return the sorted live keys of all shards whose Meta
+// holds every pair of match
+func (sc *ShardedCache) KeysWithMeta(match map[string]string) []string {
+	return sc.mergeKeys(func(c *Cache) []string { return c.KeysWithMeta(match) })
+}
+
+// RangeWithMeta ... RangeWithMeta over every shard
+func (sc *ShardedCache) RangeWithMeta(match map[string]string, f func(k string, item Item) bool) {
+	for _, c := range sc.shardSet() {
+		stopped := false
+		c.RangeWithMeta(match, func(k string, item Item) bool {
+			stopped = !f(k, item)
+			return !stopped
+		})
+		if stopped {
+			return
+		}
+	}
+}
+
+// DeleteWithMeta ... DeleteWithMeta in every shard, Return how many
+func (sc *ShardedCache) DeleteWithMeta(match map[string]string) int {
+	n := 0
+	for _, c := range sc.shardSet() {
+		n += c.DeleteWithMeta(match)
+	}
+	return n
+}
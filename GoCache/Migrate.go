@@ -0,0 +1,60 @@
+package GoCache
+
+import "io"
+
+// Migrate ... Convert the snapshot in src into one written with toCodec
+// in dst, so a warmed Cache survives a change of persistence format.
+// fromCodec decodes streams from before headers, a header naming a
+// registered Codec wins over it as in Load. Every Data is kept as it
+// was, Expired or not: the Cache that Loads dst settles that. src may be
+// a file SaveToFile wrote without encryption, see MigrateFile for the
+// others; dst is a bare stream as Save writes
+func Migrate(src io.Reader, dst io.Writer, fromCodec, toCodec Codec) error {
+	items, err := readSnapshotItems(src, fromCodec, nil)
+	if err != nil {
+		return err
+	}
+	return encodeSnapshot(dst, toCodec, items)
+}
+
+// MigrateFile ... Migrate the snapshot file src, decrypted with fromKey
+// if it is encrypted, into the file dst, written atomically as
+// SaveToFile of a Cache made with opts would: WithCodec picks the new
+// Codec, GobCodec if none, WithCompression and WithSnapshotEncryption
+// the wrapping. src and dst may be the same file
+//
+//	err := GoCache.MigrateFile("cache.snap", "cache.snap", GoCache.GobCodec, nil,
+//		GoCache.WithCodec(GoCache.MsgpackCodec), GoCache.WithSnapshotEncryption(key))
+func MigrateFile(src, dst string, fromCodec Codec, fromKey []byte, opts ...Option) error {
+	o := buildOptions(opts)
+	toCodec := o.Codec
+	if toCodec == nil {
+		toCodec = GobCodec
+	}
+	var items map[string]Item
+	err := readFile(src, func(r io.Reader) error {
+		var err error
+		items, err = readSnapshotItems(r, fromCodec, fromKey)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(dst, false, sealFile(o.Compress, o.SnapshotKey, func(w io.Writer) error {
+		return encodeSnapshot(w, toCodec, items)
+	}))
+}
+
+// readSnapshotItems ... Every item of the snapshot in r, a stream or a
+// file decrypted with key
+func readSnapshotItems(r io.Reader, codec Codec, key []byte) (map[string]Item, error) {
+	items := map[string]Item{}
+	err := openFile(key, func(r io.Reader) error {
+		return decodeSnapshot(r, codec, func(batch map[string]Item) {
+			for k, v := range batch {
+				items[k] = v
+			}
+		})
+	})(r)
+	return items, err
+}
@@ -0,0 +1,39 @@
+package GoCache
+
+import (
+	"fmt"
+	"time"
+)
+
+// Modify ... Replace the live Data at k by what fn makes of it, in one
+// step under the lock, and Return it. Live Data keeps its Expiration
+// and options whatever its deadline, so Pinned Data and Data held by
+// PauseExpiration stay as they are; a missing k reaches fn with found
+// false and what it makes is Set with Expiration d. An error of fn
+// leaves k alone and is returned. fn must not call the Cache
+func (c *Cache) Modify(k string, d time.Duration, fn func(v interface{}, found bool) (interface{}, error)) (interface{}, error) {
+	k = c.key(k)
+	c = c.lockFor(k)
+	defer c.unlock()
+	item, found := c.items[k]
+	found = found && !c.expired(item)
+	var old interface{}
+	if found {
+		old = c.open(item.Object)
+	}
+	v, err := fn(old, found)
+	if err != nil {
+		return nil, fmt.Errorf("item %s: %w", k, err)
+	}
+	if !found {
+		return v, c.set(k, v, d)
+	}
+	item.Object = v
+	item.size = 0
+	return v, c.put(k, item)
+}
+
+// Modify ... Modify the Data in the shard of k
+func (sc *ShardedCache) Modify(k string, d time.Duration, fn func(v interface{}, found bool) (interface{}, error)) (interface{}, error) {
+	return sc.shard(k).Modify(k, d, fn)
+}
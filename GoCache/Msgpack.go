@@ -0,0 +1,404 @@
+package GoCache
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// MsgpackCodec ... Compact and readable by any msgpack library: a map
+// of the keys to maps of the fields of their Item, named as in Go and
+// left out when zero, except Object. Data must be portable: nil, bools,
+// numbers, strings, []byte and slices and string keyed maps of them.
+// It comes back as nil, bool, int64, uint64 for what int64 cannot hold,
+// float64, string, []byte, []interface{} and map[string]interface{}
+var MsgpackCodec Codec = msgpackCodec{}
+
+type msgpackCodec struct{}
+
+func (msgpackCodec) Name() string { return "msgpack" }
+
+// Longest string, []byte, array or map a decode allocates for, past it
+// the stream is taken for corrupt
+const msgpackMaxLen = 1 << 30
+
+var errMsgpack = errors.New("msgpack: invalid stream")
+
+func (msgpackCodec) Encode(w io.Writer, items map[string]Item) error {
+	var buf bytes.Buffer
+	msgpackLen(&buf, 0x80, 0xde, 0xdf, len(items))
+	for _, k := range sortedKeys(items) {
+		fields, err := itemFields(items[k])
+		if err != nil {
+			return fmt.Errorf("item %s: %w", k, err)
+		}
+		msgpackString(&buf, k)
+		msgpackValue(&buf, fields)
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func (msgpackCodec) Decode(r io.Reader) (map[string]Item, error) {
+	br, ok := r.(msgpackReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	v, err := msgpackDecode(br)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: items are a %T", errMsgpack, v)
+	}
+	items := make(map[string]Item, len(m))
+	for k, fields := range m {
+		f, ok := fields.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: item %s is a %T", errMsgpack, k, fields)
+		}
+		items[k] = fieldsItem(f)
+	}
+	return items, nil
+}
+
+// itemFields ... The fields of item as MsgpackCodec writes them
+func itemFields(item Item) (map[string]interface{}, error) {
+	object, err := portable(item.Object)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{"Object": object}
+	for name, v := range map[string]int64{
+		"Expiration":     item.Expiration,
+		"SoftExpiration": item.SoftExpiration,
+		"ComputeCost":    int64(item.ComputeCost),
+		"Priority":       int64(item.Priority),
+		"Sliding":        int64(item.Sliding),
+		"CreatedAt":      item.CreatedAt,
+		"LastAccessedAt": item.LastAccessedAt,
+	} {
+		if v != 0 {
+			fields[name] = v
+		}
+	}
+	if len(item.Tags) > 0 {
+		fields["Tags"], _ = portable(item.Tags)
+	}
+	if len(item.Deps) > 0 {
+		fields["Deps"], _ = portable(item.Deps)
+	}
+	if len(item.Meta) > 0 {
+		fields["Meta"], _ = portable(item.Meta)
+	}
+	return fields, nil
+}
+
+// fieldsItem ... The Item of fields written by itemFields, fields of
+// the wrong type are left zero
+func fieldsItem(fields map[string]interface{}) Item {
+	num := func(name string) int64 {
+		switch x := fields[name].(type) {
+		case int64:
+			return x
+		case uint64:
+			return int64(x)
+		case float64:
+			return int64(x)
+		}
+		return 0
+	}
+	strs := func(name string) []string {
+		list, _ := fields[name].([]interface{})
+		var out []string
+		for _, e := range list {
+			if s, ok := e.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	var meta map[string]string
+	m, _ := fields["Meta"].(map[string]interface{})
+	for k, v := range m {
+		if s, ok := v.(string); ok {
+			if meta == nil {
+				meta = map[string]string{}
+			}
+			meta[k] = s
+		}
+	}
+	return Item{
+		Object:         fields["Object"],
+		Expiration:     num("Expiration"),
+		SoftExpiration: num("SoftExpiration"),
+		ComputeCost:    time.Duration(num("ComputeCost")),
+		Priority:       Priority(num("Priority")),
+		Sliding:        time.Duration(num("Sliding")),
+		CreatedAt:      num("CreatedAt"),
+		LastAccessedAt: num("LastAccessedAt"),
+		Tags:           strs("Tags"),
+		Deps:           strs("Deps"),
+		Meta:           meta,
+	}
+}
+
+// msgpackValue ... Append the portable value v
+func msgpackValue(buf *bytes.Buffer, v interface{}) {
+	switch x := v.(type) {
+	case nil:
+		buf.WriteByte(0xc0)
+	case bool:
+		if x {
+			buf.WriteByte(0xc3)
+		} else {
+			buf.WriteByte(0xc2)
+		}
+	case int64:
+		if x >= 0 {
+			msgpackUint(buf, uint64(x))
+		} else if x >= -32 {
+			buf.WriteByte(byte(x))
+		} else if x >= math.MinInt8 {
+			buf.Write([]byte{0xd0, byte(x)})
+		} else if x >= math.MinInt16 {
+			buf.WriteByte(0xd1)
+			buf.Write(binary.BigEndian.AppendUint16(nil, uint16(x)))
+		} else if x >= math.MinInt32 {
+			buf.WriteByte(0xd2)
+			buf.Write(binary.BigEndian.AppendUint32(nil, uint32(x)))
+		} else {
+			buf.WriteByte(0xd3)
+			buf.Write(binary.BigEndian.AppendUint64(nil, uint64(x)))
+		}
+	case uint64:
+		msgpackUint(buf, x)
+	case float64:
+		buf.WriteByte(0xcb)
+		buf.Write(binary.BigEndian.AppendUint64(nil, math.Float64bits(x)))
+	case string:
+		msgpackString(buf, x)
+	case []byte:
+		switch n := len(x); {
+		case n <= math.MaxUint8:
+			buf.Write([]byte{0xc4, byte(n)})
+		case n <= math.MaxUint16:
+			buf.WriteByte(0xc5)
+			buf.Write(binary.BigEndian.AppendUint16(nil, uint16(n)))
+		default:
+			buf.WriteByte(0xc6)
+			buf.Write(binary.BigEndian.AppendUint32(nil, uint32(n)))
+		}
+		buf.Write(x)
+	case []interface{}:
+		msgpackLen(buf, 0x90, 0xdc, 0xdd, len(x))
+		for _, e := range x {
+			msgpackValue(buf, e)
+		}
+	case map[string]interface{}:
+		msgpackLen(buf, 0x80, 0xde, 0xdf, len(x))
+		for _, k := range sortedKeys(x) {
+			msgpackString(buf, k)
+			msgpackValue(buf, x[k])
+		}
+	}
+}
+
+func msgpackUint(buf *bytes.Buffer, x uint64) {
+	switch {
+	case x <= 0x7f:
+		buf.WriteByte(byte(x))
+	case x <= math.MaxUint8:
+		buf.Write([]byte{0xcc, byte(x)})
+	case x <= math.MaxUint16:
+		buf.WriteByte(0xcd)
+		buf.Write(binary.BigEndian.AppendUint16(nil, uint16(x)))
+	case x <= math.MaxUint32:
+		buf.WriteByte(0xce)
+		buf.Write(binary.BigEndian.AppendUint32(nil, uint32(x)))
+	default:
+		buf.WriteByte(0xcf)
+		buf.Write(binary.BigEndian.AppendUint64(nil, x))
+	}
+}
+
+func msgpackString(buf *bytes.Buffer, s string) {
+	switch n := len(s); {
+	case n < 32:
+		buf.WriteByte(0xa0 | byte(n))
+	case n <= math.MaxUint8:
+		buf.Write([]byte{0xd9, byte(n)})
+	case n <= math.MaxUint16:
+		buf.WriteByte(0xda)
+		buf.Write(binary.BigEndian.AppendUint16(nil, uint16(n)))
+	default:
+		buf.WriteByte(0xdb)
+		buf.Write(binary.BigEndian.AppendUint32(nil, uint32(n)))
+	}
+	buf.WriteString(s)
+}
+
+// msgpackLen ... Append the header of an array or map of n entries, in
+// its fix form, or with a 16 or 32 bit length
+func msgpackLen(buf *bytes.Buffer, fix, b16, b32 byte, n int) {
+	switch {
+	case n < 16:
+		buf.WriteByte(fix | byte(n))
+	case n <= math.MaxUint16:
+		buf.WriteByte(b16)
+		buf.Write(binary.BigEndian.AppendUint16(nil, uint16(n)))
+	default:
+		buf.WriteByte(b32)
+		buf.Write(binary.BigEndian.AppendUint32(nil, uint32(n)))
+	}
+}
+
+type msgpackReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+// msgpackDecode ... Read one value
+func msgpackDecode(r msgpackReader) (interface{}, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case b <= 0x7f:
+		return int64(b), nil
+	case b >= 0xe0:
+		return int64(int8(b)), nil
+	case b&0xf0 == 0x80:
+		return msgpackMap(r, int(b&0x0f))
+	case b&0xf0 == 0x90:
+		return msgpackArray(r, int(b&0x0f))
+	case b&0xe0 == 0xa0:
+		return msgpackStr(r, int(b&0x1f))
+	}
+	switch b {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xc4, 0xc5, 0xc6:
+		n, err := msgpackN(r, b-0xc4)
+		if err != nil {
+			return nil, err
+		}
+		return msgpackBytes(r, n)
+	case 0xca:
+		u, err := msgpackFixed(r, 4)
+		return float64(math.Float32frombits(uint32(u))), err
+	case 0xcb:
+		u, err := msgpackFixed(r, 8)
+		return math.Float64frombits(u), err
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		u, err := msgpackFixed(r, 1<<(b-0xcc))
+		if u > math.MaxInt64 {
+			return u, err
+		}
+		return int64(u), err
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		size := 1 << (b - 0xd0)
+		u, err := msgpackFixed(r, size)
+		// Sign extend from size bytes
+		shift := 64 - 8*size
+		return int64(u<<shift) >> shift, err
+	case 0xd9, 0xda, 0xdb:
+		n, err := msgpackN(r, b-0xd9)
+		if err != nil {
+			return nil, err
+		}
+		return msgpackStr(r, n)
+	case 0xdc, 0xdd:
+		n, err := msgpackN(r, b-0xdc+1)
+		if err != nil {
+			return nil, err
+		}
+		return msgpackArray(r, n)
+	case 0xde, 0xdf:
+		n, err := msgpackN(r, b-0xde+1)
+		if err != nil {
+			return nil, err
+		}
+		return msgpackMap(r, n)
+	}
+	return nil, fmt.Errorf("%w: type byte %#x", errMsgpack, b)
+}
+
+// msgpackFixed ... Read a big endian number of size bytes
+func msgpackFixed(r msgpackReader, size int) (uint64, error) {
+	var b [8]byte
+	if _, err := io.ReadFull(r, b[8-size:]); err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(b[:]), nil
+}
+
+// msgpackN ... Read a length of 1, 2 or 4 bytes for width 0, 1 or 2
+func msgpackN(r msgpackReader, width byte) (int, error) {
+	u, err := msgpackFixed(r, 1<<width)
+	if err == nil && u > msgpackMaxLen {
+		err = fmt.Errorf("%w: length %d", errMsgpack, u)
+	}
+	return int(u), err
+}
+
+func msgpackBytes(r msgpackReader, n int) ([]byte, error) {
+	b := make([]byte, n)
+	_, err := io.ReadFull(r, b)
+	return b, err
+}
+
+func msgpackStr(r msgpackReader, n int) (interface{}, error) {
+	b, err := msgpackBytes(r, n)
+	return string(b), err
+}
+
+func msgpackArray(r msgpackReader, n int) (interface{}, error) {
+	list := make([]interface{}, 0, msgpackCap(n))
+	for i := 0; i < n; i++ {
+		v, err := msgpackDecode(r)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, v)
+	}
+	return list, nil
+}
+
+func msgpackMap(r msgpackReader, n int) (interface{}, error) {
+	m := make(map[string]interface{}, msgpackCap(n))
+	for i := 0; i < n; i++ {
+		k, err := msgpackDecode(r)
+		if err != nil {
+			return nil, err
+		}
+		s, ok := k.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: map key is a %T", errMsgpack, k)
+		}
+		if m[s], err = msgpackDecode(r); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// msgpackCap ... Room to make for n entries, trusting a length read from
+// the stream only so far
+func msgpackCap(n int) int {
+	if n > 1024 {
+		return 1024
+	}
+	return n
+}
@@ -0,0 +1,69 @@
+package GoCache
+
+import "time"
+
+// GetMulti ... Get the live Data of keys under a single lock,
+// missing keys are left out of the returned map, which holds the keys
+// as given
+func (c *Cache) GetMulti(keys []string) map[string]interface{} {
+	res := make(map[string]interface{}, len(keys))
+	var slides, refreshes []string
+	write := c.lockForRead()
+	if sc := c.movedTo; sc != nil {
+		c.unlockForRead(write)
+		return sc.GetMulti(keys)
+	}
+	for _, key := range keys {
+		k := c.key(key)
+		v, found := c.get(k)
+		c.countRead(k, found)
+		if !found {
+			continue
+		}
+		res[key] = v
+		if c.items[k].Sliding > 0 {
+			slides = append(slides, k)
+		} else if c.needsRefresh(k) {
+			refreshes = append(refreshes, k)
+		}
+	}
+	c.unlockForRead(write)
+	for _, k := range slides {
+		c.slide(k)
+	}
+	for _, k := range refreshes {
+		c.refreshAhead(k)
+	}
+	return res
+}
+
+// SetMulti ... Set all items with Expiration d under a single lock
+func (c *Cache) SetMulti(items map[string]interface{}, d time.Duration) {
+	if sc := c.lockOrMoved(); sc != nil {
+		sc.SetMulti(items, d)
+		return
+	}
+	defer c.unlock()
+	for k, v := range items {
+		c.set(c.key(k), v, d)
+	}
+}
+
+// DeleteMulti ... Delete all keys under a single lock
+func (c *Cache) DeleteMulti(keys []string) {
+	if sc := c.lockOrMoved(); sc != nil {
+		for c, part := range sc.splitKeys(keys) {
+			c.DeleteMulti(part)
+		}
+		return
+	}
+	keys = c.keys(keys)
+	for _, k := range keys {
+		c.delete(k)
+	}
+	inv := c.invalidator
+	c.unlock()
+	for _, k := range keys {
+		publish(inv, c.instanceID, Invalidation{Key: k})
+	}
+}
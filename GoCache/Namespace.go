@@ -0,0 +1,193 @@
+package GoCache
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// NamespaceSeparator ... Put between a namespace name and the keys in it
+const NamespaceSeparator = ":"
+
+// Namespace ... View of a Cache whose keys are stored under a prefix,
+// so subsystems sharing one Cache get their own keyspace
+type Namespace struct {
+	cache     *Cache
+	name      string
+	prefix    string
+	match     string         // prefix in canonical form, see WithKeyFunc
+	transform ValueTransform // See WithTransform
+}
+
+// Namespace ... Return the view of the keys under name
+func (c *Cache) Namespace(name string) *Namespace {
+	return c.newNamespace(name, name+NamespaceSeparator)
+}
+
+func (c *Cache) newNamespace(name, prefix string) *Namespace {
+	return &Namespace{cache: c, name: name, prefix: prefix, match: c.key(prefix)}
+}
+
+// namespacePrefix ... The prefix of the keys of namespace name, in
+// canonical form
+func (c *Cache) namespacePrefix(name string) string {
+	return c.key(name + NamespaceSeparator)
+}
+
+// FlushNamespace ... Delete all Data of namespace name, Return how many
+func (c *Cache) FlushNamespace(name string) int {
+	return c.deletePrefix(c.namespacePrefix(name))
+}
+
+// deletePrefix ... Delete all Data whose key starts with prefix
+func (c *Cache) deletePrefix(prefix string) int {
+	c.lock()
+	n := 0
+	var keys []string
+	if c.prefixes != nil {
+		keys = c.prefixes.withPrefix(prefix)
+	} else {
+		for k := range c.items {
+			if strings.HasPrefix(k, prefix) {
+				keys = append(keys, k)
+			}
+		}
+	}
+	for _, k := range keys {
+		c.delete(k)
+		n++
+	}
+	inv := c.invalidator
+	c.unlock()
+	for _, k := range keys {
+		publish(inv, c.instanceID, Invalidation{Key: k})
+	}
+	return n
+}
+
+// Name ... Return the name of the namespace
+func (ns *Namespace) Name() string {
+	return ns.name
+}
+
+// Namespace ... Return a namespace nested in this one
+func (ns *Namespace) Namespace(name string) *Namespace {
+	return ns.cache.newNamespace(ns.name+NamespaceSeparator+name, ns.prefix+name+NamespaceSeparator)
+}
+
+// Set ... To Set the Data, Data the transform of the namespace cannot
+// seal is not stored but counted as Rejected, SetE tells why
+func (ns *Namespace) Set(k string, v interface{}, d time.Duration) {
+	ns.SetE(k, v, d)
+}
+
+// SetE ... Set the Data, or Return the error that kept it out
+func (ns *Namespace) SetE(k string, v interface{}, d time.Duration) error {
+	v, err := ns.seal(v)
+	if err != nil {
+		ns.cache.stats.rejections.Add(1)
+		return fmt.Errorf("item %s: %w", ns.prefix+k, err)
+	}
+	return ns.cache.SetE(ns.prefix+k, v, ns.expiration(d))
+}
+
+// Get ... To Get the Data
+func (ns *Namespace) Get(k string) (interface{}, bool) {
+	v, found := ns.cache.Get(ns.prefix + k)
+	return ns.open(v, found)
+}
+
+// GetWithExpiration ... Get the Data and the time it Expires
+func (ns *Namespace) GetWithExpiration(k string) (interface{}, time.Time, bool) {
+	v, e, found := ns.cache.GetWithExpiration(ns.prefix + k)
+	v, found = ns.open(v, found)
+	return v, e, found
+}
+
+// Add ... Add Data if it did not Exist yet
+func (ns *Namespace) Add(k string, v interface{}, d time.Duration) error {
+	v, err := ns.seal(v)
+	if err != nil {
+		return err
+	}
+	return ns.cache.Add(ns.prefix+k, v, ns.expiration(d))
+}
+
+// Replace ... Set Data only if it Exists already
+func (ns *Namespace) Replace(k string, v interface{}, d time.Duration) error {
+	v, err := ns.seal(v)
+	if err != nil {
+		return err
+	}
+	return ns.cache.Replace(ns.prefix+k, v, ns.expiration(d))
+}
+
+// expiration ... Resolve DefaultExpiration to the one ConfigureNamespace
+// gave the namespace, if any
+func (ns *Namespace) expiration(d time.Duration) time.Duration {
+	return ns.cache.namespaceExpiration(ns.match, d)
+}
+
+// seal ... Return v sealed by the transform of the namespace, if any
+func (ns *Namespace) seal(v interface{}) (interface{}, error) {
+	if ns.transform == nil {
+		return v, nil
+	}
+	b, err := seal(ns.transform, v)
+	if err != nil {
+		return nil, err
+	}
+	return nsSealed{b}, nil
+}
+
+// open ... Undo seal on a Get result
+func (ns *Namespace) open(v interface{}, found bool) (interface{}, bool) {
+	if ns.transform == nil || !found {
+		return v, found
+	}
+	s, ok := v.(nsSealed)
+	if !ok {
+		return nil, false
+	}
+	v, err := unseal(ns.transform, s.Data)
+	return v, err == nil
+}
+
+// Touch ... Reset the Expiration of existing Data to d from now
+func (ns *Namespace) Touch(k string, d time.Duration) bool {
+	return ns.cache.Touch(ns.prefix+k, ns.expiration(d))
+}
+
+// Increment ... Add n to the number stored at k
+func (ns *Namespace) Increment(k string, n int64) (interface{}, error) {
+	return ns.cache.Increment(ns.prefix+k, n)
+}
+
+// Decrement ... Subtract n from the number stored at k
+func (ns *Namespace) Decrement(k string, n int64) (interface{}, error) {
+	return ns.cache.Decrement(ns.prefix+k, n)
+}
+
+// Delete ... Delete the Data
+func (ns *Namespace) Delete(k string) {
+	ns.cache.Delete(ns.prefix + k)
+}
+
+// Keys ... Return the sorted live keys of the namespace, without prefix
+func (ns *Namespace) Keys() []string {
+	keys := ns.cache.KeysWithPrefix(ns.match)
+	for i, k := range keys {
+		keys[i] = k[len(ns.match):]
+	}
+	return keys
+}
+
+// Count ... Return Number of live Data In the namespace
+func (ns *Namespace) Count() int {
+	return len(ns.cache.KeysWithPrefix(ns.match))
+}
+
+// Flush ... Delete all Data of the namespace, nested ones included
+func (ns *Namespace) Flush() int {
+	return ns.cache.deletePrefix(ns.match)
+}
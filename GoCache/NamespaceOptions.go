@@ -0,0 +1,228 @@
+package GoCache
+
+import (
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// NamespaceOptions ... Settings of one namespace, see ConfigureNamespace
+type NamespaceOptions struct {
+	// DefaultExpiration ... Expiration of Data Set through the Namespace
+	// with DefaultExpiration, zero keeps the one of the Cache
+	DefaultExpiration time.Duration
+	// MaxEntries ... Evict Data of the namespace beyond this many entries,
+	// zero means bounded by the Cache only
+	MaxEntries int
+	// MaxBytes ... Evict Data of the namespace beyond this approximate
+	// size, zero means bounded by the Cache only
+	MaxBytes int64
+	// EvictionPolicy ... Make the policy picking what to evict within the
+	// namespace, nil means NewLRUPolicy
+	EvictionPolicy func() EvictionPolicy
+	// Stats ... Keep NamespaceStats though nothing else is set, every
+	// configured namespace keeps them
+	Stats bool
+}
+
+// NamespaceStats ... Counters of a configured namespace since it was
+// configured, see Cache.NamespaceStats
+type NamespaceStats struct {
+	Items     int    // Data In the namespace now, Expired ones included
+	Bytes     int64  // Approximate size of the Data
+	Hits      uint64 // Reads that found live Data
+	Misses    uint64 // Reads that found nothing or Expired Data, but for those WithBloomFilter answers
+	Sets      uint64 // Data stored
+	Evictions uint64 // Data Evicted, by the bounds of the namespace or of the Cache
+	Expired   uint64 // Data removed because it Expired
+}
+
+// HitRatio ... Return Hits over all reads, zero before the first read
+func (s NamespaceStats) HitRatio() float64 {
+	return Stats{Hits: s.Hits, Misses: s.Misses}.HitRatio()
+}
+
+// namespaceLimits ... State of a configured namespace
+type namespaceLimits struct {
+	opts      NamespaceOptions
+	policy    EvictionPolicy // nil without a bound
+	newPolicy func() EvictionPolicy
+	count     int   // Data under the prefix, nested namespaces with their own settings excepted
+	bytes     int64 // Size of that Data
+	// Counters of NamespaceStats, reads are counted under the read lock
+	hits, misses              atomic.Uint64
+	sets, evictions, expiries uint64
+}
+
+// bounded ... Report whether the namespace has a bound of its own
+func (opts NamespaceOptions) bounded() bool {
+	return opts.MaxEntries > 0 || opts.MaxBytes > 0
+}
+
+// over ... Report whether the namespace is beyond its bounds
+func (nl *namespaceLimits) over() bool {
+	return nl.opts.MaxEntries > 0 && nl.count > nl.opts.MaxEntries ||
+		nl.opts.MaxBytes > 0 && nl.bytes > nl.opts.MaxBytes
+}
+
+// read ... Count a read of the namespace as a hit or a miss
+func (nl *namespaceLimits) read(found bool) {
+	if found {
+		nl.hits.Add(1)
+	} else {
+		nl.misses.Add(1)
+	}
+}
+
+// removed ... Count Data leaving the namespace for why
+func (nl *namespaceLimits) removed(why EventType) {
+	switch why {
+	case EventEvict:
+		nl.evictions++
+	case EventExpire:
+		nl.expiries++
+	}
+}
+
+// ConfigureNamespace ... Give namespace name its own default Expiration,
+// entry and byte bounds, eviction policy and Stats, replacing earlier
+// settings and Stats; the zero NamespaceOptions drops them
+// The bounds cover every key under the namespace prefix, however it was
+// Set, but a nested namespace with settings of its own is bounded by
+// those alone. A namespace over its bounds Evicts its own Data only, so
+// quotas for the tenants of a shared Cache keep a noisy one from
+// Evicting the Data of others; the bounds of the Cache still Evict
+// across namespaces, so keep the quotas within them. Lowering a bound
+// Evicts at once, Data already In Cache is fed to a new policy in no
+// particular order
+func (c *Cache) ConfigureNamespace(name string, opts NamespaceOptions) {
+	prefix := c.namespacePrefix(name)
+	c.mutex.Lock()
+	defer c.unlock()
+	var keys []string
+	var owners []*namespaceLimits
+	for k := range c.items {
+		if strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+			owners = append(owners, c.namespaceOf(k))
+		}
+	}
+	if opts.DefaultExpiration == 0 && !opts.bounded() && opts.EvictionPolicy == nil && !opts.Stats {
+		delete(c.namespaces, prefix)
+	} else {
+		nl := &namespaceLimits{opts: opts}
+		if opts.bounded() {
+			nl.newPolicy = opts.EvictionPolicy
+			if nl.newPolicy == nil {
+				nl.newPolicy = NewLRUPolicy
+			}
+			nl.policy = nl.newPolicy()
+			c.tracksReads.Store(true)
+		}
+		if c.namespaces == nil {
+			c.namespaces = map[string]*namespaceLimits{}
+		}
+		c.namespaces[prefix] = nl
+	}
+	// Only keys under prefix may change namespace, move them over
+	gained := map[*namespaceLimits]bool{}
+	for i, k := range keys {
+		from, to := owners[i], c.namespaceOf(k)
+		if from == to {
+			continue
+		}
+		size := c.items[k].size
+		if from != nil {
+			from.count--
+			from.bytes -= size
+			if from.policy != nil {
+				from.policy.OnDelete(k)
+			}
+		}
+		if to != nil {
+			to.count++
+			to.bytes += size
+			if to.policy != nil {
+				to.policy.OnSet(k)
+			}
+			gained[to] = true
+		}
+	}
+	for nl := range gained {
+		c.evictNamespace(nl)
+	}
+}
+
+// Configure ... ConfigureNamespace of this namespace
+func (ns *Namespace) Configure(opts NamespaceOptions) {
+	ns.cache.ConfigureNamespace(ns.name, opts)
+}
+
+// namespaceOf ... Return the settings of the innermost configured
+// namespace holding k, nil if none
+func (c *Cache) namespaceOf(k string) *namespaceLimits {
+	if len(c.namespaces) == 0 {
+		return nil
+	}
+	for i := strings.LastIndex(k, NamespaceSeparator); i >= 0; i = strings.LastIndex(k[:i], NamespaceSeparator) {
+		if nl, ok := c.namespaces[k[:i+len(NamespaceSeparator)]]; ok {
+			return nl
+		}
+	}
+	return nil
+}
+
+// namespaceExpiration ... Resolve DefaultExpiration for the namespace
+// with prefix, leaving other durations alone
+func (c *Cache) namespaceExpiration(prefix string, d time.Duration) time.Duration {
+	if d != DefaultExpiration {
+		return d
+	}
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	if nl, ok := c.namespaces[prefix]; ok && nl.opts.DefaultExpiration != 0 {
+		return nl.opts.DefaultExpiration
+	}
+	return d
+}
+
+// evictNamespace ... Evict the victims of the policy of nl until it
+// fits its bound again
+func (c *Cache) evictNamespace(nl *namespaceLimits) {
+	if nl.policy == nil {
+		return
+	}
+	for nl.over() {
+		victim, ok := nl.policy.Victim()
+		if !ok {
+			break
+		}
+		c.remove(victim, EventEvict)
+		c.stats.evictions.Add(1)
+	}
+}
+
+// NamespaceStats ... Return the counters of the configured namespace
+// name, false if it is not configured
+func (c *Cache) NamespaceStats(name string) (NamespaceStats, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	nl, ok := c.namespaces[c.namespacePrefix(name)]
+	if !ok {
+		return NamespaceStats{}, false
+	}
+	return NamespaceStats{
+		Items:     nl.count,
+		Bytes:     nl.bytes,
+		Hits:      nl.hits.Load(),
+		Misses:    nl.misses.Load(),
+		Sets:      nl.sets,
+		Evictions: nl.evictions,
+		Expired:   nl.expiries,
+	}, true
+}
+
+// Stats ... NamespaceStats of this namespace
+func (ns *Namespace) Stats() (NamespaceStats, bool) {
+	return ns.cache.NamespaceStats(ns.name)
+}
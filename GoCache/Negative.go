@@ -0,0 +1,22 @@
+package GoCache
+
+import "time"
+
+// Negative ... Type of NegativeEntry
+type Negative bool
+
+// NegativeEntry ... Value Get returns for keys cached as known missing
+// by SetNegative, found is true for them so the caller can tell them
+// apart from keys the Cache has never seen
+const NegativeEntry Negative = true
+
+// SetNegative ... Cache k as known missing for d
+func (c *Cache) SetNegative(k string, d time.Duration) {
+	c.Set(k, NegativeEntry, d)
+}
+
+// IsNegative ... Report whether k is cached as known missing
+func (c *Cache) IsNegative(k string) bool {
+	v, found := c.Get(k)
+	return found && v == NegativeEntry
+}
@@ -0,0 +1,38 @@
+package GoCache
+
+import "time"
+
+// expiredCall ... An OnExpired callback waiting for the lock to be released
+type expiredCall struct {
+	kv keyValue
+	f  func(string, interface{})
+}
+
+// SetWithOnExpired ... Set the Data and call f with its key and value
+// when it Expires and the GC or lazy Expiration removes it
+// Delete, eviction or a later Set of k drop f without calling it
+// f runs after the Cache lock is released, so it may use the Cache
+func (c *Cache) SetWithOnExpired(k string, v interface{}, d time.Duration, f func(string, interface{})) {
+	k = c.key(k)
+	c = c.lockFor(k)
+	defer c.unlock()
+	item := Item{
+		Object:     v,
+		Expiration: c.expiration(d),
+		onExpired:  f,
+	}
+	if c.slidingByDefault && item.Expiration > 0 {
+		item.Sliding = c.resolve(d)
+	}
+	c.put(k, item)
+}
+
+// expire ... Delete the Expired Data at k and queue its OnExpired callback
+func (c *Cache) expire(k string) {
+	item := c.items[k]
+	if item.onExpired != nil {
+		c.expiredCalls = append(c.expiredCalls, expiredCall{keyValue{k, c.open(item.Object)}, item.onExpired})
+	}
+	c.queueExpired(k, item)
+	c.remove(k, EventExpire)
+}
@@ -0,0 +1,280 @@
+package GoCache
+
+import "time"
+
+// DefaultGcInterval ... GC interval used when none is given
+const DefaultGcInterval = time.Minute
+
+// Options ... Settings of a Cache made by NewCacheWithOptions
+type Options struct {
+	DefaultExpiration time.Duration
+	// GcInterval ... Time between GC sweeps, DefaultGcInterval if not positive
+	GcInterval time.Duration
+	// InitialCapacity ... See WithInitialCapacity
+	InitialCapacity int
+	// MaxEntries ... Evict Data once the Cache holds more than this,
+	// zero means unbounded
+	MaxEntries int
+	// MaxBytes ... Evict Data once the approximate size of all Data
+	// goes above this, zero means unbounded
+	// With a Sizer it is a bound on the total weight it reports
+	MaxBytes int64
+	// Sizer ... See WithSizer
+	Sizer ItemSizer
+	// CopyOnRead ... See WithCopyOnRead, nil hands out the Data itself
+	CopyOnRead Cloner
+	// ValueTransform ... See WithValueTransform
+	ValueTransform ValueTransform
+	// EvictionPolicy ... Make the policy picking what to evict, one of
+	// NewLRUPolicy, NewLFUPolicy, NewFIFOPolicy, NewClockPolicy,
+	// NewSampledLRUPolicy(n) or your own
+	// nil means NewLRUPolicy
+	EvictionPolicy func() EvictionPolicy
+	// NoLRUPromoteOnGet ... See WithLRUPromoteOnGet
+	NoLRUPromoteOnGet bool
+	// Admission ... Make the policy deciding whether new Data may evict
+	// old Data, like NewTinyLFU, nil admits everything
+	// It only applies to bounded Caches
+	Admission func() AdmissionPolicy
+	// ExpirationIndex ... Keep the Data that can Expire in a heap by
+	// Expiration, so GC sweeps cost the number of Expired Data instead
+	// of the size of the Cache, at some cost on every Set
+	ExpirationIndex bool
+	// SweepBatch and SweepPause ... Bound the work of one GC tick to this
+	// many keys or this long holding the lock, later ticks carry on
+	// where it stopped; zero leaves that bound off
+	SweepBatch int
+	SweepPause time.Duration
+	// PersistFile ... Close saves the Cache to this file when set
+	PersistFile string
+	// Compress ... SaveToFile gzips the file, LoadFile reads both
+	Compress bool
+	// SnapshotKey ... See WithSnapshotEncryption
+	SnapshotKey []byte
+	// SnapshotFile, SnapshotInterval and SnapshotKeep ... See WithSnapshot
+	// and WithSnapshotKeep
+	SnapshotFile     string
+	SnapshotInterval time.Duration
+	SnapshotKeep     int
+	// OverflowDir and OverflowThreshold ... See WithDiskOverflow
+	OverflowDir       string
+	OverflowThreshold int64
+	// MaxValueSize and OversizePolicy ... See WithMaxValueSize
+	MaxValueSize   int64
+	OversizePolicy OversizePolicy
+	// StatsBucket and StatsBuckets ... See WithStatsWindow
+	StatsBucket  time.Duration
+	StatsBuckets int
+	// HotKeys ... See WithHotKeys
+	HotKeys int
+	// LockFreeReads ... See WithLockFreeReads
+	LockFreeReads bool
+	// BreakerFailures, BreakerCoolDown and BreakerPerKey ... See
+	// WithCircuitBreaker and WithKeyCircuitBreaker
+	BreakerFailures int
+	BreakerCoolDown time.Duration
+	BreakerPerKey   bool
+	// KeyFunc ... See WithKeyFunc
+	KeyFunc func(string) string
+	// PrefixIndex ... See WithPrefixIndex
+	PrefixIndex bool
+	// TTLJitter ... See WithTTLJitter
+	TTLJitter float64
+	// EarlyExpiration ... See WithEarlyExpiration
+	EarlyExpiration float64
+	// BloomKeys and BloomFalsePositiveRate ... See WithBloomFilter
+	BloomKeys              int
+	BloomFalsePositiveRate float64
+	// ShardHasher ... Picks the shard of a key in a ShardedCache,
+	// FNVHasher if nil
+	ShardHasher ShardHasher
+	// ReshardWait ... See WithOnlineResharding
+	ReshardWait time.Duration
+	// Logger ... See WithLogger
+	Logger Logger
+	// IdleTimeout ... See WithIdleTimeout
+	IdleTimeout time.Duration
+	// MaxTTL and MinTTL ... See WithMaxTTL and WithMinTTL
+	MaxTTL time.Duration
+	MinTTL time.Duration
+	// Invalidator ... See WithInvalidator
+	Invalidator Invalidator
+	// AppendLog ... See WithAppendLog
+	AppendLog string
+	// LogSyncInterval ... Time between fsyncs of the append log,
+	// DefaultLogSyncInterval if not positive
+	LogSyncInterval time.Duration
+	// LogCompactInterval ... Time between rewrites of the append log,
+	// DefaultLogCompactInterval if not positive
+	LogCompactInterval time.Duration
+	// OnEvicted ... See Cache.OnEvicted
+	OnEvicted func(string, interface{})
+	// AdaptiveGcMin and AdaptiveGcMax ... See Cache.EnableAdaptiveGC
+	AdaptiveGcMin time.Duration
+	AdaptiveGcMax time.Duration
+	// LockWaitStats ... See Cache.EnableLockWaitStats
+	LockWaitStats bool
+	// SlidingExpiration ... See Cache.EnableSlidingExpiration
+	SlidingExpiration bool
+	// LazyExpiration ... See Cache.EnableLazyExpiration
+	LazyExpiration bool
+	// NoGC ... See WithNoGC
+	NoGC bool
+	// AutoStopGC ... See WithAutoStopGC
+	AutoStopGC bool
+	// LoadBatch ... See WithBatchedLoad
+	LoadBatch int
+	// Clock ... Source of time, SystemClock if nil
+	Clock Clock
+	// Codec ... Format of Save and Load, GobCodec if nil
+	Codec Codec
+}
+
+// Option ... Change one setting of the Cache New makes
+type Option func(*Options)
+
+// New ... Create a New Cache System from Options And goRoutine
+//
+//	c := GoCache.New(
+//		GoCache.WithDefaultExpiration(5*time.Minute),
+//		GoCache.WithMaxEntries(10000),
+//	)
+func New(opts ...Option) *Cache {
+	return NewCacheWithOptions(buildOptions(opts))
+}
+
+// NewSharded ... Create a ShardedCache of n shards from Options And goRoutine
+func NewSharded(n int, opts ...Option) *ShardedCache {
+	return NewShardedCache(n, buildOptions(opts))
+}
+
+func buildOptions(opts []Option) Options {
+	o := Options{GcInterval: DefaultGcInterval}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithOptions ... Start from a whole Options, later Options override it
+func WithOptions(o Options) Option {
+	return func(opts *Options) { *opts = o }
+}
+
+// WithDefaultExpiration ... Expiration of Data Set with DefaultExpiration
+func WithDefaultExpiration(d time.Duration) Option {
+	return func(o *Options) { o.DefaultExpiration = d }
+}
+
+// WithGCInterval ... Time between GC sweeps
+func WithGCInterval(d time.Duration) Option {
+	return func(o *Options) { o.GcInterval = d }
+}
+
+// WithMaxEntries ... Evict Data beyond n entries
+func WithMaxEntries(n int) Option {
+	return func(o *Options) { o.MaxEntries = n }
+}
+
+// WithMaxBytes ... Evict Data beyond n bytes of approximate size
+func WithMaxBytes(n int64) Option {
+	return func(o *Options) { o.MaxBytes = n }
+}
+
+// WithEvictionPolicy ... Pick victims with the policies newPolicy makes
+func WithEvictionPolicy(newPolicy func() EvictionPolicy) Option {
+	return func(o *Options) { o.EvictionPolicy = newPolicy }
+}
+
+// WithAdmission ... Gate new Data with the policies newAdmission makes
+func WithAdmission(newAdmission func() AdmissionPolicy) Option {
+	return func(o *Options) { o.Admission = newAdmission }
+}
+
+// WithOnEvicted ... Call f for every Data removed
+func WithOnEvicted(f func(string, interface{})) Option {
+	return func(o *Options) { o.OnEvicted = f }
+}
+
+// WithExpirationIndex ... Sweep through a heap of Expirations
+func WithExpirationIndex() Option {
+	return func(o *Options) { o.ExpirationIndex = true }
+}
+
+// WithIncrementalGC ... Bound one GC tick to batch keys or pause
+func WithIncrementalGC(batch int, pause time.Duration) Option {
+	return func(o *Options) {
+		o.SweepBatch = batch
+		o.SweepPause = pause
+	}
+}
+
+// WithNoGC ... Start no GC goRoutine: Get Deletes the Expired Data it
+// finds, as with WithLazyExpiration, and the rest stays until
+// DeleteExpired or StartGC. For short lived programs that want no
+// goRoutine left behind; snapshots, the append log and Invalidators
+// still start theirs when asked for
+func WithNoGC() Option {
+	return func(o *Options) { o.NoGC = true }
+}
+
+// WithBatchedLoad ... Let Load merge n Data at a time, releasing the
+// lock in between so requests are served while a big snapshot loads,
+// instead of holding it for the whole merge. Reads during the Load see
+// part of the snapshot, IsLoading tells them it is not all there yet
+func WithBatchedLoad(n int) Option {
+	return func(o *Options) { o.LoadBatch = n }
+}
+
+// WithAutoStopGC ... End the GC goRoutine once the Cache is garbage
+// collected, for callers that drop a Cache without Close
+// The goRoutine then holds the Cache by a weak pointer, so it does not
+// keep it alive, and a cleanup wakes it to return. Nothing else Close does
+// happens: no PersistFile, no last snapshot, no OnEvicted. Snapshots, the
+// append log and Invalidators hold the Cache for good, so a Cache using
+// them is never collected and must be Closed
+// It needs Go 1.24 for weak pointers, older builds ignore it
+func WithAutoStopGC() Option {
+	return func(o *Options) { o.AutoStopGC = true }
+}
+
+// WithAdaptiveGC ... Let the GC interval float between min and max
+func WithAdaptiveGC(min, max time.Duration) Option {
+	return func(o *Options) {
+		if min > max {
+			min, max = max, min
+		}
+		o.AdaptiveGcMin = min
+		o.AdaptiveGcMax = max
+	}
+}
+
+// WithPersistFile ... Save the Cache to file on Close
+func WithPersistFile(file string) Option {
+	return func(o *Options) { o.PersistFile = file }
+}
+
+// WithCompression ... Gzip the files SaveToFile writes
+func WithCompression() Option {
+	return func(o *Options) { o.Compress = true }
+}
+
+// WithLockWaitStats ... Time the waits for the lock in Set and Get
+func WithLockWaitStats() Option {
+	return func(o *Options) { o.LockWaitStats = true }
+}
+
+// WithSlidingExpiration ... Make every Data Set sliding
+func WithSlidingExpiration() Option {
+	return func(o *Options) { o.SlidingExpiration = true }
+}
+
+// WithCodec ... Save and Load in the format of codec
+func WithCodec(codec Codec) Option {
+	return func(o *Options) { o.Codec = codec }
+}
+
+// WithLazyExpiration ... Let Get Delete the Expired Data it finds
+func WithLazyExpiration() Option {
+	return func(o *Options) { o.LazyExpiration = true }
+}
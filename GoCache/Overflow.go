@@ -0,0 +1,87 @@
+package GoCache
+
+import (
+	"os"
+)
+
+// overflowConfig ... Where and from what size Data is spilled to disk
+type overflowConfig struct {
+	dir       string
+	threshold int64
+}
+
+// spilled ... Stands in the Cache for Data written to File
+type spilled struct {
+	File string
+}
+
+// WithDiskOverflow ... Keep Data whose approximate size is above
+// threshold in a file under dir instead of in memory, Get reads it
+// back transparently. Spilled Data counts for a few bytes in MaxBytes
+func WithDiskOverflow(dir string, threshold int64) Option {
+	return func(o *Options) {
+		o.OverflowDir = dir
+		o.OverflowThreshold = threshold
+	}
+}
+
+// newOverflowConfig ... Return nil if opts asks for no overflow
+func newOverflowConfig(opts Options) *overflowConfig {
+	if opts.OverflowDir == "" || opts.OverflowThreshold <= 0 {
+		return nil
+	}
+	os.MkdirAll(opts.OverflowDir, 0o755)
+	return &overflowConfig{dir: opts.OverflowDir, threshold: opts.OverflowThreshold}
+}
+
+// spill ... Write the Object of item to a new file and return item
+// pointing at it, or item as it is if that fails
+func (o *overflowConfig) spill(k string, item Item) Item {
+	f, err := os.CreateTemp(o.dir, "item*")
+	if err != nil {
+		return item
+	}
+	err = GobCodec.Encode(f, map[string]Item{k: {Object: item.Object}})
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return item
+	}
+	item.Object = spilled{f.Name()}
+	item.size = approxSize(item.Object)
+	return item
+}
+
+// read ... Read back the Data in the file
+func (sp spilled) read() (interface{}, error) {
+	f, err := os.Open(sp.File)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	items, err := GobCodec.Decode(f)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		return item.Object, nil
+	}
+	return nil, nil
+}
+
+// release ... Remove the file of object if it was spilled, reading the
+// Data back first if keep is set so OnEvicted can see it
+func release(object interface{}, keep bool) interface{} {
+	sp, ok := object.(spilled)
+	if !ok {
+		return object
+	}
+	var v interface{}
+	if keep {
+		v, _ = sp.read()
+	}
+	os.Remove(sp.File)
+	return v
+}
@@ -0,0 +1,194 @@
+package GoCache
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultPeerPath ... Path a PeerPool serves its peers under
+const DefaultPeerPath = "/_gocache/"
+
+// PeerPool ... One process of a fleet whose Caches fill each other
+// Every key is owned by one peer, picked by consistent hashing over the
+// peer URLs; the others ask the owner over HTTP instead of loading the
+// key themselves, so a key is loaded once across the fleet
+// Mount the pool at DefaultPeerPath on the address in self
+type PeerPool struct {
+	self   string // Base URL of this process, like http://10.0.0.1:8000
+	Client *http.Client
+	mutex  sync.RWMutex
+	ring   *hashRing
+	groups map[string]*PeerGroup
+}
+
+// PeerGroup ... Named Cache of a PeerPool with the loader its owner
+// peer calls on a miss
+type PeerGroup struct {
+	name   string
+	pool   *PeerPool
+	cache  *Cache
+	ttl    time.Duration
+	loader func(k string) ([]byte, error)
+}
+
+// NewPeerPool ... Create the pool of the process reachable at self
+func NewPeerPool(self string) *PeerPool {
+	return &PeerPool{
+		self:   strings.TrimSuffix(self, "/"),
+		Client: http.DefaultClient,
+		ring:   newHashRing(0),
+		groups: map[string]*PeerGroup{},
+	}
+}
+
+// SetPeers ... Replace the peers by the base URLs in peers, which
+// should include self
+func (p *PeerPool) SetPeers(peers ...string) {
+	ring := newHashRing(0)
+	for _, peer := range peers {
+		ring.add(strings.TrimSuffix(peer, "/"))
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.ring = ring
+}
+
+// NewGroup ... Add the group name, keeping its Data in c for ttl and
+// calling loader for the keys this process owns
+func (p *PeerPool) NewGroup(name string, c *Cache, ttl time.Duration, loader func(k string) ([]byte, error)) *PeerGroup {
+	g := &PeerGroup{name: name, pool: p, cache: c, ttl: ttl, loader: loader}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.groups[name] = g
+	return g
+}
+
+// owner ... Return the peer owning k, remote is false if it is self
+// or there are no peers
+func (p *PeerPool) owner(k string) (peer string, remote bool) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	peer, ok := p.ring.locate(k)
+	return peer, ok && peer != p.self
+}
+
+// fetch ... Ask peer for k of group
+func (p *PeerPool) fetch(peer, group, k string) ([]byte, error) {
+	u := peer + DefaultPeerPath + url.PathEscape(group) + "/" + url.PathEscape(k)
+	resp, err := p.Client.Get(u)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("peer %s: %s: %s", peer, resp.Status, strings.TrimSpace(string(b)))
+	}
+	return b, nil
+}
+
+// ServeHTTP ... Answer GET DefaultPeerPath{group}/{key} from another peer
+func (p *PeerPool) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !allow(w, r, http.MethodGet) {
+		return
+	}
+	rest, ok := strings.CutPrefix(r.URL.EscapedPath(), DefaultPeerPath)
+	escGroup, escKey, ok2 := strings.Cut(rest, "/")
+	group, err1 := url.PathUnescape(escGroup)
+	k, err2 := url.PathUnescape(escKey)
+	if !ok || !ok2 || err1 != nil || err2 != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	p.mutex.RLock()
+	g := p.groups[group]
+	p.mutex.RUnlock()
+	if g == nil {
+		http.Error(w, "no such group: "+group, http.StatusNotFound)
+		return
+	}
+	// Load here even if the ring says otherwise, peers may briefly
+	// disagree about it while the peer list changes
+	b, err := g.getLocally(k)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Write(b)
+}
+
+// Name ... Return the name of the group
+func (g *PeerGroup) Name() string {
+	return g.name
+}
+
+// Get ... Get the Data of k from the Cache, or on a miss from the peer
+// owning it, or from the loader if that is this process or the owner
+// cannot be reached. Concurrent misses on the same key share one call
+func (g *PeerGroup) Get(k string) ([]byte, error) {
+	if v, found := g.cache.Get(k); found {
+		return asBytes(k, v)
+	}
+	v, err := g.cache.load(k, func() (interface{}, error) {
+		// Another flight may have filled k since the miss above
+		if v, found := g.cache.Get(k); found {
+			return v, nil
+		}
+		if peer, remote := g.pool.owner(k); remote {
+			if b, err := g.pool.fetch(peer, g.name, k); err == nil {
+				g.cache.Set(k, b, g.ttl)
+				return b, nil
+			}
+		}
+		return g.load(k)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return asBytes(k, v)
+}
+
+// getLocally ... Get k from the Cache or the loader, never from a peer
+func (g *PeerGroup) getLocally(k string) ([]byte, error) {
+	if v, found := g.cache.Get(k); found {
+		return asBytes(k, v)
+	}
+	v, err := g.cache.load(k, func() (interface{}, error) {
+		if v, found := g.cache.Get(k); found {
+			return v, nil
+		}
+		return g.load(k)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return asBytes(k, v)
+}
+
+func (g *PeerGroup) load(k string) (interface{}, error) {
+	b, err := g.loader(k)
+	if err != nil {
+		return nil, err
+	}
+	g.cache.Set(k, b, g.ttl)
+	return b, nil
+}
+
+func asBytes(k string, v interface{}) ([]byte, error) {
+	switch x := v.(type) {
+	case []byte:
+		return x, nil
+	case string:
+		return []byte(x), nil
+	}
+	return nil, fmt.Errorf("item %s of type %T is not bytes: %w", k, v, ErrTypeMismatch)
+}
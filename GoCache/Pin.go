@@ -0,0 +1,71 @@
+package GoCache
+
+// Pin ... Keep the live Data at k from Expiring, going idle and being
+// Evicted until as many Unpin calls, so it stays while a long operation
+// uses it. Delete and Flush still remove it, dropping its pins
+// Return false if there is no live Data at k
+func (c *Cache) Pin(k string) bool {
+	k = c.key(k)
+	c = c.lockFor(k)
+	defer c.unlock()
+	item, found := c.items[k]
+	if !found || c.expired(item) || c.isIdle(item, c.now().UnixNano()) {
+		return false
+	}
+	item.pins++
+	c.update(k, item)
+	if item.pins == 1 {
+		if c.policy != nil {
+			c.policy.OnDelete(k)
+		}
+		if nl := c.namespaceOf(k); nl != nil && nl.policy != nil {
+			nl.policy.OnDelete(k)
+		}
+	}
+	return true
+}
+
+// Unpin ... Undo one Pin of k. Once the last is undone the Data Expires
+// as it was Set to, at once if that time has passed, and may be Evicted
+// again. Return false if k was not pinned
+func (c *Cache) Unpin(k string) bool {
+	k = c.key(k)
+	c = c.lockFor(k)
+	defer c.unlock()
+	item, found := c.items[k]
+	if !found || item.pins == 0 {
+		return false
+	}
+	item.pins--
+	c.update(k, item)
+	if item.pins > 0 {
+		return true
+	}
+	if nl := c.namespaceOf(k); nl != nil && nl.policy != nil {
+		nl.policy.OnSet(k)
+		c.evictNamespace(nl)
+	}
+	if c.policy != nil {
+		c.policy.OnSet(k)
+		c.evictOverBudget()
+	}
+	return true
+}
+
+// deadline ... The Expiration that applies to item now, zero while pinned
+func (item Item) deadline() int64 {
+	if item.pins > 0 {
+		return 0
+	}
+	return item.Expiration
+}
+
+// Pin ... Pin k in its shard
+func (sc *ShardedCache) Pin(k string) bool {
+	return sc.shard(k).Pin(k)
+}
+
+// Unpin ... Unpin k in its shard
+func (sc *ShardedCache) Unpin(k string) bool {
+	return sc.shard(k).Unpin(k)
+}
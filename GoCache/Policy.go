@@ -0,0 +1,187 @@
+package GoCache
+
+import (
+	"container/heap"
+	"container/list"
+)
+
+// EvictionPolicy ... Decide which Data a bounded Cache evicts
+// The Cache calls it with its write lock held, so it need not lock
+type EvictionPolicy interface {
+	OnGet(k string)         // k was read
+	OnSet(k string)         // k was stored, new or overwritten
+	OnDelete(k string)      // k left the Cache
+	Victim() (string, bool) // Next key to evict, false when empty
+}
+
+// lruPolicy ... Keys ordered from most to least recently used
+type lruPolicy struct {
+	ll    *list.List
+	index map[string]*list.Element
+}
+
+// NewLRUPolicy ... Evict the least recently used Data first
+func NewLRUPolicy() EvictionPolicy {
+	return &lruPolicy{ll: list.New(), index: map[string]*list.Element{}}
+}
+
+func (p *lruPolicy) OnGet(k string) {
+	if e, ok := p.index[k]; ok {
+		p.ll.MoveToFront(e)
+	}
+}
+
+func (p *lruPolicy) OnSet(k string) {
+	if e, ok := p.index[k]; ok {
+		p.ll.MoveToFront(e)
+		return
+	}
+	p.index[k] = p.ll.PushFront(k)
+}
+
+func (p *lruPolicy) OnDelete(k string) {
+	if e, ok := p.index[k]; ok {
+		p.ll.Remove(e)
+		delete(p.index, k)
+	}
+}
+
+func (p *lruPolicy) Victim() (string, bool) {
+	if e := p.ll.Back(); e != nil {
+		return e.Value.(string), true
+	}
+	return "", false
+}
+
+// WithLRUPromoteOnGet ... Whether a read makes Data the most recently
+// used for the LRU policy of a bounded Cache, true by default. Turned
+// off only writes do, so one large pass of reads over cold Data does
+// not push the hot Data out, and reads need no write lock for it
+func WithLRUPromoteOnGet(promote bool) Option {
+	return func(o *Options) { o.NoLRUPromoteOnGet = !promote }
+}
+
+// lruWritePolicy ... LRU whose reads do not count as uses, see
+// WithLRUPromoteOnGet
+type lruWritePolicy struct {
+	lruPolicy
+}
+
+func (p *lruWritePolicy) OnGet(k string) {}
+
+func (p *lruWritePolicy) onGetUnderReadLock() {}
+
+// withoutPromotion ... newPolicy with the LRU policies it makes, if
+// any, not promoting on reads
+func withoutPromotion(newPolicy func() EvictionPolicy) func() EvictionPolicy {
+	return func() EvictionPolicy {
+		p := newPolicy()
+		if lru, ok := p.(*lruPolicy); ok {
+			return &lruWritePolicy{*lru}
+		}
+		return p
+	}
+}
+
+// fifoPolicy ... Keys in the order they were first stored
+type fifoPolicy struct {
+	lruPolicy
+}
+
+// NewFIFOPolicy ... Evict the Data stored first, reads and
+// overwrites do not change the order
+func NewFIFOPolicy() EvictionPolicy {
+	return &fifoPolicy{lruPolicy{ll: list.New(), index: map[string]*list.Element{}}}
+}
+
+func (p *fifoPolicy) OnGet(k string) {}
+
+func (p *fifoPolicy) OnSet(k string) {
+	if _, ok := p.index[k]; !ok {
+		p.index[k] = p.ll.PushFront(k)
+	}
+}
+
+// lfuEntry ... A key with its use count, seq breaks ties oldest first
+type lfuEntry struct {
+	key   string
+	freq  uint64
+	seq   uint64
+	index int
+}
+
+type lfuHeap []*lfuEntry
+
+func (h lfuHeap) Len() int { return len(h) }
+func (h lfuHeap) Less(i, j int) bool {
+	if h[i].freq != h[j].freq {
+		return h[i].freq < h[j].freq
+	}
+	return h[i].seq < h[j].seq
+}
+func (h lfuHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+func (h *lfuHeap) Push(x interface{}) {
+	e := x.(*lfuEntry)
+	e.index = len(*h)
+	*h = append(*h, e)
+}
+func (h *lfuHeap) Pop() interface{} {
+	old := *h
+	e := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return e
+}
+
+// lfuPolicy ... Keys in a min heap of use counts
+type lfuPolicy struct {
+	heap  lfuHeap
+	index map[string]*lfuEntry
+	seq   uint64
+}
+
+// NewLFUPolicy ... Evict the least frequently used Data first,
+// the least recently used among equally used ones
+func NewLFUPolicy() EvictionPolicy {
+	return &lfuPolicy{index: map[string]*lfuEntry{}}
+}
+
+func (p *lfuPolicy) use(k string) {
+	p.seq++
+	if e, ok := p.index[k]; ok {
+		e.freq++
+		e.seq = p.seq
+		heap.Fix(&p.heap, e.index)
+		return
+	}
+	e := &lfuEntry{key: k, freq: 1, seq: p.seq}
+	p.index[k] = e
+	heap.Push(&p.heap, e)
+}
+
+func (p *lfuPolicy) OnGet(k string) {
+	if _, ok := p.index[k]; ok {
+		p.use(k)
+	}
+}
+
+func (p *lfuPolicy) OnSet(k string) {
+	p.use(k)
+}
+
+func (p *lfuPolicy) OnDelete(k string) {
+	if e, ok := p.index[k]; ok {
+		heap.Remove(&p.heap, e.index)
+		delete(p.index, k)
+	}
+}
+
+func (p *lfuPolicy) Victim() (string, bool) {
+	if len(p.heap) == 0 {
+		return "", false
+	}
+	return p.heap[0].key, true
+}
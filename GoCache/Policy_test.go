@@ -0,0 +1,56 @@
+package GoCache
+
+import "testing"
+
+// scanThenSet ... Fill a Cache of 4 with 3 cold keys then a hot one,
+// read the cold keys once as a scan does, and Set one more key
+// Return which of the hot key and the first cold key survived
+func scanThenSet(t *testing.T, opts ...Option) (hot, cold bool) {
+	c := New(append([]Option{WithMaxEntries(4), WithNoGC()}, opts...)...)
+	for _, k := range []string{"cold1", "cold2", "cold3", "hot"} {
+		c.Set(k, k, NoExpiration)
+	}
+	for _, k := range []string{"cold1", "cold2", "cold3"} {
+		if _, found := c.Get(k); !found {
+			t.Fatalf("%s missing before the Cache is full", k)
+		}
+	}
+	c.Set("new", "new", NoExpiration)
+	if c.Count() != 4 {
+		t.Fatalf("Count() = %d, want 4", c.Count())
+	}
+	items := c.Items()
+	_, hot = items["hot"]
+	_, cold = items["cold1"]
+	return hot, cold
+}
+
+func TestLRUPromoteOnGet(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		opts      []Option
+		hot, cold bool
+	}{
+		// The scan promotes the cold keys over the hot one, which goes
+		{name: "default", hot: false, cold: true},
+		{name: "on", opts: []Option{WithLRUPromoteOnGet(true)}, hot: false, cold: true},
+		// Reads do not count, the first key written goes
+		{name: "off", opts: []Option{WithLRUPromoteOnGet(false)}, hot: true, cold: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			hot, cold := scanThenSet(t, tc.opts...)
+			if hot != tc.hot || cold != tc.cold {
+				t.Fatalf("hot kept %v, cold1 kept %v, want %v and %v", hot, cold, tc.hot, tc.cold)
+			}
+		})
+	}
+}
+
+func TestLRUPromoteOnGetSetMaxEntries(t *testing.T) {
+	c := New(WithLRUPromoteOnGet(false), WithNoGC())
+	c.Set("a", 1, NoExpiration)
+	c.SetMaxEntries(1)
+	if _, ok := c.policy.(*lruWritePolicy); !ok {
+		t.Fatalf("policy %T after SetMaxEntries, want *lruWritePolicy", c.policy)
+	}
+}
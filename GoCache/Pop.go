@@ -0,0 +1,19 @@
+package GoCache
+
+// Pop ... Get the Data and Delete it in one step, so of concurrent
+// callers only one gets it. Like Delete it is passed to OnEvicted
+func (c *Cache) Pop(k string) (interface{}, bool) {
+	k = c.key(k)
+	c = c.lockFor(k)
+	v, found := c.get(k)
+	c.countRead(k, found)
+	if found {
+		c.delete(k)
+	}
+	inv := c.invalidator
+	c.unlock()
+	if found {
+		publish(inv, c.instanceID, Invalidation{Key: k})
+	}
+	return v, found
+}
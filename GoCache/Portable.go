@@ -0,0 +1,79 @@
+package GoCache
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// portable ... v as one of the types MsgpackCodec and ProtobufCodec
+// write: nil, bool, int64, uint64, float64, string, []byte,
+// []interface{} or map[string]interface{}. Other slices, arrays, string
+// keyed maps and pointers are followed, anything else is an error
+func portable(v interface{}) (interface{}, error) {
+	switch x := v.(type) {
+	case nil, bool, int64, uint64, float64, string, []byte:
+		return x, nil
+	case int:
+		return int64(x), nil
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Bool:
+		return rv.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return rv.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), nil
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Slice, reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(b), rv)
+			return b, nil
+		}
+		list := make([]interface{}, rv.Len())
+		for i := range list {
+			e, err := portable(rv.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			list[i] = e
+		}
+		return list, nil
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			break
+		}
+		m := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			e, err := portable(iter.Value().Interface())
+			if err != nil {
+				return nil, err
+			}
+			m[iter.Key().String()] = e
+		}
+		return m, nil
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return nil, nil
+		}
+		return portable(rv.Elem().Interface())
+	}
+	return nil, fmt.Errorf("cannot encode %T portably", v)
+}
+
+// sortedKeys ... The keys of m in order, so the portable Codecs write the
+// same bytes for the same Data
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
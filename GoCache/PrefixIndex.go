@@ -0,0 +1,108 @@
+package GoCache
+
+import "strings"
+
+// WithPrefixIndex ... Keep the keys in a radix tree, so DeletePrefix,
+// FlushNamespace and KeysWithPrefix cost the number of keys under the
+// prefix instead of the size of the Cache, at some cost on every Set
+// of a new key and every Delete
+func WithPrefixIndex() Option {
+	return func(o *Options) { o.PrefixIndex = true }
+}
+
+// DeletePrefix ... Delete all Data whose key starts with prefix, like
+// the subtree users/42/ of hierarchical keys, Return how many
+func (c *Cache) DeletePrefix(prefix string) int {
+	return c.deletePrefix(prefix)
+}
+
+// radixNode ... Node of the prefix index, the edge into it is prefix
+// and leaf tells whether the path down to it is a key
+type radixNode struct {
+	prefix   string
+	leaf     bool
+	children map[byte]*radixNode
+}
+
+// insert ... Add k below n
+func (n *radixNode) insert(k string) {
+	for k != "" {
+		child := n.children[k[0]]
+		if child == nil {
+			if n.children == nil {
+				n.children = map[byte]*radixNode{}
+			}
+			n.children[k[0]] = &radixNode{prefix: k, leaf: true}
+			return
+		}
+		common := 0
+		for common < len(k) && common < len(child.prefix) && k[common] == child.prefix[common] {
+			common++
+		}
+		if common < len(child.prefix) {
+			// Split the edge where k leaves it
+			mid := &radixNode{prefix: child.prefix[:common], children: map[byte]*radixNode{}}
+			child.prefix = child.prefix[common:]
+			mid.children[child.prefix[0]] = child
+			n.children[k[0]] = mid
+			child = mid
+		}
+		n, k = child, k[common:]
+	}
+	n.leaf = true
+}
+
+// remove ... Take k out from below n, merging the edges it leaves single
+func (n *radixNode) remove(k string) {
+	if k == "" {
+		n.leaf = false
+		return
+	}
+	child := n.children[k[0]]
+	if child == nil || !strings.HasPrefix(k, child.prefix) {
+		return
+	}
+	child.remove(k[len(child.prefix):])
+	switch {
+	case child.leaf:
+	case len(child.children) == 0:
+		delete(n.children, k[0])
+	case len(child.children) == 1:
+		for _, grandchild := range child.children {
+			grandchild.prefix = child.prefix + grandchild.prefix
+			n.children[k[0]] = grandchild
+		}
+	}
+}
+
+// withPrefix ... Return the keys below n starting with prefix
+func (n *radixNode) withPrefix(prefix string) []string {
+	path := ""
+	for prefix != "" {
+		child := n.children[prefix[0]]
+		switch {
+		case child == nil:
+			return nil
+		case strings.HasPrefix(prefix, child.prefix):
+			prefix = prefix[len(child.prefix):]
+		case strings.HasPrefix(child.prefix, prefix):
+			prefix = ""
+		default:
+			return nil
+		}
+		path += child.prefix
+		n = child
+	}
+	var keys []string
+	var walk func(n *radixNode, path string)
+	walk = func(n *radixNode, path string) {
+		if n.leaf {
+			keys = append(keys, path)
+		}
+		for _, child := range n.children {
+			walk(child, path+child.prefix)
+		}
+	}
+	walk(n, path)
+	return keys
+}
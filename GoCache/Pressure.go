@@ -0,0 +1,117 @@
+package GoCache
+
+import (
+	"runtime"
+	"sync"
+	"time"
+)
+
+// EvictFraction ... Evict about fraction of the Data In Cache
+// Expired Data goes first, the rest is picked by the eviction policy
+// when the Cache is bounded and by map order otherwise, pinned Data stays
+// Return the Number of Data Evicted
+func (c *Cache) EvictFraction(fraction float64) int {
+	if fraction <= 0 {
+		return 0
+	}
+	if fraction > 1 {
+		fraction = 1
+	}
+	c.mutex.Lock()
+	defer c.unlock()
+	n := int(float64(len(c.items))*fraction + 0.5)
+	evicted := 0
+	for k, v := range c.items {
+		if evicted >= n {
+			return evicted
+		}
+		if c.expired(v) {
+			c.expire(k)
+			c.stats.expired.Add(1)
+			evicted++
+		}
+	}
+	if c.policy != nil {
+		for ; evicted < n; evicted++ {
+			victim, ok := c.policy.Victim()
+			if !ok {
+				break
+			}
+			c.remove(victim, EventEvict)
+			c.stats.evictions.Add(1)
+		}
+		return evicted
+	}
+	for k, item := range c.items {
+		if evicted >= n {
+			break
+		}
+		if item.pins > 0 {
+			continue
+		}
+		c.remove(k, EventEvict)
+		c.stats.evictions.Add(1)
+		evicted++
+	}
+	return evicted
+}
+
+// MemoryPressureHook ... Poll the Heap Usage and Evict part of the Cache
+// when it crosses Threshold
+type MemoryPressureHook struct {
+	cache     *Cache
+	Threshold uint64  // Heap bytes that trigger eviction
+	Fraction  float64 // Fraction of the Cache evicted on each trigger
+	Interval  time.Duration
+	stop      chan struct{}
+	stopOnce  sync.Once
+}
+
+// NewMemoryPressureHook ... Create a Hook for c, call Start to run it
+func NewMemoryPressureHook(c *Cache, threshold uint64, fraction float64, interval time.Duration) *MemoryPressureHook {
+	return &MemoryPressureHook{
+		cache:     c,
+		Threshold: threshold,
+		Fraction:  fraction,
+		Interval:  interval,
+		stop:      make(chan struct{}),
+	}
+}
+
+// Check ... Read the MemStats once and Evict if Heap is above Threshold
+// Return the Number of Data Evicted
+func (h *MemoryPressureHook) Check() int {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	if m.HeapAlloc < h.Threshold {
+		return 0
+	}
+	n := h.cache.EvictFraction(h.Fraction)
+	if n > 0 {
+		// Give the memory of the evicted Data back before next poll
+		runtime.GC()
+	}
+	return n
+}
+
+// Start ... Poll in a goRoutine until Stop is called
+func (h *MemoryPressureHook) Start() {
+	go func() {
+		ticker := time.NewTicker(h.Interval)
+		for {
+			select {
+			case <-ticker.C:
+				h.Check()
+			case <-h.stop:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+}
+
+// Stop ... Stop the polling goRoutine, it may be called more than once
+// and before Start, which then polls no more
+func (h *MemoryPressureHook) Stop() {
+	h.stopOnce.Do(func() { close(h.stop) })
+}
@@ -0,0 +1,76 @@
+package GoCache
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestEvictFraction(t *testing.T) {
+	c := NewCache(0, time.Hour)
+	defer c.StopGc()
+	for i := 0; i < 100; i++ {
+		c.Set(strconv.Itoa(i), i, NoExpiration)
+	}
+	if n := c.EvictFraction(0.5); n != 50 {
+		t.Fatalf("EvictFraction(0.5) = %d, want 50", n)
+	}
+	if n := c.Count(); n != 50 {
+		t.Fatalf("Count() = %d after evicting half, want 50", n)
+	}
+}
+
+func TestEvictFractionExpiredFirst(t *testing.T) {
+	c := NewCache(0, time.Hour)
+	defer c.StopGc()
+	for i := 0; i < 10; i++ {
+		c.Set("live"+strconv.Itoa(i), i, NoExpiration)
+		c.Set("gone"+strconv.Itoa(i), i, time.Nanosecond)
+	}
+	time.Sleep(time.Millisecond)
+	if n := c.EvictFraction(0.5); n != 10 {
+		t.Fatalf("EvictFraction(0.5) = %d, want 10", n)
+	}
+	for i := 0; i < 10; i++ {
+		if _, found := c.Get("live" + strconv.Itoa(i)); !found {
+			t.Fatalf("live%d evicted before the Expired Data", i)
+		}
+	}
+}
+
+func TestEvictFractionPinned(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		opts []Option
+	}{
+		{name: "unbounded"},
+		{name: "bounded", opts: []Option{WithMaxEntries(100)}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := New(append([]Option{WithNoGC()}, tc.opts...)...)
+			for i := 0; i < 10; i++ {
+				k := strconv.Itoa(i)
+				c.Set(k, i, NoExpiration)
+				c.Pin(k)
+			}
+			if n := c.EvictFraction(0.5); n != 0 {
+				t.Fatalf("EvictFraction(0.5) = %d with every key pinned, want 0", n)
+			}
+			if n := c.Count(); n != 10 {
+				t.Fatalf("Count() = %d after EvictFraction, want 10 pinned", n)
+			}
+		})
+	}
+}
+
+func TestMemoryPressureHookStop(t *testing.T) {
+	c := NewCache(0, time.Hour)
+	defer c.StopGc()
+	h := NewMemoryPressureHook(c, 0, 0.5, time.Millisecond)
+	h.Stop()
+	h.Stop()
+	h = NewMemoryPressureHook(c, 0, 0.5, time.Millisecond)
+	h.Start()
+	h.Stop()
+	h.Stop()
+}
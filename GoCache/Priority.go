@@ -0,0 +1,111 @@
+package GoCache
+
+import (
+	"sort"
+	"time"
+)
+
+// Priority ... Rank of Data for eviction: a bounded Cache Evicts all Data
+// of a lower Priority before any of a higher one, the eviction policy
+// only orders Data of the same Priority
+type Priority int
+
+const (
+	PriorityLow    Priority = -1
+	PriorityNormal Priority = 0 // What Set and the other Setters give
+	PriorityHigh   Priority = 1
+)
+
+// SetWithPriority ... Set the Data with Expiration d and priority p
+func (c *Cache) SetWithPriority(k string, v interface{}, d time.Duration, p Priority) {
+	k = c.key(k)
+	c = c.lockFor(k)
+	defer c.unlock()
+	item := Item{
+		Object:     v,
+		Expiration: c.expiration(d),
+		Priority:   p,
+	}
+	if c.slidingByDefault && item.Expiration > 0 {
+		item.Sliding = c.resolve(d)
+	}
+	c.put(k, item)
+}
+
+// SetWithPriority ... Set the Data with priority p in its shard
+func (sc *ShardedCache) SetWithPriority(k string, v interface{}, d time.Duration, p Priority) {
+	sc.shard(k).SetWithPriority(k, v, d, p)
+}
+
+// prioritize ... Put the eviction policy behind a priorityPolicy, once
+// Data with a Priority other than PriorityNormal shows up
+// The current policy keeps ranking the PriorityNormal Data
+func (c *Cache) prioritize() {
+	if _, ok := c.policy.(*priorityPolicy); ok {
+		return
+	}
+	c.policy = &priorityPolicy{
+		newPolicy: c.newPolicy,
+		priorityOf: func(k string) Priority {
+			return c.items[k].Priority
+		},
+		levels:     map[Priority]EvictionPolicy{PriorityNormal: c.policy},
+		priorities: []Priority{PriorityNormal},
+		of:         map[string]Priority{},
+	}
+	c.tracksReads.Store(true)
+}
+
+// priorityPolicy ... One EvictionPolicy per Priority, Victims come from
+// the lowest Priority holding any Data
+type priorityPolicy struct {
+	newPolicy  func() EvictionPolicy
+	priorityOf func(k string) Priority // Priority of the Data stored at k
+	levels     map[Priority]EvictionPolicy
+	priorities []Priority          // Keys of levels, ascending
+	of         map[string]Priority // Keys not at PriorityNormal
+}
+
+func (p *priorityPolicy) level(pr Priority) EvictionPolicy {
+	if l, ok := p.levels[pr]; ok {
+		return l
+	}
+	l := p.newPolicy()
+	p.levels[pr] = l
+	i := sort.Search(len(p.priorities), func(i int) bool { return p.priorities[i] >= pr })
+	p.priorities = append(p.priorities, 0)
+	copy(p.priorities[i+1:], p.priorities[i:])
+	p.priorities[i] = pr
+	return l
+}
+
+func (p *priorityPolicy) OnGet(k string) {
+	p.level(p.of[k]).OnGet(k)
+}
+
+func (p *priorityPolicy) OnSet(k string) {
+	pr, old := p.priorityOf(k), p.of[k]
+	if pr != old {
+		p.level(old).OnDelete(k)
+	}
+	p.level(pr).OnSet(k)
+	if pr != PriorityNormal {
+		p.of[k] = pr
+	} else {
+		delete(p.of, k)
+	}
+}
+
+func (p *priorityPolicy) OnDelete(k string) {
+	p.level(p.of[k]).OnDelete(k)
+	delete(p.of, k)
+}
+
+func (p *priorityPolicy) Victim() (string, bool) {
+	for _, pr := range p.priorities {
+		if k, ok := p.levels[pr].Victim(); ok {
+			return k, true
+		}
+	}
+	return "", false
+}
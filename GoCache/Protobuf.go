@@ -0,0 +1,306 @@
+package GoCache
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// ProtobufCodec ... Writes the Snapshot message of snapshot.proto, for
+// tools that generate their reader from it. Data must be portable as
+// for MsgpackCodec and comes back as the same types
+var ProtobufCodec Codec = protobufCodec{}
+
+type protobufCodec struct{}
+
+func (protobufCodec) Name() string { return "protobuf" }
+
+// Wire types of the protobuf encoding
+const (
+	protoVarint = 0
+	protoI64    = 1
+	protoLen    = 2
+	protoI32    = 5
+)
+
+var errProtobuf = errors.New("protobuf: invalid message")
+
+func (protobufCodec) Encode(w io.Writer, items map[string]Item) error {
+	var b []byte
+	for _, k := range sortedKeys(items) {
+		entry, err := protoEntry(k, items[k])
+		if err != nil {
+			return fmt.Errorf("item %s: %w", k, err)
+		}
+		b = protoBytes(b, 1, entry)
+	}
+	_, err := w.Write(b)
+	return err
+}
+
+func (protobufCodec) Decode(r io.Reader) (map[string]Item, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	items := map[string]Item{}
+	err = protoFields(b, func(num, _ int, _ uint64, data []byte) error {
+		if num != 1 {
+			return nil
+		}
+		k, item, err := protoItem(data)
+		if err != nil {
+			return err
+		}
+		items[k] = item
+		return nil
+	})
+	return items, err
+}
+
+// protoEntry ... The Entry message of k and item
+func protoEntry(k string, item Item) ([]byte, error) {
+	object, err := portable(item.Object)
+	if err != nil {
+		return nil, err
+	}
+	b := protoBytes(nil, 1, []byte(k))
+	b = protoBytes(b, 2, protoValue(nil, object))
+	for i, v := range []int64{
+		item.Expiration, item.SoftExpiration, int64(item.ComputeCost),
+		int64(item.Priority), int64(item.Sliding),
+	} {
+		b = protoInt(b, 3+i, v)
+	}
+	for _, tag := range item.Tags {
+		b = protoBytes(b, 8, []byte(tag))
+	}
+	for _, dep := range item.Deps {
+		b = protoBytes(b, 9, []byte(dep))
+	}
+	b = protoInt(b, 10, item.CreatedAt)
+	b = protoInt(b, 11, item.LastAccessedAt)
+	for _, k := range sortedKeys(item.Meta) {
+		entry := protoBytes(nil, 1, []byte(k))
+		b = protoBytes(b, 12, protoBytes(entry, 2, []byte(item.Meta[k])))
+	}
+	return b, nil
+}
+
+// protoItem ... The key and Item of an Entry message
+func protoItem(b []byte) (string, Item, error) {
+	var k string
+	var item Item
+	err := protoFields(b, func(num, _ int, v uint64, data []byte) (err error) {
+		switch num {
+		case 1:
+			k = string(data)
+		case 2:
+			item.Object, err = protoDecodeValue(data)
+		case 3:
+			item.Expiration = int64(v)
+		case 4:
+			item.SoftExpiration = int64(v)
+		case 5:
+			item.ComputeCost = time.Duration(v)
+		case 6:
+			item.Priority = Priority(v)
+		case 7:
+			item.Sliding = time.Duration(v)
+		case 8:
+			item.Tags = append(item.Tags, string(data))
+		case 9:
+			item.Deps = append(item.Deps, string(data))
+		case 10:
+			item.CreatedAt = int64(v)
+		case 11:
+			item.LastAccessedAt = int64(v)
+		case 12:
+			var mk, mv string
+			err = protoFields(data, func(num, _ int, _ uint64, data []byte) error {
+				switch num {
+				case 1:
+					mk = string(data)
+				case 2:
+					mv = string(data)
+				}
+				return nil
+			})
+			if item.Meta == nil {
+				item.Meta = map[string]string{}
+			}
+			item.Meta[mk] = mv
+		}
+		return
+	})
+	return k, item, err
+}
+
+// protoValue ... Append the Value message of the portable value v
+func protoValue(b []byte, v interface{}) []byte {
+	switch x := v.(type) {
+	case nil:
+		return protoVarintField(b, 1, 1)
+	case bool:
+		if x {
+			return protoVarintField(b, 2, 1)
+		}
+		return protoVarintField(b, 2, 0)
+	case int64:
+		// Zigzag, as sint64
+		return protoVarintField(b, 3, uint64(x<<1)^uint64(x>>63))
+	case uint64:
+		if x <= math.MaxInt64 {
+			return protoValue(b, int64(x))
+		}
+		return protoVarintField(b, 4, x)
+	case float64:
+		b = protoTag(b, 5, protoI64)
+		return binary.LittleEndian.AppendUint64(b, math.Float64bits(x))
+	case string:
+		return protoBytes(b, 6, []byte(x))
+	case []byte:
+		return protoBytes(b, 7, x)
+	case []interface{}:
+		var list []byte
+		for _, e := range x {
+			list = protoBytes(list, 1, protoValue(nil, e))
+		}
+		return protoBytes(b, 8, list)
+	case map[string]interface{}:
+		var m []byte
+		for _, k := range sortedKeys(x) {
+			entry := protoBytes(nil, 1, []byte(k))
+			entry = protoBytes(entry, 2, protoValue(nil, x[k]))
+			m = protoBytes(m, 1, entry)
+		}
+		return protoBytes(b, 9, m)
+	}
+	return b
+}
+
+// protoDecodeValue ... The portable value of a Value message, nil for
+// an empty one
+func protoDecodeValue(b []byte) (interface{}, error) {
+	var v interface{}
+	err := protoFields(b, func(num, _ int, u uint64, data []byte) (err error) {
+		switch num {
+		case 1:
+			v = nil
+		case 2:
+			v = u != 0
+		case 3:
+			v = int64(u>>1) ^ -int64(u&1)
+		case 4:
+			v = u
+		case 5:
+			v = math.Float64frombits(u)
+		case 6:
+			v = string(data)
+		case 7:
+			v = append([]byte{}, data...)
+		case 8:
+			list := []interface{}{}
+			err = protoFields(data, func(num, _ int, _ uint64, data []byte) error {
+				if num != 1 {
+					return nil
+				}
+				e, err := protoDecodeValue(data)
+				list = append(list, e)
+				return err
+			})
+			v = list
+		case 9:
+			m := map[string]interface{}{}
+			err = protoFields(data, func(num, _ int, _ uint64, data []byte) error {
+				if num != 1 {
+					return nil
+				}
+				var k string
+				var e interface{}
+				err := protoFields(data, func(num, _ int, _ uint64, data []byte) (err error) {
+					switch num {
+					case 1:
+						k = string(data)
+					case 2:
+						e, err = protoDecodeValue(data)
+					}
+					return
+				})
+				m[k] = e
+				return err
+			})
+			v = m
+		}
+		return
+	})
+	return v, err
+}
+
+func protoTag(b []byte, num, wire int) []byte {
+	return binary.AppendUvarint(b, uint64(num)<<3|uint64(wire))
+}
+
+func protoVarintField(b []byte, num int, v uint64) []byte {
+	return binary.AppendUvarint(protoTag(b, num, protoVarint), v)
+}
+
+// protoInt ... Append an int64 field, left out when zero as proto3 does
+func protoInt(b []byte, num int, v int64) []byte {
+	if v == 0 {
+		return b
+	}
+	return protoVarintField(b, num, uint64(v))
+}
+
+func protoBytes(b []byte, num int, data []byte) []byte {
+	b = binary.AppendUvarint(protoTag(b, num, protoLen), uint64(len(data)))
+	return append(b, data...)
+}
+
+// protoFields ... Call fn with every field of the message b: its number,
+// wire type, and value, a number for the fixed and varint types and the
+// bytes for the length delimited one. Groups are not supported
+func protoFields(b []byte, fn func(num, wire int, v uint64, data []byte) error) error {
+	for len(b) > 0 {
+		tag, n := binary.Uvarint(b)
+		if n <= 0 || tag>>3 == 0 {
+			return errProtobuf
+		}
+		b = b[n:]
+		var v uint64
+		var data []byte
+		switch wire := int(tag & 7); wire {
+		case protoVarint:
+			if v, n = binary.Uvarint(b); n <= 0 {
+				return errProtobuf
+			}
+			b = b[n:]
+		case protoI64:
+			if len(b) < 8 {
+				return errProtobuf
+			}
+			v, b = binary.LittleEndian.Uint64(b), b[8:]
+		case protoI32:
+			if len(b) < 4 {
+				return errProtobuf
+			}
+			v, b = uint64(binary.LittleEndian.Uint32(b)), b[4:]
+		case protoLen:
+			size, n := binary.Uvarint(b)
+			if n <= 0 || size > uint64(len(b)-n) {
+				return errProtobuf
+			}
+			data, b = b[n:n+int(size)], b[n+int(size):]
+		default:
+			return fmt.Errorf("%w: wire type %d", errProtobuf, wire)
+		}
+		if err := fn(int(tag>>3), int(tag&7), v, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package GoCache
+
+import "time"
+
+// rateWindow ... Data of a rate limited key: the requests counted in
+// the current window, which started at Start, and in the one before
+type rateWindow struct {
+	Start int64
+	Count int
+	Prev  int
+}
+
+// Allow ... Count a request on key and Report whether it stays within
+// limit per window, with a sliding window: the requests of the window
+// before are weighed by how much of it still overlaps the last window
+// Refused requests are not counted. The key Expires after two idle windows
+func (c *Cache) Allow(key string, limit int, window time.Duration) bool {
+	if limit <= 0 || window <= 0 {
+		return false
+	}
+	key = c.key(key)
+	c = c.lockFor(key)
+	defer c.unlock()
+	now := c.now().UnixNano()
+	w := int64(window)
+	item, found := c.items[key]
+	r, ok := c.open(item.Object).(rateWindow)
+	if !found || c.expired(item) || !ok {
+		item = Item{}
+		r = rateWindow{Start: now - now%w}
+	}
+	if elapsed := now - r.Start; elapsed >= 2*w {
+		r = rateWindow{Start: now - now%w}
+	} else if elapsed >= w {
+		r = rateWindow{Start: r.Start + w, Prev: r.Count}
+	}
+	overlap := float64(w-(now-r.Start)) / float64(w)
+	if float64(r.Prev)*overlap+float64(r.Count) >= float64(limit) {
+		return false
+	}
+	r.Count++
+	item.Object = r
+	item.Expiration = r.Start + 2*w
+	c.put(key, item)
+	return true
+}
+
+// Allow ... Count a request on key and Report whether it stays within
+// limit per window, see Cache.Allow
+func (sc *ShardedCache) Allow(key string, limit int, window time.Duration) bool {
+	return sc.shard(key).Allow(key, limit, window)
+}
@@ -0,0 +1,29 @@
+package GoCache
+
+// ReadOnlyCache ... The read operations of a Cache, to hand to code that
+// must not Set, Delete or Flush it
+type ReadOnlyCache interface {
+	Get(k string) (interface{}, bool)
+	Count() int
+	Keys() []string
+}
+
+// readOnly ... Hides the Cache behind it, so a ReadOnlyCache cannot be
+// asserted back to one
+type readOnly struct {
+	c ReadOnlyCache
+}
+
+func (r readOnly) Get(k string) (interface{}, bool) { return r.c.Get(k) }
+func (r readOnly) Count() int                       { return r.c.Count() }
+func (r readOnly) Keys() []string                   { return r.c.Keys() }
+
+// ReadOnly ... Return a view of the Cache that can only read it
+func (c *Cache) ReadOnly() ReadOnlyCache {
+	return readOnly{c}
+}
+
+// ReadOnly ... Return a view of all shards that can only read them
+func (sc *ShardedCache) ReadOnly() ReadOnlyCache {
+	return readOnly{sc}
+}
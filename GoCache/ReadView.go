@@ -0,0 +1,74 @@
+package GoCache
+
+import "sync/atomic"
+
+// WithLockFreeReads ... Let Get read a copy of the Data published by
+// every write, an immutable map swapped in atomically, so reads never
+// wait for the lock or contend on it. Each write lock that changed the
+// Data copies the whole map before it is released: a write costs time
+// and garbage in the size of the Cache, so this is for Caches read far
+// more than written, best with SetMulti to batch writes under one lock.
+// Reads that change state still take the lock: an eviction policy,
+// namespace bound, admission, read limit, idle timeout, refresh ahead or
+// early Expiration on the Cache, or Expired, Sliding or spilled Data
+func WithLockFreeReads() Option {
+	return func(o *Options) { o.LockFreeReads = true }
+}
+
+// readView ... The published copy of the Data
+type readView struct {
+	current atomic.Pointer[viewItems]
+	stale   atomic.Bool // The Data changed since current was published
+}
+
+type viewItems struct {
+	items map[string]Item
+	bare  bool // Reads need nothing but the lookup, see bareReads
+}
+
+// changed ... Mark the view stale, the caller holds the write lock and
+// is changing the Data. Until the copy is published, reads take the lock
+func (v *readView) changed() {
+	if v != nil {
+		v.stale.Store(true)
+	}
+}
+
+// publish ... Copy the Data for reads if it or what reads need changed,
+// the caller holds the write lock
+func (v *readView) publish(c *Cache) {
+	bare := c.bareReads()
+	if cur := v.current.Load(); cur != nil && cur.bare == bare && !v.stale.Load() {
+		return
+	}
+	next := &viewItems{bare: bare}
+	if bare {
+		next.items = make(map[string]Item, len(c.items))
+		for k, item := range c.items {
+			next.items[k] = item
+		}
+	}
+	v.current.Store(next)
+	v.stale.Store(false)
+}
+
+// readUnlocked ... Get k from the view, ok false when the read must
+// take the lock
+func (c *Cache) readUnlocked(k string) (v interface{}, found, ok bool) {
+	if c.lockFree == nil || c.lockFree.stale.Load() || c.tracksReads.Load() {
+		return nil, false, false
+	}
+	cur := c.lockFree.current.Load()
+	if !cur.bare {
+		return nil, false, false
+	}
+	item, found := cur.items[k]
+	if found {
+		if _, sp := item.Object.(spilled); sp || item.Sliding > 0 || c.expired(item) {
+			return nil, false, false
+		}
+		v, found = c.value(item)
+	}
+	c.countRead(k, found)
+	return v, found, true
+}
@@ -0,0 +1,102 @@
+package GoCache
+
+import "time"
+
+// Setters to tune a running Cache, say from an admin endpoint, without
+// making a new one. Data already In Cache keeps its Expiration
+
+// SetDefaultExpiration ... Expiration of Data Set from now on with
+// DefaultExpiration
+func (c *Cache) SetDefaultExpiration(d time.Duration) {
+	c.mutex.Lock()
+	defer c.unlock()
+	c.defaultExpiration = d
+}
+
+// SetGCInterval ... Sweep every d from now on, DefaultGcInterval if not
+// positive. The running interval is replaced at once; with the adaptive
+// GC on it still floats between its bounds from there
+func (c *Cache) SetGCInterval(d time.Duration) {
+	if d <= 0 {
+		d = DefaultGcInterval
+	}
+	c.mutex.Lock()
+	defer c.unlock()
+	c.gcInterval = d
+	resetGc(c.gcReset, d)
+}
+
+// SetMaxEntries ... Evict Data beyond n entries, zero or less lifts the
+// bound. Lowering it Evicts at once; a Cache that was unbounded starts
+// an eviction policy first, which sees the Data it holds in no
+// particular order
+func (c *Cache) SetMaxEntries(n int) {
+	if n < 0 {
+		n = 0
+	}
+	c.mutex.Lock()
+	defer c.unlock()
+	c.maxEntries = n
+	if n == 0 {
+		return
+	}
+	if c.policy == nil {
+		if c.newPolicy == nil {
+			c.newPolicy = NewLRUPolicy
+		}
+		c.policy = c.newPolicy()
+		for k, v := range c.items {
+			if v.Priority != PriorityNormal {
+				c.prioritize()
+			}
+			c.policy.OnSet(k)
+		}
+		c.tracksReads.Store(c.readsNeedWriteLock())
+	}
+	c.evictOverBudget()
+}
+
+// SetDefaultExpiration ... Set the default Expiration of every shard
+func (sc *ShardedCache) SetDefaultExpiration(d time.Duration) {
+	sc.resharding.Lock()
+	defer sc.resharding.Unlock()
+	for _, c := range sc.shardSet() {
+		c.SetDefaultExpiration(d)
+	}
+}
+
+// SetGCInterval ... Sweep all shards every d from now on,
+// DefaultGcInterval if not positive
+func (sc *ShardedCache) SetGCInterval(d time.Duration) {
+	if d <= 0 {
+		d = DefaultGcInterval
+	}
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	sc.gcInterval = d
+	resetGc(sc.gcReset, d)
+}
+
+// SetMaxEntries ... Split a bound of n entries evenly between the shards,
+// like NewShardedCache does with Options.MaxEntries
+func (sc *ShardedCache) SetMaxEntries(n int) {
+	sc.resharding.Lock()
+	defer sc.resharding.Unlock()
+	shards := sc.shardSet()
+	if n > 0 {
+		n = (n + len(shards) - 1) / len(shards)
+	}
+	for _, c := range shards {
+		c.SetMaxEntries(n)
+	}
+}
+
+// resetGc ... Hand d to the GC goRoutine reading reset, replacing an
+// interval it has not picked up yet. Callers serialize the sends
+func resetGc(reset chan time.Duration, d time.Duration) {
+	select {
+	case <-reset:
+	default:
+	}
+	reset <- d
+}
@@ -0,0 +1,56 @@
+package GoCache
+
+import "time"
+
+type refreshConfig struct {
+	threshold time.Duration
+	loader    func(string) (interface{}, error)
+	ttl       time.Duration
+}
+
+// EnableRefreshAhead ... When Get finds Data with less than threshold
+// left to live, call loader in a goRoutine and Set its result with
+// Expiration d, so hot Data is replaced before it Expires
+// A failed load leaves the current Data alone, nil loader turns it off
+func (c *Cache) EnableRefreshAhead(threshold time.Duration, loader func(k string) (interface{}, error), d time.Duration) {
+	c.mutex.Lock()
+	defer c.unlock()
+	if loader == nil {
+		c.refresh = nil
+		return
+	}
+	c.refresh = &refreshConfig{threshold: threshold, loader: loader, ttl: d}
+}
+
+// needsRefresh ... Report whether the live Data at k is due for refresh,
+// being close to Expire or past its soft Expiration, the caller holds the lock
+func (c *Cache) needsRefresh(k string) bool {
+	if c.refresh == nil {
+		return false
+	}
+	item := c.items[k]
+	if c.softExpired(item) {
+		return true
+	}
+	e := item.Expiration
+	return e > 0 && time.Unix(0, e).Sub(c.now()) < c.refresh.threshold
+}
+
+// refreshAhead ... Reload k in the background unless a load is running
+func (c *Cache) refreshAhead(k string) {
+	c.mutex.RLock()
+	r := c.refresh
+	c.mutex.RUnlock()
+	if r == nil {
+		return
+	}
+	c.loadAsync(k, func() (interface{}, error) {
+		v, err := c.callLoader(k, func() (interface{}, error) { return r.loader(k) })
+		if err == nil {
+			c.setKey(k, v, r.ttl)
+		} else if !circuitOpen(err) {
+			c.warn("refresh ahead load failed", "key", k, "err", err)
+		}
+		return v, err
+	})
+}
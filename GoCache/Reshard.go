@@ -0,0 +1,275 @@
+package GoCache
+
+import (
+	"errors"
+	"runtime"
+	"time"
+)
+
+// ErrReshard ... Reshard of a ShardedCache whose Data cannot move to
+// other shards: it writes an append log per shard index, or has Watch
+// channels that only hear the shards they were made on
+var ErrReshard = errors.New("shards cannot be re-sharded")
+
+// WithOnlineResharding ... Let the GC of a ShardedCache double its
+// shards, up to 4096, when the mean lock wait of the shards is over
+// maxWait, see ShardedCache.Reshard and SuggestShards. It turns on the
+// lock wait stats of the shards, and has no effect on a Cache
+func WithOnlineResharding(maxWait time.Duration) Option {
+	return func(o *Options) { o.ReshardWait = maxWait }
+}
+
+// Reshard ... Move all Data into n new shards while the ShardedCache is
+// in use, AutoShards picks n from GOMAXPROCS and zero is DefaultShards
+// Every shard is locked while the Data moves and the new shards are
+// swapped in, so calls wait for it as for a Flush. The old shards are
+// marked retired under their locks, and a call that found its shard
+// just before the swap and gets the lock just after sees the mark and
+// goes on to the shard of its key in the new set
+// MaxEntries and MaxBytes in force are split over the new shards, and
+// the settings of SetDefaultExpiration, PauseExpiration and
+// EnableLockWaitStats carry over, as do ExpiredEvents channels. It
+// returns ErrReshard with an append log or Watch channels open
+func (sc *ShardedCache) Reshard(n int) error {
+	if n == AutoShards {
+		n = autoShards(runtime.GOMAXPROCS(0))
+	}
+	if n <= 0 {
+		n = DefaultShards
+	}
+	if sc.opts.AppendLog != "" {
+		return ErrReshard
+	}
+	sc.resharding.Lock()
+	defer sc.resharding.Unlock()
+	// ExpiredEvents holds resharding too, so no channel is added now
+	sc.mutex.Lock()
+	subs := sc.expiredSubs
+	sc.mutex.Unlock()
+	old := sc.lockAll()
+	retired := false
+	defer func() {
+		for _, c := range old {
+			c.unlock()
+		}
+		if !retired {
+			return
+		}
+		// Calls still coming to the old shards go on to the new ones
+		for _, c := range old {
+			c.closeExpiredEvents()
+		}
+	}()
+	if len(old) == n {
+		return nil
+	}
+	var maxEntries int
+	var maxBytes int64
+	var floor uint64
+	for _, c := range old {
+		if len(c.watchers) > 0 {
+			return ErrReshard
+		}
+		maxEntries += c.maxEntries
+		maxBytes += c.maxBytes
+		if c.diffFloor > floor {
+			floor = c.diffFloor
+		}
+	}
+	opts := shardOptions(sc.opts, n)
+	opts.MaxEntries, opts.MaxBytes = 0, 0
+	if maxEntries > 0 {
+		opts.MaxEntries = (maxEntries + n - 1) / n
+	}
+	if maxBytes > 0 {
+		opts.MaxBytes = (maxBytes + int64(n) - 1) / int64(n)
+	}
+	next := newShards(n, opts, old[0].versions, old[0].breaker)
+	for _, c := range next {
+		c.lock()
+		c.defaultExpiration = old[0].defaultExpiration
+		c.paused.Store(old[0].paused.Load())
+		c.lockWait.enabled.Store(old[0].lockWait.enabled.Load())
+		c.diffFloor = floor
+	}
+	for _, c := range old {
+		c.moveTo(next, sc.hasher)
+		next[0].stats.carry(&c.stats)
+	}
+	for _, c := range next {
+		c.unlock()
+	}
+	for _, out := range subs {
+		feedExpired(out, next)
+	}
+	for _, c := range old {
+		c.movedTo = sc
+		c.lockFree.changed() // Reads of the view take the lock and see the mark
+	}
+	sc.shards.Store(&next)
+	retired = true
+	return nil
+}
+
+// lockAll ... Lock every shard in order and Return them, again if a
+// Reshard swapped them while this waited
+func (sc *ShardedCache) lockAll() []*Cache {
+	for {
+		set := sc.shards.Load()
+		for _, c := range *set {
+			c.lock()
+		}
+		if sc.shards.Load() == set {
+			return *set
+		}
+		for _, c := range *set {
+			c.unlock()
+		}
+	}
+}
+
+// reshardTick ... Reshard if WithOnlineResharding is on and the shards
+// wait too long for their locks. Run by the GC after a sweep
+func (sc *ShardedCache) reshardTick() {
+	if sc.opts.ReshardWait <= 0 {
+		return
+	}
+	if n := sc.SuggestShards(sc.opts.ReshardWait); n > sc.ShardCount() {
+		if err := sc.Reshard(n); err != nil {
+			sc.shardSet()[0].warn("online re-shard failed", "shards", n, "err", err)
+		}
+	}
+}
+
+// lockFor ... Lock c for a call on k, in canonical form, and Return it,
+// or the shard of k in the current set if a Reshard retired c before
+// the lock was had
+func (c *Cache) lockFor(k string) *Cache {
+	c.lock()
+	for c.movedTo != nil {
+		next := c.movedTo.shardOf(k)
+		c.mutex.Unlock()
+		c = next
+		c.lock()
+	}
+	return c
+}
+
+// rLockFor ... lockFor with the read lock
+func (c *Cache) rLockFor(k string) *Cache {
+	c.rLock()
+	for c.movedTo != nil {
+		next := c.movedTo.shardOf(k)
+		c.mutex.RUnlock()
+		c = next
+		c.rLock()
+	}
+	return c
+}
+
+// lockForReadOf ... lockFor with the lock a read needs, see lockForRead
+func (c *Cache) lockForReadOf(k string) (*Cache, bool) {
+	write := c.lockForRead()
+	for c.movedTo != nil {
+		next := c.movedTo.shardOf(k)
+		c.unlockForRead(write)
+		c = next
+		write = c.lockForRead()
+	}
+	return c, write
+}
+
+// tryLockFor ... lockFor within timeout, each shard tried gets it anew
+func (c *Cache) tryLockFor(k string, timeout time.Duration) (*Cache, bool) {
+	for c.tryLock(timeout) {
+		if c.movedTo == nil {
+			return c, true
+		}
+		next := c.movedTo.shardOf(k)
+		c.mutex.Unlock()
+		c = next
+	}
+	return c, false
+}
+
+// lockOrMoved ... Lock c for a call on many keys, or Return the
+// ShardedCache to hand the call to if a Reshard retired c, unlocked
+func (c *Cache) lockOrMoved() *ShardedCache {
+	c.lock()
+	if sc := c.movedTo; sc != nil {
+		c.mutex.Unlock()
+		return sc
+	}
+	return nil
+}
+
+// moveTo ... Hand every Data of the shard to its shard among next, in
+// eviction order so the policies there keep it, with the removals Diff
+// needs. The caller holds the locks of the shard and of next
+func (c *Cache) moveTo(next []*Cache, hasher ShardHasher) {
+	var keys []string
+	if l, ok := c.policy.(VictimLister); ok {
+		keys = l.Victims(len(c.items))
+	}
+	moved := make(map[string]bool, len(c.items))
+	for _, k := range keys {
+		if item, found := c.items[k]; found && !moved[k] {
+			shardIn(next, hasher, k).adopt(k, item)
+			moved[k] = true
+		}
+	}
+	for k, item := range c.items {
+		if !moved[k] {
+			shardIn(next, hasher, k).adopt(k, item)
+		}
+	}
+	for k, version := range c.tombstones {
+		to := shardIn(next, hasher, k)
+		if to.tombstones == nil {
+			to.tombstones = map[string]uint64{}
+		}
+		to.tombstones[k] = version
+	}
+}
+
+// adopt ... Store item moved from another shard as it is, keeping its
+// version, pins and reads left, the caller holds the lock
+func (c *Cache) adopt(k string, item Item) {
+	c.items[k] = item
+	c.lockFree.changed()
+	c.index(k, item)
+	if c.bloom != nil {
+		c.bloom.add(k)
+	}
+	if c.prefixes != nil {
+		c.prefixes.insert(k)
+	}
+	if item.reads != nil && !c.readLimited {
+		c.readLimited = true
+		c.tracksReads.Store(true)
+	}
+	if c.policy == nil || item.pins > 0 {
+		return
+	}
+	if item.Priority != PriorityNormal {
+		c.prioritize()
+	}
+	c.policy.OnSet(k)
+}
+
+// carry ... Add the counters of from, so the Stats of a ShardedCache
+// do not drop at a Reshard
+func (s *cacheStats) carry(from *cacheStats) {
+	s.hits.Add(from.hits.Load())
+	s.misses.Add(from.misses.Load())
+	s.sets.Add(from.sets.Load())
+	s.expired.Add(from.expired.Load())
+	s.evictions.Add(from.evictions.Load())
+	s.rejections.Add(from.rejections.Load())
+	s.gcSweeps.Add(from.gcSweeps.Load())
+	s.gcRemoved.Add(from.gcRemoved.Load())
+	s.gcTotal.Add(from.gcTotal.Load())
+	if last := from.gcLast.Load(); last > s.gcLast.Load() {
+		s.gcLast.Store(last)
+	}
+}
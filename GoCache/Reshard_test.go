@@ -0,0 +1,166 @@
+package GoCache
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestReshard(t *testing.T) {
+	sc := NewShardedCache(4, Options{GcInterval: time.Hour, MaxEntries: 1000})
+	defer sc.Close()
+	for i := 0; i < 500; i++ {
+		sc.Set(strconv.Itoa(i), i, NoExpiration)
+	}
+	sc.Delete("0")
+	since := sc.Version()
+	sc.Delete("1")
+	if err := sc.Reshard(16); err != nil {
+		t.Fatal(err)
+	}
+	if n := sc.ShardCount(); n != 16 {
+		t.Fatalf("ShardCount() = %d after Reshard(16)", n)
+	}
+	if n := sc.Count(); n != 498 {
+		t.Fatalf("Count() = %d after Reshard, want 498", n)
+	}
+	for i := 2; i < 500; i++ {
+		if v, found := sc.Get(strconv.Itoa(i)); !found || v != i {
+			t.Fatalf("Get(%d) = %v, %v after Reshard", i, v, found)
+		}
+	}
+	if d := sc.Diff(since); len(d.Deleted) != 1 || d.Deleted[0] != "1" {
+		t.Fatalf("Diff across Reshard deleted %v, want [1]", d.Deleted)
+	}
+	if s := sc.Stats(); s.Sets != 500 {
+		t.Fatalf("Stats().Sets = %d after Reshard, want 500", s.Sets)
+	}
+	// The bound of 1000 is split over the new shards
+	for _, c := range sc.shardSet() {
+		if c.maxEntries != 1000/16+1 {
+			t.Fatalf("shard bound %d, want %d", c.maxEntries, 1000/16+1)
+		}
+	}
+}
+
+func TestReshardConcurrent(t *testing.T) {
+	sc := NewShardedCache(2, Options{GcInterval: time.Hour})
+	defer sc.Close()
+	const writers, keys = 8, 64
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	last := make([]int, writers)
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; ; i++ {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				sc.Set(strconv.Itoa(w*keys+i%keys), i, NoExpiration)
+				last[w] = i
+			}
+		}(w)
+	}
+	for _, n := range []int{4, 8, 32, 64} {
+		time.Sleep(10 * time.Millisecond)
+		if err := sc.Reshard(n); err != nil {
+			t.Fatal(err)
+		}
+	}
+	close(stop)
+	wg.Wait()
+	for w := 0; w < writers; w++ {
+		for j := 0; j < keys && j <= last[w]; j++ {
+			// The last value written to each key of the writer
+			want := last[w] - (last[w]-j)%keys
+			k := strconv.Itoa(w*keys + j)
+			if v, found := sc.Get(k); !found || v != want {
+				t.Fatalf("Get(%s) = %v, %v after Reshards under writes, want %d", k, v, found, want)
+			}
+		}
+	}
+}
+
+func TestReshardReadYourWrites(t *testing.T) {
+	sc := NewShardedCache(2, Options{GcInterval: time.Hour})
+	defer sc.Close()
+	const workers = 8
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	errs := make(chan string, workers)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; ; i++ {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				k := strconv.Itoa(w*1000 + i%1000)
+				sc.Set(k, i, NoExpiration)
+				if v, found := sc.Get(k); !found || v != i {
+					errs <- fmt.Sprintf("Get(%s) = %v, %v right after Set(%d) across a Reshard", k, v, found, i)
+					return
+				}
+			}
+		}(w)
+	}
+	for _, n := range []int{4, 8, 3, 16, 2, 32} {
+		time.Sleep(5 * time.Millisecond)
+		if err := sc.Reshard(n); err != nil {
+			t.Fatal(err)
+		}
+	}
+	close(stop)
+	wg.Wait()
+	close(errs)
+	for msg := range errs {
+		t.Error(msg)
+	}
+}
+
+func TestReshardRefused(t *testing.T) {
+	sc := NewShardedCache(4, Options{GcInterval: time.Hour})
+	defer sc.Close()
+	_, cancel := sc.Watch("")
+	if err := sc.Reshard(8); !errors.Is(err, ErrReshard) {
+		t.Fatalf("Reshard with a Watch = %v, want ErrReshard", err)
+	}
+	cancel()
+	if err := sc.Reshard(8); err != nil {
+		t.Fatalf("Reshard after cancel = %v", err)
+	}
+}
+
+func TestOnlineResharding(t *testing.T) {
+	sc := NewSharded(2, WithGCInterval(time.Hour), WithOnlineResharding(time.Nanosecond))
+	defer sc.Close()
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				sc.Set("k", j, NoExpiration)
+			}
+		}()
+	}
+	wg.Wait()
+	var log sweepLog
+	sc.gcTick(&log, time.Hour)
+	if n := sc.ShardCount(); n != 4 {
+		t.Fatalf("ShardCount() = %d after a GC with lock waits, want 4", n)
+	}
+	if v, found := sc.Get("k"); !found || v != 999 {
+		t.Fatalf("Get(k) = %v, %v after online re-shard", v, found)
+	}
+}
@@ -0,0 +1,60 @@
+package GoCache
+
+import "time"
+
+// CachedError ... Value GetOrComputeResult keeps for a loader error, so
+// a Get of the key tells it apart from a result
+type CachedError struct {
+	Err error
+}
+
+func (e CachedError) Error() string { return e.Err.Error() }
+
+// Unwrap ... Return the error of the loader, for errors.Is and As
+func (e CachedError) Unwrap() error { return e.Err }
+
+// GetOrComputeResult ... GetOrCompute that caches errors too: a result
+// is kept for d, an error of loader as a CachedError for errTTL, so a
+// failing backend is asked again after errTTL rather than on every
+// miss, and sooner than a result is refreshed. Cached errors are
+// returned as loader returned them. errTTL of zero or less caches no
+// errors, as GetOrCompute
+func (c *Cache) GetOrComputeResult(k string, loader func() (interface{}, error), d, errTTL time.Duration) (interface{}, error) {
+	k = c.key(k)
+	v, found, early := c.read(k)
+	if found {
+		return result(v)
+	}
+	return c.load(k, func() (interface{}, error) {
+		if !early {
+			if v, found := c.getKey(k); found {
+				return result(v)
+			}
+		}
+		start := c.now()
+		v, err := c.callLoader(k, loader)
+		if circuitOpen(err) {
+			if v, found := c.staleValue(k); found {
+				return result(v)
+			}
+			return nil, err
+		}
+		if err != nil {
+			if errTTL > 0 {
+				c.setKey(k, CachedError{err}, errTTL)
+			}
+			return nil, err
+		}
+		c.setWithComputeCost(k, v, d, c.now().Sub(start))
+		return v, nil
+	})
+}
+
+// result ... Split Data read by GetOrComputeResult into the result and
+// the error it caches
+func result(v interface{}) (interface{}, error) {
+	if e, ok := v.(CachedError); ok {
+		return nil, e.Err
+	}
+	return v, nil
+}
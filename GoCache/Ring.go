@@ -0,0 +1,73 @@
+package GoCache
+
+import (
+	"sort"
+	"strconv"
+)
+
+// DefaultVirtualNodes ... Points each node gets on the ring when given zero
+const DefaultVirtualNodes = 160
+
+// hashRing ... Consistent hash ring of node names, each node has many
+// virtual points on it to even out the shares. Not safe for concurrent
+// use, its owner locks it
+type hashRing struct {
+	replicas int
+	points   []ringPoint // Sorted by hash
+}
+
+type ringPoint struct {
+	hash uint64
+	node string
+}
+
+func newHashRing(replicas int) *hashRing {
+	if replicas <= 0 {
+		replicas = DefaultVirtualNodes
+	}
+	return &hashRing{replicas: replicas}
+}
+
+// ringHash ... FNV-1a mixed so near names land far apart on the ring
+func ringHash(k string) uint64 {
+	h := hash64(k)
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
+
+// add ... Put the points of node on the ring
+func (r *hashRing) add(node string) {
+	for i := 0; i < r.replicas; i++ {
+		r.points = append(r.points, ringPoint{ringHash(node + "#" + strconv.Itoa(i)), node})
+	}
+	sort.Slice(r.points, func(i, j int) bool { return r.points[i].hash < r.points[j].hash })
+}
+
+// remove ... Take the points of node off the ring
+func (r *hashRing) remove(node string) {
+	points := r.points[:0]
+	for _, p := range r.points {
+		if p.node != node {
+			points = append(points, p)
+		}
+	}
+	r.points = points
+}
+
+// locate ... Return the node of the first point at or after the hash
+// of k, wrapping around the end of the ring, false if it is empty
+func (r *hashRing) locate(k string) (string, bool) {
+	if len(r.points) == 0 {
+		return "", false
+	}
+	h := ringHash(k)
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i].hash >= h })
+	if i == len(r.points) {
+		i = 0
+	}
+	return r.points[i].node, true
+}
@@ -0,0 +1,68 @@
+package GoCache
+
+import (
+	"context"
+	"time"
+)
+
+// GCResult ... What one GC sweep did
+type GCResult struct {
+	Removed  int // Expired Data removed, and idle Data with WithIdleTimeout
+	Scanned  int
+	Duration time.Duration
+}
+
+// gcFlight ... A RunGC sweep in progress
+type gcFlight struct {
+	done chan struct{}
+	res  GCResult
+}
+
+// RunGC ... Run a full GC sweep now and Return what it did
+// Calls made while a sweep runs wait for it and share its result
+// instead of starting another. When ctx is done first, ctx.Err() is
+// returned and the sweep finishes on its own
+func (c *Cache) RunGC(ctx context.Context) (GCResult, error) {
+	if err := ctx.Err(); err != nil {
+		return GCResult{}, err
+	}
+	c.flightMutex.Lock()
+	f := c.gcFlight
+	if f == nil {
+		f = &gcFlight{done: make(chan struct{})}
+		c.gcFlight = f
+		go func() {
+			start := time.Now()
+			removed, scanned := c.deleteExpired()
+			f.res = GCResult{Removed: removed, Scanned: scanned, Duration: time.Since(start)}
+			c.flightMutex.Lock()
+			c.gcFlight = nil
+			c.flightMutex.Unlock()
+			close(f.done)
+		}()
+	}
+	c.flightMutex.Unlock()
+	select {
+	case <-f.done:
+		return f.res, nil
+	case <-ctx.Done():
+		return GCResult{}, ctx.Err()
+	}
+}
+
+// RunGC ... Run a full GC sweep of the shards one after the other and
+// Return the sum of what they did. When ctx is done the shards not
+// swept yet are skipped and ctx.Err() is returned with the partial sum
+func (sc *ShardedCache) RunGC(ctx context.Context) (GCResult, error) {
+	var total GCResult
+	for _, c := range sc.shardSet() {
+		res, err := c.RunGC(ctx)
+		total.Removed += res.Removed
+		total.Scanned += res.Scanned
+		total.Duration += res.Duration
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
@@ -0,0 +1,94 @@
+package GoCache
+
+import "math/rand"
+
+// DefaultEvictionSamples ... Sample size NewSampledLRUPolicy uses when
+// given zero, the default of Redis
+const DefaultEvictionSamples = 5
+
+// NewSampledLRUPolicy ... Make policies that approximate LRU the way
+// Redis does: Victim looks at samples keys picked at random and returns
+// the one read or written least recently
+// It keeps a key and a counter per Data in a slice, against a list
+// element and a map entry pointing to it for NewLRUPolicy, and a read
+// only bumps the counter. The price is the choice: the victim is the
+// oldest of the sample, not of the Cache, so recently used Data is
+// sometimes evicted. More samples get closer to LRU, at samples times
+// the cost per eviction; 5 is close for most loads, 10 very close
+func NewSampledLRUPolicy(samples int) func() EvictionPolicy {
+	if samples <= 0 {
+		samples = DefaultEvictionSamples
+	}
+	return func() EvictionPolicy {
+		return &sampledPolicy{samples: samples, index: map[string]int{}}
+	}
+}
+
+type sampledEntry struct {
+	key  string
+	used uint64 // Value of clock at the last read or write
+}
+
+// sampledPolicy ... Keys packed in a slice, so a random one is a random
+// index; a Deleted key takes the place of the last one
+type sampledPolicy struct {
+	samples int
+	entries []sampledEntry
+	index   map[string]int // Position of each key in entries
+	clock   uint64
+}
+
+func (p *sampledPolicy) OnGet(k string) {
+	if i, ok := p.index[k]; ok {
+		p.clock++
+		p.entries[i].used = p.clock
+	}
+}
+
+func (p *sampledPolicy) OnSet(k string) {
+	p.clock++
+	if i, ok := p.index[k]; ok {
+		p.entries[i].used = p.clock
+		return
+	}
+	p.index[k] = len(p.entries)
+	p.entries = append(p.entries, sampledEntry{key: k, used: p.clock})
+}
+
+func (p *sampledPolicy) OnDelete(k string) {
+	i, ok := p.index[k]
+	if !ok {
+		return
+	}
+	last := len(p.entries) - 1
+	if i != last {
+		p.entries[i] = p.entries[last]
+		p.index[p.entries[i].key] = i
+	}
+	p.entries[last] = sampledEntry{}
+	p.entries = p.entries[:last]
+	delete(p.index, k)
+}
+
+func (p *sampledPolicy) Victim() (string, bool) {
+	n := len(p.entries)
+	if n == 0 {
+		return "", false
+	}
+	if n <= p.samples {
+		best := 0
+		for i := 1; i < n; i++ {
+			if p.entries[i].used < p.entries[best].used {
+				best = i
+			}
+		}
+		return p.entries[best].key, true
+	}
+	best := rand.Intn(n)
+	for i := 1; i < p.samples; i++ {
+		if j := rand.Intn(n); p.entries[j].used < p.entries[best].used {
+			best = j
+		}
+	}
+	return p.entries[best].key, true
+}
@@ -0,0 +1,25 @@
+package GoCache
+
+import "io"
+
+// SaveStream ... Save in the record format: the header of Save followed
+// by one length prefixed record per Data, which Load decodes and merges
+// a batch at a time instead of decoding the whole snapshot first, so a
+// huge Cache loads with little memory beyond its own
+// Builds from before the format cannot read it. SaveToFile seals the
+// whole snapshot in memory, write SaveStream to a plain file to get the
+// benefit
+func (c *Cache) SaveStream(w io.Writer) error {
+	return encodeRecords(w, c.codec, c.copyItems())
+}
+
+// SaveStream ... Save all shards in the record format of
+// Cache.SaveStream
+func (sc *ShardedCache) SaveStream(w io.Writer) error {
+	shards := sc.shardSet()
+	parts := make([]map[string]Item, len(shards))
+	for i, c := range shards {
+		parts[i] = c.copyItems()
+	}
+	return encodeRecords(w, shards[0].codec, parts...)
+}
@@ -0,0 +1,213 @@
+package GoCache
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Format ... Layout of a seed file read by LoadSeed
+type Format int
+
+const (
+	// FormatCSV ... A header row naming the columns, then one row per
+	// Data. Values are strings, or a map[string]string of the row
+	FormatCSV Format = iota
+	// FormatJSON ... An array of objects or a stream of them, such as JSON
+	// lines. Values come back as the types encoding/json decodes into,
+	// like JSONCodec. A key may be a string or a number
+	FormatJSON
+)
+
+// seedPair ... A key and value read from a seed file
+type seedPair struct {
+	k string
+	v interface{}
+}
+
+// LoadSeed ... Set the records of a seed file with Expiration d, keyed
+// by their keyField, to preload static reference data such as country
+// codes or feature flags. The value is the valueField of the record, or
+// with valueField empty the whole record. The file is read in full
+// before any Data is Set, so a bad record, or one without keyField,
+// Sets nothing; later records win over earlier ones with the same key
+func (c *Cache) LoadSeed(r io.Reader, format Format, keyField, valueField string, d time.Duration) error {
+	pairs, err := readSeed(r, format, keyField, valueField)
+	if err != nil {
+		return err
+	}
+	c.lock()
+	defer c.unlock()
+	for _, p := range pairs {
+		c.set(c.key(p.k), p.v, d)
+	}
+	return nil
+}
+
+// LoadSeed ... LoadSeed into the shards of the keys
+func (sc *ShardedCache) LoadSeed(r io.Reader, format Format, keyField, valueField string, d time.Duration) error {
+	pairs, err := readSeed(r, format, keyField, valueField)
+	if err != nil {
+		return err
+	}
+	sc.setSeed(pairs, d)
+	return nil
+}
+
+// setSeed ... Set pairs in the shard of each key
+func (sc *ShardedCache) setSeed(pairs []seedPair, d time.Duration) {
+	byShard := map[*Cache][]seedPair{}
+	for _, p := range pairs {
+		c := sc.shard(p.k)
+		byShard[c] = append(byShard[c], p)
+	}
+	for c, ps := range byShard {
+		if moved := c.lockOrMoved(); moved != nil {
+			moved.setSeed(ps, d)
+			continue
+		}
+		for _, p := range ps {
+			c.set(c.key(p.k), p.v, d)
+		}
+		c.unlock()
+	}
+}
+
+func readSeed(r io.Reader, format Format, keyField, valueField string) ([]seedPair, error) {
+	switch format {
+	case FormatCSV:
+		return readSeedCSV(r, keyField, valueField)
+	case FormatJSON:
+		return readSeedJSON(r, keyField, valueField)
+	}
+	return nil, fmt.Errorf("unknown seed format %d", format)
+}
+
+func readSeedCSV(r io.Reader, keyField, valueField string) ([]seedPair, error) {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	keyCol, valueCol := -1, -1
+	for i, name := range header {
+		switch name {
+		case keyField:
+			keyCol = i
+		case valueField:
+			valueCol = i
+		}
+	}
+	if keyCol < 0 {
+		return nil, fmt.Errorf("no column %q", keyField)
+	}
+	if valueField != "" && valueCol < 0 {
+		return nil, fmt.Errorf("no column %q", valueField)
+	}
+	var pairs []seedPair
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			return pairs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if valueCol >= 0 {
+			pairs = append(pairs, seedPair{row[keyCol], row[valueCol]})
+			continue
+		}
+		record := make(map[string]string, len(header))
+		for i, name := range header {
+			record[name] = row[i]
+		}
+		pairs = append(pairs, seedPair{row[keyCol], record})
+	}
+}
+
+func readSeedJSON(r io.Reader, keyField, valueField string) ([]seedPair, error) {
+	br := bufio.NewReader(r)
+	dec := json.NewDecoder(br)
+	// An array is read an element at a time, like a stream
+	if first, err := peekNonSpace(br); err == nil && first == '[' {
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+	}
+	var pairs []seedPair
+	for n := 1; dec.More(); n++ {
+		var record map[string]json.RawMessage
+		if err := dec.Decode(&record); err != nil {
+			return nil, fmt.Errorf("record %d: %v", n, err)
+		}
+		k, err := seedKey(record[keyField])
+		if err != nil {
+			return nil, fmt.Errorf("record %d: field %q: %v", n, keyField, err)
+		}
+		var v interface{}
+		if valueField == "" {
+			whole := make(map[string]interface{}, len(record))
+			for name, raw := range record {
+				var fv interface{}
+				if err := json.Unmarshal(raw, &fv); err != nil {
+					return nil, fmt.Errorf("record %d: %v", n, err)
+				}
+				whole[name] = fv
+			}
+			v = whole
+		} else {
+			raw, found := record[valueField]
+			if !found {
+				return nil, fmt.Errorf("record %d: no field %q", n, valueField)
+			}
+			if err := json.Unmarshal(raw, &v); err != nil {
+				return nil, fmt.Errorf("record %d: %v", n, err)
+			}
+		}
+		pairs = append(pairs, seedPair{k, v})
+	}
+	return pairs, nil
+}
+
+// seedKey ... The key a JSON string or number stands for
+func seedKey(raw json.RawMessage) (string, error) {
+	if raw == nil {
+		return "", fmt.Errorf("missing")
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var k interface{}
+	if err := dec.Decode(&k); err != nil {
+		return "", err
+	}
+	switch k := k.(type) {
+	case string:
+		return k, nil
+	case json.Number:
+		return k.String(), nil
+	}
+	return "", fmt.Errorf("%s is not a string or number", raw)
+}
+
+// peekNonSpace ... The first byte of br past white space, left unread
+func peekNonSpace(br *bufio.Reader) (byte, error) {
+	for {
+		b, err := br.Peek(1)
+		if err != nil {
+			return 0, err
+		}
+		switch b[0] {
+		case ' ', '\t', '\r', '\n':
+			br.ReadByte()
+		default:
+			return b[0], nil
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package GoCache
+
+import "time"
+
+// SetOption ... Per call setting of Set, so metadata of one Data needs
+// no Set variant of its own
+//
+//	c.Set("user:1", u, time.Hour, GoCache.WithTags("users"), GoCache.WithPriority(GoCache.PriorityHigh))
+type SetOption func(item *Item)
+
+// WithTags ... File the Data under tags, like SetWithTags
+func WithTags(tags ...string) SetOption {
+	return func(item *Item) { item.Tags = tags }
+}
+
+// WithPriority ... Give the Data priority p, like SetWithPriority
+func WithPriority(p Priority) SetOption {
+	return func(item *Item) { item.Priority = p }
+}
+
+// WithOnExpire ... Call f when the Data Expires, like SetWithOnExpired
+func WithOnExpire(f func(string, interface{})) SetOption {
+	return func(item *Item) { item.onExpired = f }
+}
+
+// WithSize ... Count the Data as size against MaxBytes, like SetWithSize
+func WithSize(size int64) SetOption {
+	return func(item *Item) { item.size = size }
+}
+
+// WithComputeCost ... Record the time the Data took to compute, like
+// SetWithComputeCost
+func WithComputeCost(cost time.Duration) SetOption {
+	return func(item *Item) { item.ComputeCost = cost }
+}
+
+// WithDeps ... Delete the Data along with any of deps, like SetWithDeps
+func WithDeps(deps ...string) SetOption {
+	return func(item *Item) { item.Deps = deps }
+}
@@ -0,0 +1,30 @@
+package GoCache
+
+import "time"
+
+// SetUntil ... Set Data Expiring at deadline instead of after a
+// duration, so callers with a wall clock deadline need not compute one
+// against a clock that may differ from the Cache's. A deadline already
+// past Expires the Data at once, the zero time.Time never. The deadline
+// is kept as given: no jitter, MinTTL or MaxTTL, nor sliding by default
+func (c *Cache) SetUntil(k string, v interface{}, deadline time.Time, opts ...SetOption) {
+	k = c.key(k)
+	item := Item{Object: v}
+	if !deadline.IsZero() {
+		// Zero would mean never, as would a deadline before 1970
+		if item.Expiration = deadline.UnixNano(); item.Expiration <= 0 {
+			item.Expiration = 1
+		}
+	}
+	for _, opt := range opts {
+		opt(&item)
+	}
+	c = c.lockFor(k)
+	defer c.unlock()
+	c.put(k, item)
+}
+
+// SetUntil ... SetUntil in the shard of k
+func (sc *ShardedCache) SetUntil(k string, v interface{}, deadline time.Time, opts ...SetOption) {
+	sc.shard(k).SetUntil(k, v, deadline, opts...)
+}
@@ -0,0 +1,679 @@
+package GoCache
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultShards ... Number of shards NewShardedCache uses when given zero
+const DefaultShards = 256
+
+// ShardedCache ... Cache split into shards by key hash, each with its
+// own lock, so writers of different keys do not wait on each other
+// It offers the same methods as Cache
+type ShardedCache struct {
+	shards      atomic.Pointer[[]*Cache] // Swapped whole by Reshard
+	opts        Options                  // As given, Reshard makes shards from them
+	resharding  sync.Mutex               // Held by Reshard and the calls it must not race
+	hasher      ShardHasher
+	stopGc      chan bool
+	gcReset     chan time.Duration // New GC intervals, see SetGCInterval
+	stopOnce    sync.Once
+	gc          gcRunner
+	loading     atomic.Int32 // Loads running
+	closeOnce   sync.Once
+	closeErr    error
+	persistFile string
+	snapshot    *snapshotConfig
+	mutex       sync.Mutex  // Guards invalidator, unsubscribe, expiredSubs and the GC intervals, orders sends on gcReset
+	invalidator Invalidator // Shared by the shards, which have none
+	instanceID  string
+	unsubscribe func()
+	expiredSubs []*expiredSub // Channels of ExpiredEvents, fed by the shards
+	gcInterval  time.Duration
+	gcMin       time.Duration // Bounds of the adaptive GC, zero when off
+	gcMax       time.Duration
+}
+
+// NewShardedCache ... Create a ShardedCache of n shards And goRoutine,
+// AutoShards picks n from GOMAXPROCS
+// MaxEntries, MaxBytes, InitialCapacity and BloomKeys of opts are split evenly between the shards
+func NewShardedCache(n int, opts Options) *ShardedCache {
+	if n == AutoShards {
+		n = autoShards(runtime.GOMAXPROCS(0))
+	}
+	if n <= 0 {
+		n = DefaultShards
+	}
+	if opts.GcInterval <= 0 {
+		opts.GcInterval = DefaultGcInterval
+	}
+	sc := &ShardedCache{
+		opts:        opts,
+		stopGc:      make(chan bool),
+		gcReset:     make(chan time.Duration, 1),
+		persistFile: opts.PersistFile,
+		snapshot:    newSnapshotConfig(opts),
+		hasher:      opts.ShardHasher,
+		gcInterval:  opts.GcInterval,
+		gcMin:       opts.AdaptiveGcMin,
+		gcMax:       opts.AdaptiveGcMax,
+	}
+	if sc.hasher == nil {
+		sc.hasher = FNVHasher
+	}
+	shards := newShards(n, shardOptions(opts, n), new(atomic.Uint64), nil)
+	sc.shards.Store(&shards)
+	clock := opts.Clock
+	if clock == nil {
+		clock = SystemClock
+	}
+	if sc.snapshot != nil {
+		if err := sc.snapshot.restore(sc.LoadFile); err != nil && opts.Logger != nil {
+			opts.Logger.Warn("snapshot restore failed", "file", sc.snapshot.file, "err", err)
+		}
+		go sc.snapshot.run(clock, sc.stopGc, sc.SaveToFile, opts.Logger)
+	}
+	if opts.Invalidator != nil {
+		sc.invalidator = opts.Invalidator
+		sc.instanceID = newInstanceID()
+		sc.unsubscribe = subscribe(sc.invalidator, sc.instanceID, func(k string) {
+			c := sc.shardOf(k).lockFor(k)
+			c.delete(k)
+			c.unlock()
+		}, func() {
+			for _, c := range sc.shardSet() {
+				c.mutex.Lock()
+				c.flush()
+				c.unlock()
+			}
+		})
+	}
+	if opts.AppendLog != "" {
+		for i, c := range shards {
+			file := fmt.Sprintf("%s.%d", opts.AppendLog, i)
+			if c.aofErr = openAppendLog(c, file); c.aofErr != nil {
+				c.warn("append log open failed", "file", file, "err", c.aofErr)
+			}
+		}
+		go runAppendLogs(clock, opts.LogSyncInterval, opts.LogCompactInterval, sc.stopGc, shards)
+	}
+	sc.gc.run = func(stop <-chan struct{}) {
+		var log sweepLog
+		runGc(clock, sc.GcInterval(), stop, sc.gcReset, func(interval time.Duration) time.Duration {
+			return sc.gcTick(&log, interval)
+		})
+	}
+	if opts.AutoStopGC {
+		sc.gc.run = weakGcLoop(sc, clock, sc.gcReset, (*ShardedCache).GcInterval, (*ShardedCache).gcTick)
+	}
+	if !opts.NoGC {
+		sc.gc.start()
+	}
+	return sc
+}
+
+// gcTick ... Sweep every shard once and Return the interval to the
+// next sweep
+func (sc *ShardedCache) gcTick(log *sweepLog, interval time.Duration) time.Duration {
+	shards := sc.shardSet()
+	clock := shards[0].clock
+	start := clock.Now()
+	var removed, scanned int
+	var evictions uint64
+	for _, c := range shards {
+		r, s := c.sweep()
+		removed += r
+		scanned += s
+		evictions += c.stats.evictions.Load()
+	}
+	log.observe(shards[0].logger, removed, scanned, sc.Count(), evictions, clock.Now().Sub(start))
+	sc.reshardTick()
+	return sc.nextGcInterval(interval, removed, scanned)
+}
+
+// shardOptions ... The Options of each of n shards made from opts
+func shardOptions(opts Options, n int) Options {
+	opts.Invalidator = nil
+	if opts.MaxEntries > 0 {
+		opts.MaxEntries = (opts.MaxEntries + n - 1) / n
+	}
+	if opts.MaxBytes > 0 {
+		opts.MaxBytes = (opts.MaxBytes + int64(n) - 1) / int64(n)
+	}
+	if opts.InitialCapacity > 0 {
+		opts.InitialCapacity = (opts.InitialCapacity + n - 1) / n
+	}
+	if opts.BloomKeys > 0 {
+		opts.BloomKeys = (opts.BloomKeys + n - 1) / n
+	}
+	if opts.ReshardWait > 0 {
+		opts.LockWaitStats = true
+	}
+	return opts
+}
+
+// newShards ... Make n shards from opts sharing versions and, unless
+// nil, the circuit breaker
+func newShards(n int, opts Options, versions *atomic.Uint64, breaker *breaker) []*Cache {
+	shards := make([]*Cache, n)
+	for i := range shards {
+		shards[i] = newCache(opts)
+		shards[i].versions = versions
+		if breaker == nil {
+			breaker = shards[0].breaker
+		}
+		shards[i].breaker = breaker
+	}
+	return shards
+}
+
+// shardSet ... The current shards, a Reshard swaps them for new ones
+func (sc *ShardedCache) shardSet() []*Cache {
+	return *sc.shards.Load()
+}
+
+// shard ... Pick the shard of k by the hash of its canonical form
+func (sc *ShardedCache) shard(k string) *Cache {
+	return sc.shardOf(sc.key(k))
+}
+
+// shardOf ... The shard of k, in canonical form already
+func (sc *ShardedCache) shardOf(k string) *Cache {
+	return shardIn(sc.shardSet(), sc.hasher, k)
+}
+
+// shardIn ... The shard of k among shards
+func shardIn(shards []*Cache, hasher ShardHasher, k string) *Cache {
+	return shards[hasher.HashString(k)%uint64(len(shards))]
+}
+
+// Set ... To Set the Data
+func (sc *ShardedCache) Set(k string, v interface{}, d time.Duration, opts ...SetOption) {
+	sc.shard(k).Set(k, v, d, opts...)
+}
+
+// Get ... To Get the Data
+func (sc *ShardedCache) Get(k string) (interface{}, bool) {
+	return sc.shard(k).Get(k)
+}
+
+// GetString ... Get the Data if it is a string
+func (sc *ShardedCache) GetString(k string) (string, bool) {
+	return sc.shard(k).GetString(k)
+}
+
+// GetInt64 ... Get the Data if it is an int64
+func (sc *ShardedCache) GetInt64(k string) (int64, bool) {
+	return sc.shard(k).GetInt64(k)
+}
+
+// GetBytes ... Get the Data if it is a []byte
+func (sc *ShardedCache) GetBytes(k string) ([]byte, bool) {
+	return sc.shard(k).GetBytes(k)
+}
+
+// GetWithExpiration ... Get the Data and the time it Expires
+func (sc *ShardedCache) GetWithExpiration(k string) (interface{}, time.Time, bool) {
+	return sc.shard(k).GetWithExpiration(k)
+}
+
+// SetSliding ... Set Data that Expires d after the last Get or Set
+func (sc *ShardedCache) SetSliding(k string, v interface{}, d time.Duration) {
+	sc.shard(k).SetSliding(k, v, d)
+}
+
+// SetWithTags ... Set the Data and file it under tags for InvalidateTag
+func (sc *ShardedCache) SetWithTags(k string, v interface{}, d time.Duration, tags ...string) {
+	sc.shard(k).SetWithTags(k, v, d, tags...)
+}
+
+// SetWithOnExpired ... Set the Data and call f when it Expires
+func (sc *ShardedCache) SetWithOnExpired(k string, v interface{}, d time.Duration, f func(string, interface{})) {
+	sc.shard(k).SetWithOnExpired(k, v, d, f)
+}
+
+// ListPush ... Append values to the List at k, Return its length
+func (sc *ShardedCache) ListPush(k string, values ...interface{}) (int, error) {
+	return sc.shard(k).ListPush(k, values...)
+}
+
+// ListPop ... Remove and Return the last value of the List at k
+func (sc *ShardedCache) ListPop(k string) (interface{}, error) {
+	return sc.shard(k).ListPop(k)
+}
+
+// ListPopFront ... Remove and Return the first value of the List at k
+func (sc *ShardedCache) ListPopFront(k string) (interface{}, error) {
+	return sc.shard(k).ListPopFront(k)
+}
+
+// ListRange ... Return a copy of the List at k
+func (sc *ShardedCache) ListRange(k string) ([]interface{}, error) {
+	return sc.shard(k).ListRange(k)
+}
+
+// SetAdd ... Add members to the Set at k, Return how many were New
+func (sc *ShardedCache) SetAdd(k string, members ...string) (int, error) {
+	return sc.shard(k).SetAdd(k, members...)
+}
+
+// SetRemove ... Remove members from the Set at k, Return how many were in it
+func (sc *ShardedCache) SetRemove(k string, members ...string) (int, error) {
+	return sc.shard(k).SetRemove(k, members...)
+}
+
+// SetIsMember ... Report whether m is in the Set at k
+func (sc *ShardedCache) SetIsMember(k, m string) (bool, error) {
+	return sc.shard(k).SetIsMember(k, m)
+}
+
+// SetMembers ... Return the members of the Set at k, sorted
+func (sc *ShardedCache) SetMembers(k string) ([]string, error) {
+	return sc.shard(k).SetMembers(k)
+}
+
+// HSet ... Set field of the Hash at k to v, Return whether field is New
+func (sc *ShardedCache) HSet(k, field string, v interface{}) (bool, error) {
+	return sc.shard(k).HSet(k, field, v)
+}
+
+// HGet ... Get field of the Hash at k
+func (sc *ShardedCache) HGet(k, field string) (interface{}, bool, error) {
+	return sc.shard(k).HGet(k, field)
+}
+
+// HDel ... Delete fields of the Hash at k, Return how many it had
+func (sc *ShardedCache) HDel(k string, fields ...string) (int, error) {
+	return sc.shard(k).HDel(k, fields...)
+}
+
+// HGetAll ... Return a copy of the Hash at k
+func (sc *ShardedCache) HGetAll(k string) (map[string]interface{}, error) {
+	return sc.shard(k).HGetAll(k)
+}
+
+// GetItem ... Get the live Item at k without counting as an access
+func (sc *ShardedCache) GetItem(k string) (Item, bool) {
+	return sc.shard(k).GetItem(k)
+}
+
+// SetWithCopyOnRead ... Set Data that every read copies
+func (sc *ShardedCache) SetWithCopyOnRead(k string, v interface{}, d time.Duration) {
+	sc.shard(k).SetWithCopyOnRead(k, v, d)
+}
+
+// SetWithSoftTTL ... Set the Data with a soft and a hard Expiration
+func (sc *ShardedCache) SetWithSoftTTL(k string, v interface{}, soft, hard time.Duration) {
+	sc.shard(k).SetWithSoftTTL(k, v, soft, hard)
+}
+
+// DeletePrefix ... Delete all Data whose key starts with prefix in
+// every shard, Return how many
+func (sc *ShardedCache) DeletePrefix(prefix string) int {
+	n := 0
+	for _, c := range sc.shardSet() {
+		n += c.DeletePrefix(prefix)
+	}
+	return n
+}
+
+// InvalidateTag ... Delete all Data filed under tag in every shard
+func (sc *ShardedCache) InvalidateTag(tag string) int {
+	n := 0
+	for _, c := range sc.shardSet() {
+		n += c.InvalidateTag(tag)
+	}
+	return n
+}
+
+// SetNegative ... Cache k as known missing for d
+func (sc *ShardedCache) SetNegative(k string, d time.Duration) {
+	sc.shard(k).SetNegative(k, d)
+}
+
+// IsNegative ... Report whether k is cached as known missing
+func (sc *ShardedCache) IsNegative(k string) bool {
+	return sc.shard(k).IsNegative(k)
+}
+
+// Add ... Add Data if it did not Exist yet
+func (sc *ShardedCache) Add(k string, v interface{}, d time.Duration) error {
+	return sc.shard(k).Add(k, v, d)
+}
+
+// Replace ... Set Data only if it Exists already
+func (sc *ShardedCache) Replace(k string, v interface{}, d time.Duration) error {
+	return sc.shard(k).Replace(k, v, d)
+}
+
+// Increment ... Add n to the number stored at k
+func (sc *ShardedCache) Increment(k string, n int64) (interface{}, error) {
+	return sc.shard(k).Increment(k, n)
+}
+
+// Decrement ... Subtract n from the number stored at k
+func (sc *ShardedCache) Decrement(k string, n int64) (interface{}, error) {
+	return sc.shard(k).Decrement(k, n)
+}
+
+// Touch ... Reset the Expiration of existing Data to d from now
+func (sc *ShardedCache) Touch(k string, d time.Duration) bool {
+	return sc.shard(k).Touch(k, d)
+}
+
+// CompareAndSwap ... Set new only if the live Data at k equals old
+func (sc *ShardedCache) CompareAndSwap(k string, old, new interface{}, d time.Duration) bool {
+	return sc.shard(k).CompareAndSwap(k, old, new, d)
+}
+
+// LoadOrStore ... Return the live Data at k, or Set v and Return it
+func (sc *ShardedCache) LoadOrStore(k string, v interface{}, d time.Duration) (interface{}, bool, error) {
+	return sc.shard(k).LoadOrStore(k, v, d)
+}
+
+// CompareAndDelete ... Delete the Data at k only if it equals old
+func (sc *ShardedCache) CompareAndDelete(k string, old interface{}) bool {
+	return sc.shard(k).CompareAndDelete(k, old)
+}
+
+// LockKey ... Lock the mutex of k and Return the func unlocking it
+func (sc *ShardedCache) LockKey(k string) func() {
+	return sc.shard(k).LockKey(k)
+}
+
+// WithKeyLock ... Call fn holding the lock of k
+func (sc *ShardedCache) WithKeyLock(k string, fn func()) {
+	sc.shard(k).WithKeyLock(k, fn)
+}
+
+// Delete ... Delete the Data
+func (sc *ShardedCache) Delete(k string) {
+	sc.shard(k).Delete(k)
+	publish(sc.getInvalidator(), sc.instanceID, Invalidation{Key: k})
+}
+
+func (sc *ShardedCache) getInvalidator() Invalidator {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	return sc.invalidator
+}
+
+// Pop ... Get the Data and Delete it in one step
+func (sc *ShardedCache) Pop(k string) (interface{}, bool) {
+	v, found := sc.shard(k).Pop(k)
+	if found {
+		publish(sc.getInvalidator(), sc.instanceID, Invalidation{Key: k})
+	}
+	return v, found
+}
+
+// GetMulti ... Get the live Data of keys, locking each shard once
+func (sc *ShardedCache) GetMulti(keys []string) map[string]interface{} {
+	res := make(map[string]interface{}, len(keys))
+	for c, part := range sc.splitKeys(keys) {
+		for k, v := range c.GetMulti(part) {
+			res[k] = v
+		}
+	}
+	return res
+}
+
+// SetMulti ... Set all items with Expiration d, locking each shard once
+func (sc *ShardedCache) SetMulti(items map[string]interface{}, d time.Duration) {
+	parts := map[*Cache]map[string]interface{}{}
+	for k, v := range items {
+		c := sc.shard(k)
+		if parts[c] == nil {
+			parts[c] = map[string]interface{}{}
+		}
+		parts[c][k] = v
+	}
+	for c, part := range parts {
+		c.SetMulti(part, d)
+	}
+}
+
+// DeleteMulti ... Delete all keys, locking each shard once
+func (sc *ShardedCache) DeleteMulti(keys []string) {
+	for c, part := range sc.splitKeys(keys) {
+		c.DeleteMulti(part)
+	}
+	inv := sc.getInvalidator()
+	for _, k := range keys {
+		publish(inv, sc.instanceID, Invalidation{Key: k})
+	}
+}
+
+// splitKeys ... Group keys by their shard
+func (sc *ShardedCache) splitKeys(keys []string) map[*Cache][]string {
+	parts := map[*Cache][]string{}
+	for _, k := range keys {
+		c := sc.shard(k)
+		parts[c] = append(parts[c], k)
+	}
+	return parts
+}
+
+// DeleteExpired ... Sweep the shards one at a time, so only one
+// shard is locked at any moment
+func (sc *ShardedCache) DeleteExpired() {
+	for _, c := range sc.shardSet() {
+		c.DeleteExpired()
+	}
+}
+
+// Count ... Return Number of Data In all shards
+func (sc *ShardedCache) Count() int {
+	n := 0
+	for _, c := range sc.shardSet() {
+		n += c.Count()
+	}
+	return n
+}
+
+// CountValid ... Return Number of live Data In all shards
+func (sc *ShardedCache) CountValid() int {
+	n := 0
+	for _, c := range sc.shardSet() {
+		n += c.CountValid()
+	}
+	return n
+}
+
+// Items ... Return a copy of the live Data In all shards
+func (sc *ShardedCache) Items() map[string]Item {
+	items := map[string]Item{}
+	for _, c := range sc.shardSet() {
+		for k, v := range c.Items() {
+			items[k] = v
+		}
+	}
+	return items
+}
+
+// Range ... Call f for every live Data until it returns false,
+// one shard snapshot at a time
+func (sc *ShardedCache) Range(f func(k string, v interface{}) bool) {
+	for _, c := range sc.shardSet() {
+		for k, item := range c.Items() {
+			if !f(k, item.Object) {
+				return
+			}
+		}
+	}
+}
+
+// Keys ... Return the sorted keys of the live Data In all shards
+func (sc *ShardedCache) Keys() []string {
+	return sc.mergeKeys(func(c *Cache) []string { return c.Keys() })
+}
+
+// KeysWithPrefix ... Return the sorted live keys starting with prefix
+func (sc *ShardedCache) KeysWithPrefix(prefix string) []string {
+	return sc.mergeKeys(func(c *Cache) []string { return c.KeysWithPrefix(prefix) })
+}
+
+// KeysMatching ... Return the sorted live keys matching the glob pattern
+func (sc *ShardedCache) KeysMatching(pattern string) ([]string, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+	return sc.mergeKeys(func(c *Cache) []string {
+		keys, _ := c.KeysMatching(pattern)
+		return keys
+	}), nil
+}
+
+func (sc *ShardedCache) mergeKeys(f func(*Cache) []string) []string {
+	var keys []string
+	for _, c := range sc.shardSet() {
+		keys = append(keys, f(c)...)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Stats ... Return the counters of all shards added up
+func (sc *ShardedCache) Stats() Stats {
+	var s Stats
+	for _, c := range sc.shardSet() {
+		cs := c.Stats()
+		s.Hits += cs.Hits
+		s.Misses += cs.Misses
+		s.Sets += cs.Sets
+		s.Expired += cs.Expired
+		s.Evictions += cs.Evictions
+		s.Rejected += cs.Rejected
+		s.Items += cs.Items
+		s.Bytes += cs.Bytes
+		s.GcSweeps += cs.GcSweeps
+		s.GcRemoved += cs.GcRemoved
+		s.TotalGcTime += cs.TotalGcTime
+		if cs.LastGcDuration > s.LastGcDuration {
+			s.LastGcDuration = cs.LastGcDuration
+		}
+		s.Last1m = s.Last1m.add(cs.Last1m)
+		s.Last5m = s.Last5m.add(cs.Last5m)
+		s.Last1h = s.Last1h.add(cs.Last1h)
+	}
+	s.GcInterval = sc.GcInterval()
+	return s
+}
+
+// Flush ... Flush every shard
+func (sc *ShardedCache) Flush() {
+	for _, c := range sc.shardSet() {
+		c.Flush()
+	}
+	publish(sc.getInvalidator(), sc.instanceID, Invalidation{Flush: true})
+}
+
+// Save ... Write all shards In WriteIO, in the same format as Cache.Save
+// with the same Codec
+// Shards are copied one at a time and encoded after, so no lock is held
+// while writing
+func (sc *ShardedCache) Save(w io.Writer) error {
+	items := map[string]Item{}
+	for _, c := range sc.shardSet() {
+		for k, v := range c.copyItems() {
+			items[k] = v
+		}
+	}
+	return encodeSnapshot(w, sc.shardSet()[0].codec, items)
+}
+
+// SaveToFile ... Save to file, replacing it atomically like Cache.SaveToFile
+func (sc *ShardedCache) SaveToFile(file string) error {
+	c := sc.shardSet()[0]
+	return writeFileAtomic(file, false, sealFile(c.compress, c.snapshotKey, sc.Save))
+}
+
+// Load ... Load Data written by Save or Cache.Save into the shards,
+// keeping the live Data they hold
+func (sc *ShardedCache) Load(r io.Reader) error {
+	return sc.LoadWithPolicy(r, KeepExisting)
+}
+
+// LoadWithPolicy ... Load Data written by Save or Cache.Save into the
+// shards, settling keys they already hold by policy
+func (sc *ShardedCache) LoadWithPolicy(r io.Reader, policy LoadPolicy) error {
+	sc.loading.Add(1)
+	defer sc.loading.Add(-1)
+	return decodeSnapshot(r, sc.shardSet()[0].codec, func(items map[string]Item) {
+		sc.mergeBatches(items, policy)
+	})
+}
+
+// mergeBatches ... mergeBatches of items in the shard of each key
+func (sc *ShardedCache) mergeBatches(items map[string]Item, policy LoadPolicy) {
+	parts := make(map[*Cache]map[string]Item, len(sc.shardSet()))
+	for k, v := range items {
+		c := sc.shard(k)
+		if parts[c] == nil {
+			parts[c] = map[string]Item{}
+		}
+		parts[c][k] = v
+	}
+	for c, part := range parts {
+		c.mergeBatches(part, policy)
+	}
+}
+
+// IsLoading ... Report whether a Load runs, for readiness checks
+func (sc *ShardedCache) IsLoading() bool {
+	return sc.loading.Load() > 0
+}
+
+// LoadFile ... Load from file like Cache.LoadFile
+func (sc *ShardedCache) LoadFile(file string) error {
+	return readFile(file, openFile(sc.shardSet()[0].snapshotKey, sc.Load))
+}
+
+// StopGc ... Stop the GC goRoutine and, for good, those taking
+// snapshots and syncing the append logs; later calls do nothing
+func (sc *ShardedCache) StopGc() {
+	sc.gc.stop()
+	sc.stopOnce.Do(func() { close(sc.stopGc) })
+}
+
+// Close ... Stop the GC and Invalidations, save to Options.PersistFile if set, take
+// a last snapshot if WithSnapshot is used, close the append logs and Flush
+// every shard so OnEvicted sees all Data, later calls do nothing
+func (sc *ShardedCache) Close() error {
+	sc.closeOnce.Do(func() {
+		sc.StopGc()
+		sc.mutex.Lock()
+		cancel := sc.unsubscribe
+		sc.invalidator, sc.unsubscribe = nil, nil
+		sc.mutex.Unlock()
+		if cancel != nil {
+			cancel()
+		}
+		if sc.persistFile != "" {
+			sc.closeErr = sc.SaveToFile(sc.persistFile)
+		}
+		if sc.snapshot != nil {
+			if err := sc.snapshot.take(sc.SaveToFile); sc.closeErr == nil {
+				sc.closeErr = err
+			}
+		}
+		shards := sc.shardSet()
+		for _, c := range shards {
+			if err := c.closeLog(); sc.closeErr == nil {
+				sc.closeErr = err
+			}
+		}
+		sc.closeExpiredEvents()
+		for _, c := range shards {
+			c.Flush()
+		}
+	})
+	return sc.closeErr
+}
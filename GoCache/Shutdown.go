@@ -0,0 +1,80 @@
+package GoCache
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// PersistOnShutdown ... Save the Cache to file when the process gets
+// SIGINT or SIGTERM, then let the signal end the process as it would
+// have, for warm restarts with LoadFile. stop undoes it
+// A service that handles these signals itself should call
+// PersistWhenDone from its own shutdown instead
+func (c *Cache) PersistOnShutdown(file string) (stop func()) {
+	return persistOnSignal(file, c.SaveToFile, c.logger)
+}
+
+// PersistWhenDone ... Save the Cache to file once ctx is done, the
+// returned channel gets the result of the save and is closed
+func (c *Cache) PersistWhenDone(ctx context.Context, file string) <-chan error {
+	return persistWhenDone(ctx, file, c.SaveToFile)
+}
+
+// PersistOnShutdown ... Save all shards to file on SIGINT or SIGTERM,
+// like Cache.PersistOnShutdown
+func (sc *ShardedCache) PersistOnShutdown(file string) (stop func()) {
+	return persistOnSignal(file, sc.SaveToFile, sc.shardSet()[0].logger)
+}
+
+// PersistWhenDone ... Save all shards to file once ctx is done, like
+// Cache.PersistWhenDone
+func (sc *ShardedCache) PersistWhenDone(ctx context.Context, file string) <-chan error {
+	return persistWhenDone(ctx, file, sc.SaveToFile)
+}
+
+func persistWhenDone(ctx context.Context, file string, save func(string) error) <-chan error {
+	res := make(chan error, 1)
+	go func() {
+		<-ctx.Done()
+		res <- save(file)
+		close(res)
+	}()
+	return res
+}
+
+// persistOnSignal ... Save on the first shutdown signal, then stop
+// listening and send it again, so the default action, or the handlers
+// of the service, see it
+func persistOnSignal(file string, save func(string) error, log Logger) (stop func()) {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	done := make(chan struct{})
+	var once sync.Once
+	stop = func() {
+		once.Do(func() {
+			signal.Stop(sigs)
+			close(done)
+		})
+	}
+	go func() {
+		select {
+		case sig := <-sigs:
+			if err := save(file); err != nil && log != nil {
+				log.Warn("snapshot on shutdown failed", "file", file, "err", err)
+			}
+			stop()
+			p, err := os.FindProcess(os.Getpid())
+			if err == nil {
+				err = p.Signal(sig)
+			}
+			if err != nil {
+				os.Exit(1)
+			}
+		case <-done:
+		}
+	}()
+	return stop
+}
@@ -0,0 +1,102 @@
+package GoCache
+
+import (
+	"reflect"
+	"time"
+)
+
+// Size charged for Data whose type approxSize does not know
+const defaultItemSize = 64
+
+// SetWithSize ... Set the Data and count it as size bytes against MaxBytes
+func (c *Cache) SetWithSize(k string, v interface{}, d time.Duration, size int64) {
+	k = c.key(k)
+	c = c.lockFor(k)
+	defer c.unlock()
+	c.setSized(k, v, d, size)
+}
+
+// ItemSizer ... Reports the weight of Data, in whatever unit MaxBytes
+// is then given in, so eviction weighs Data instead of guessing bytes
+type ItemSizer interface {
+	SizeOf(k string, v interface{}) int64
+}
+
+// ItemSizerFunc ... Lets a plain func be an ItemSizer
+type ItemSizerFunc func(k string, v interface{}) int64
+
+// SizeOf ... Return f(k, v)
+func (f ItemSizerFunc) SizeOf(k string, v interface{}) int64 {
+	return f(k, v)
+}
+
+// WithSizer ... Weigh Data with sizer instead of approxSize, for the
+// Data not Set with SetWithSize
+func WithSizer(sizer ItemSizer) Option {
+	return func(o *Options) { o.Sizer = sizer }
+}
+
+// sizeOf ... Return the weight of v at k
+func (c *Cache) sizeOf(k string, v interface{}) int64 {
+	if c.sizer != nil {
+		return c.sizer.SizeOf(k, v)
+	}
+	return approxSize(v)
+}
+
+// Bytes ... Return the approximate size of all Data In Cache
+func (c *Cache) Bytes() int64 {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.totalBytes
+}
+
+// approxSize ... Guess the bytes held by v for common types
+func approxSize(v interface{}) int64 {
+	switch x := v.(type) {
+	case nil:
+		return 0
+	case string:
+		return int64(len(x))
+	case []byte:
+		return int64(len(x))
+	case bool, int8, uint8:
+		return 1
+	case int16, uint16:
+		return 2
+	case int32, uint32, float32:
+		return 4
+	case int, uint, int64, uint64, float64, uintptr, complex64:
+		return 8
+	case complex128:
+		return 16
+	case sealed:
+		return int64(len(x.Data))
+	case nsSealed:
+		return int64(len(x.Data))
+	case []string:
+		n := int64(0)
+		for _, s := range x {
+			n += int64(len(s))
+		}
+		return n
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		if rv.Len() == 0 {
+			return 0
+		}
+		return int64(rv.Len()) * int64(rv.Type().Elem().Size())
+	case reflect.Map:
+		return int64(rv.Len()) * int64(rv.Type().Key().Size()+rv.Type().Elem().Size())
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return 0
+		}
+		return int64(rv.Elem().Type().Size())
+	case reflect.Struct:
+		return int64(rv.Type().Size())
+	}
+	return defaultItemSize
+}
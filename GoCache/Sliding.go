@@ -0,0 +1,38 @@
+package GoCache
+
+import "time"
+
+// EnableSlidingExpiration ... Make Data Set from now on sliding:
+// every Get pushes its Expiration out by the duration it was Set with
+func (c *Cache) EnableSlidingExpiration(enable bool) {
+	c.mutex.Lock()
+	defer c.unlock()
+	c.slidingByDefault = enable
+}
+
+// SetSliding ... Set Data that Expires d after the last Get or Set
+func (c *Cache) SetSliding(k string, v interface{}, d time.Duration) {
+	k = c.key(k)
+	c = c.lockFor(k)
+	defer c.unlock()
+	item := Item{
+		Object:     v,
+		Expiration: c.expiration(d),
+	}
+	if item.Expiration > 0 {
+		item.Sliding = c.resolve(d)
+	}
+	c.put(k, item)
+}
+
+// slide ... Push the Expiration of sliding Data at k out from now
+func (c *Cache) slide(k string) {
+	c = c.lockFor(k)
+	defer c.unlock()
+	item, found := c.items[k]
+	if !found || item.Sliding <= 0 || c.expired(item) {
+		return
+	}
+	item.Expiration = c.now().Add(item.Sliding).UnixNano()
+	c.update(k, item)
+}
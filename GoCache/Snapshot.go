@@ -0,0 +1,96 @@
+package GoCache
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// snapshotConfig ... Where and how often the Cache is saved by WithSnapshot
+type snapshotConfig struct {
+	file     string
+	interval time.Duration
+	keep     int // Snapshot files kept, the newest is file, older ones file.1, file.2...
+}
+
+// WithSnapshot ... Save the Cache to file every interval and Load it
+// from there when the Cache is made, so a restart starts warm
+// Close takes a last snapshot
+func WithSnapshot(file string, interval time.Duration) Option {
+	return func(o *Options) {
+		o.SnapshotFile = file
+		o.SnapshotInterval = interval
+	}
+}
+
+// WithSnapshotKeep ... Keep the n newest snapshots, the older ones
+// are named file.1, file.2 and so on, one by default
+func WithSnapshotKeep(n int) Option {
+	return func(o *Options) { o.SnapshotKeep = n }
+}
+
+// newSnapshotConfig ... Return nil if opts asks for no snapshots
+func newSnapshotConfig(opts Options) *snapshotConfig {
+	if opts.SnapshotFile == "" {
+		return nil
+	}
+	s := &snapshotConfig{file: opts.SnapshotFile, interval: opts.SnapshotInterval, keep: opts.SnapshotKeep}
+	if s.keep < 1 {
+		s.keep = 1
+	}
+	return s
+}
+
+// name ... Return the name of the i-th newest snapshot
+func (s *snapshotConfig) name(i int) string {
+	if i == 0 {
+		return s.file
+	}
+	return fmt.Sprintf("%s.%d", s.file, i)
+}
+
+// take ... Shift the older snapshots down one name, dropping the
+// oldest, and let save write the newest
+func (s *snapshotConfig) take(save func(string) error) error {
+	for i := s.keep - 1; i > 0; i-- {
+		if err := os.Rename(s.name(i-1), s.name(i)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return save(s.file)
+}
+
+// restore ... Let load read the newest snapshot it can, a missing or
+// broken one falls back to the one before it
+func (s *snapshotConfig) restore(load func(string) error) error {
+	var err error
+	for i := 0; i < s.keep; i++ {
+		if err = load(s.name(i)); err == nil {
+			return nil
+		}
+	}
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// run ... Take a snapshot every interval until stop is closed, warning
+// log of the ones that fail
+func (s *snapshotConfig) run(clock Clock, stop chan bool, save func(string) error, log Logger) {
+	if s.interval <= 0 {
+		return
+	}
+	ticker := clock.NewTicker(s.interval)
+	for {
+		select {
+		case <-ticker.C():
+			if err := s.take(save); err != nil && log != nil {
+				log.Warn("snapshot failed", "file", s.file, "err", err)
+			}
+		case <-stop:
+			ticker.Stop()
+			return
+		}
+	}
+}
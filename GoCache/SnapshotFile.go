@@ -0,0 +1,150 @@
+package GoCache
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Files SaveToFile writes start with snapshotMagic, a format version and
+// flags, and end with the SHA-256 of all that comes before, so LoadFile
+// rejects a damaged file instead of loading garbage
+// Encrypted files hold a nonce and the AES-GCM sealed payload, which
+// also authenticates the header
+const (
+	snapshotMagic      = "GCSF"
+	snapshotVersion    = 1
+	snapshotHeaderSize = len(snapshotMagic) + 2
+
+	flagGzip      = 1 << 0
+	flagEncrypted = 1 << 1
+)
+
+var (
+	// ErrCorruptSnapshot ... The file does not match its checksum, or
+	// does not decrypt under the key
+	ErrCorruptSnapshot = errors.New("snapshot file is corrupt or was tampered with")
+	// ErrSnapshotKey ... The file is encrypted and no key was given
+	ErrSnapshotKey = errors.New("snapshot file is encrypted, see WithSnapshotEncryption")
+)
+
+// WithSnapshotEncryption ... Encrypt the files SaveToFile writes with
+// AES-GCM under key, 16, 24 or 32 bytes long, which LoadFile then needs
+func WithSnapshotEncryption(key []byte) Option {
+	return func(o *Options) { o.SnapshotKey = key }
+}
+
+// sealFile ... Return a save func writing what save writes in the file
+// format, gzipped if compress is set and encrypted if key is not nil
+func sealFile(compress bool, key []byte, save func(io.Writer) error) func(io.Writer) error {
+	return func(w io.Writer) error {
+		var payload bytes.Buffer
+		var flags byte
+		if compress {
+			flags |= flagGzip
+			zw := gzip.NewWriter(&payload)
+			if err := save(zw); err != nil {
+				return err
+			}
+			if err := zw.Close(); err != nil {
+				return err
+			}
+		} else if err := save(&payload); err != nil {
+			return err
+		}
+		if key != nil {
+			flags |= flagEncrypted
+		}
+		header := append([]byte(snapshotMagic), snapshotVersion, flags)
+		body := payload.Bytes()
+		if key != nil {
+			aead, err := newAEAD(key)
+			if err != nil {
+				return err
+			}
+			nonce := make([]byte, aead.NonceSize())
+			if _, err := rand.Read(nonce); err != nil {
+				return err
+			}
+			body = aead.Seal(nonce, nonce, body, header)
+		}
+		sum := sha256.New()
+		for _, b := range [][]byte{header, body} {
+			if _, err := w.Write(b); err != nil {
+				return err
+			}
+			sum.Write(b)
+		}
+		_, err := w.Write(sum.Sum(nil))
+		return err
+	}
+}
+
+// openFile ... Return a load func that checks and opens the file format
+// before passing the payload to load. Files written before it existed
+// go to load as they are
+func openFile(key []byte, load func(io.Reader) error) func(io.Reader) error {
+	return func(r io.Reader) error {
+		br := bufio.NewReader(r)
+		if magic, _ := br.Peek(len(snapshotMagic)); string(magic) != snapshotMagic {
+			return load(br)
+		}
+		data, err := io.ReadAll(br)
+		if err != nil {
+			return err
+		}
+		if len(data) < snapshotHeaderSize+sha256.Size {
+			return ErrCorruptSnapshot
+		}
+		end := len(data) - sha256.Size
+		if sum := sha256.Sum256(data[:end]); !bytes.Equal(sum[:], data[end:]) {
+			return ErrCorruptSnapshot
+		}
+		header, body := data[:snapshotHeaderSize], data[snapshotHeaderSize:end]
+		if v := header[len(snapshotMagic)]; v != snapshotVersion {
+			return fmt.Errorf("snapshot file version %d is not supported", v)
+		}
+		flags := header[snapshotHeaderSize-1]
+		if flags&flagEncrypted != 0 {
+			if key == nil {
+				return ErrSnapshotKey
+			}
+			aead, err := newAEAD(key)
+			if err != nil {
+				return err
+			}
+			n := aead.NonceSize()
+			if len(body) < n {
+				return ErrCorruptSnapshot
+			}
+			if body, err = aead.Open(nil, body[:n], body[n:], header); err != nil {
+				return ErrCorruptSnapshot
+			}
+		}
+		var payload io.Reader = bytes.NewReader(body)
+		if flags&flagGzip != 0 {
+			zr, err := gzip.NewReader(payload)
+			if err != nil {
+				return err
+			}
+			defer zr.Close()
+			payload = zr
+		}
+		return load(payload)
+	}
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,213 @@
+package GoCache
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Save streams start with a header: streamMagic, the format version as
+// a uint16, the name of the Codec as a byte length and the bytes, and
+// the number of items as a uint64, all big endian. Load reads streams
+// with or without it, decoding with the Codec the header names when it
+// is registered. Versions up to streamVersion load; a change to the
+// Item layout that gob cannot absorb gets a New version and a migration
+// here
+// In version 1 the items follow as one value of the Codec, in version 2,
+// written by SaveStream, as one record per item: its uvarint length and
+// the Codec encoding of a map holding just that item
+const (
+	streamMagic   = "GCSN"
+	streamVersion = 2
+	mapVersion    = 1
+	recordVersion = 2
+)
+
+// loadBatch ... Records of a version 2 stream merged under one lock
+const loadBatch = 1024
+
+// ErrIncompatibleSnapshot ... The snapshot was written in a format
+// version this build does not know
+var ErrIncompatibleSnapshot = errors.New("snapshot format version is not supported")
+
+// NamedCodec ... A Codec whose name goes into the snapshot header, so
+// Load can tell which one wrote it. The built in Codecs are named
+type NamedCodec interface {
+	Codec
+	Name() string
+}
+
+func (gobCodec) Name() string  { return "gob" }
+func (jsonCodec) Name() string { return "json" }
+
+// codecsByName ... Codecs Load can pick by the name in a header
+var (
+	codecsMutex  sync.RWMutex
+	codecsByName = map[string]Codec{
+		"gob":        GobCodec,
+		"json":       JSONCodec,
+		"gob-sorted": sortedGobCodec{},
+		"msgpack":    MsgpackCodec,
+		"protobuf":   ProtobufCodec,
+	}
+)
+
+// RegisterCodec ... Let Load read snapshots written by codec whatever
+// the Codec of the Cache, replacing any registered under its name
+func RegisterCodec(codec NamedCodec) {
+	codecsMutex.Lock()
+	defer codecsMutex.Unlock()
+	codecsByName[codec.Name()] = codec
+}
+
+// LookupCodec ... The Codec registered as name, to pick one from a
+// config or flag: WithCodec of it
+func LookupCodec(name string) (Codec, bool) {
+	codecsMutex.RLock()
+	defer codecsMutex.RUnlock()
+	codec, ok := codecsByName[name]
+	return codec, ok
+}
+
+// encodeSnapshot ... Write the header and items encoded by codec
+func encodeSnapshot(w io.Writer, codec Codec, items map[string]Item) error {
+	if err := writeStreamHeader(w, codec, mapVersion, len(items)); err != nil {
+		return err
+	}
+	return codec.Encode(w, items)
+}
+
+// encodeRecords ... Write the header and one record per item of parts
+func encodeRecords(w io.Writer, codec Codec, parts ...map[string]Item) error {
+	n := 0
+	for _, items := range parts {
+		n += len(items)
+	}
+	if err := writeStreamHeader(w, codec, recordVersion, n); err != nil {
+		return err
+	}
+	var rec bytes.Buffer
+	var size [binary.MaxVarintLen64]byte
+	for _, items := range parts {
+		for k, v := range items {
+			rec.Reset()
+			if err := codec.Encode(&rec, map[string]Item{k: v}); err != nil {
+				return err
+			}
+			if _, err := w.Write(size[:binary.PutUvarint(size[:], uint64(rec.Len()))]); err != nil {
+				return err
+			}
+			if _, err := w.Write(rec.Bytes()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func writeStreamHeader(w io.Writer, codec Codec, version uint16, count int) error {
+	name := ""
+	if nc, ok := codec.(NamedCodec); ok {
+		name = nc.Name()
+	}
+	if len(name) > 255 {
+		return fmt.Errorf("codec name %q is too long", name)
+	}
+	header := append([]byte(streamMagic), 0, 0, byte(len(name)))
+	binary.BigEndian.PutUint16(header[len(streamMagic):], version)
+	header = append(header, name...)
+	header = binary.BigEndian.AppendUint64(header, uint64(count))
+	_, err := w.Write(header)
+	return err
+}
+
+// decodeSnapshot ... Read what encodeSnapshot or encodeRecords wrote, or
+// a bare stream of codec from before the header existed, handing the
+// items to merge: all at once, or a loadBatch of records at a time
+// A version 2 stream that breaks off has merged the batches before the
+// break
+func decodeSnapshot(r io.Reader, codec Codec, merge func(map[string]Item)) error {
+	return decodeSnapshotInfo(r, codec, merge, nil)
+}
+
+// decodeSnapshotInfo ... decodeSnapshot, filling in the header fields of
+// info unless it is nil
+func decodeSnapshotInfo(r io.Reader, codec Codec, merge func(map[string]Item), info *SnapshotInfo) error {
+	br := bufio.NewReader(r)
+	if magic, _ := br.Peek(len(streamMagic)); string(magic) != streamMagic {
+		items, err := codec.Decode(br)
+		if err == nil {
+			merge(items)
+		}
+		return err
+	}
+	header := make([]byte, len(streamMagic)+3)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return err
+	}
+	version := binary.BigEndian.Uint16(header[len(streamMagic):])
+	if version == 0 || version > streamVersion {
+		return fmt.Errorf("%w: version %d", ErrIncompatibleSnapshot, version)
+	}
+	name := make([]byte, header[len(header)-1])
+	var count [8]byte
+	if _, err := io.ReadFull(br, name); err != nil {
+		return err
+	}
+	if _, err := io.ReadFull(br, count[:]); err != nil {
+		return err
+	}
+	if named, ok := LookupCodec(string(name)); ok {
+		codec = named
+	}
+	n := binary.BigEndian.Uint64(count[:])
+	if info != nil {
+		info.Version, info.Codec, info.Declared = int(version), string(name), n
+	}
+	if version == recordVersion {
+		return decodeRecords(br, codec, n, merge)
+	}
+	items, err := codec.Decode(br)
+	if err != nil {
+		return err
+	}
+	if uint64(len(items)) != n {
+		return fmt.Errorf("%w: %d items instead of %d", ErrCorruptSnapshot, len(items), n)
+	}
+	merge(items)
+	return nil
+}
+
+// decodeRecords ... Read n records, merging them a loadBatch at a time
+func decodeRecords(br *bufio.Reader, codec Codec, n uint64, merge func(map[string]Item)) error {
+	batch := map[string]Item{}
+	for i := uint64(0); i < n; i++ {
+		size, err := binary.ReadUvarint(br)
+		if err != nil {
+			return fmt.Errorf("%w: record %d of %d: %v", ErrCorruptSnapshot, i, n, err)
+		}
+		rec := io.LimitReader(br, int64(size))
+		items, err := codec.Decode(rec)
+		if err != nil {
+			return fmt.Errorf("%w: record %d of %d: %v", ErrCorruptSnapshot, i, n, err)
+		}
+		if _, err := io.Copy(io.Discard, rec); err != nil {
+			return err
+		}
+		for k, v := range items {
+			batch[k] = v
+		}
+		if len(batch) >= loadBatch {
+			merge(batch)
+			batch = map[string]Item{}
+		}
+	}
+	if len(batch) > 0 {
+		merge(batch)
+	}
+	return nil
+}
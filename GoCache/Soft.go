@@ -0,0 +1,27 @@
+package GoCache
+
+import "time"
+
+// SetWithSoftTTL ... Set the Data with a soft and a hard Expiration
+// Past soft the Data is stale: Get still serves it but starts the loader
+// of EnableRefreshAhead, and GetStale reports it stale and starts the
+// loader of EnableStaleWhileRevalidate. Past hard it is never served
+// A soft not below hard, or not positive, leaves only the hard one
+func (c *Cache) SetWithSoftTTL(k string, v interface{}, soft, hard time.Duration) {
+	k = c.key(k)
+	c = c.lockFor(k)
+	defer c.unlock()
+	item := Item{
+		Object:     v,
+		Expiration: c.expiration(hard),
+	}
+	if soft > 0 && (item.Expiration == 0 || soft < c.resolve(hard)) {
+		item.SoftExpiration = c.now().Add(soft).UnixNano()
+	}
+	c.put(k, item)
+}
+
+// softExpired ... Report whether item is past its soft Expiration
+func (c *Cache) softExpired(item Item) bool {
+	return item.SoftExpiration > 0 && c.now().UnixNano() > item.SoftExpiration
+}
@@ -0,0 +1,70 @@
+package GoCache
+
+import "time"
+
+type staleConfig struct {
+	maxStale time.Duration
+	loader   func(string) (interface{}, error)
+	ttl      time.Duration
+}
+
+// EnableStaleWhileRevalidate ... Keep Expired Data for maxStale more, so
+// GetStale can serve it while loader reloads it in a goRoutine and Sets
+// the result with Expiration d. A failed load leaves the stale Data to
+// Expire for good, nil loader turns it off
+func (c *Cache) EnableStaleWhileRevalidate(maxStale time.Duration, loader func(k string) (interface{}, error), d time.Duration) {
+	c.mutex.Lock()
+	defer c.unlock()
+	if loader == nil {
+		c.stale = nil
+		return
+	}
+	c.stale = &staleConfig{maxStale: maxStale, loader: loader, ttl: d}
+}
+
+// GetStale ... Get the Data like Get, or Expired Data still within the
+// maxStale of EnableStaleWhileRevalidate with stale set, in which case
+// a reload is started unless one is running. A stale read counts as a miss
+// Data past the soft Expiration of SetWithSoftTTL is stale too, and
+// reloaded the same way
+func (c *Cache) GetStale(k string) (v interface{}, stale bool, found bool) {
+	k = c.key(k)
+	v, found = c.getKey(k)
+	c = c.rLockFor(k)
+	item, ok := c.items[k]
+	s := c.stale
+	if found {
+		stale = ok && c.softExpired(item)
+	} else if stale = ok && s != nil && c.expired(item) && !c.pastGrace(item); stale {
+		v, found = c.open(item.Object), true
+	}
+	c.mutex.RUnlock()
+	if !stale || s == nil {
+		return v, stale, found
+	}
+	c.loadAsync(k, func() (interface{}, error) {
+		v, err := c.callLoader(k, func() (interface{}, error) { return s.loader(k) })
+		if err == nil {
+			c.setKey(k, v, s.ttl)
+		} else if !circuitOpen(err) {
+			c.warn("stale revalidation failed", "key", k, "err", err)
+		}
+		return v, err
+	})
+	return v, true, found
+}
+
+// grace ... Return how long the GC keeps Expired Data, the caller holds the lock
+func (c *Cache) grace() int64 {
+	if c.stale == nil {
+		return 0
+	}
+	return int64(c.stale.maxStale)
+}
+
+// pastGrace ... Report whether item Expired more than grace ago, the
+// caller holds the lock
+func (c *Cache) pastGrace(item Item) bool {
+	e := item.deadline()
+	return e > 0 && !c.paused.Load() && c.now().UnixNano() > e+c.grace()
+}
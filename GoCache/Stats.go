@@ -0,0 +1,94 @@
+package GoCache
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Stats ... Counters of a Cache since it was made
+type Stats struct {
+	Hits      uint64 // Reads that found live Data
+	Misses    uint64 // Reads that found nothing or Expired Data
+	Sets      uint64 // Data stored by Set, Load and friends
+	Expired   uint64 // Data Deleted because it Expired
+	Evictions uint64 // Data Deleted to stay within the limits
+	Rejected  uint64 // Data turned away by the admission policy, a failed ValueTransform or WithMaxValueSize
+	Items     int    // Data In Cache now, Expired ones included
+	Bytes     int64  // Approximate size of the Data In Cache
+
+	GcSweeps       uint64        // Runs of DeleteExpired
+	GcRemoved      uint64        // Data the sweeps removed, Expired or idle
+	LastGcDuration time.Duration // Time the last sweep held the lock
+	TotalGcTime    time.Duration // Time all sweeps held the lock
+	GcInterval     time.Duration // Interval the GC sweeps at now, see EnableAdaptiveGC
+
+	// Reads of the last minute, 5 minutes and hour, zero without
+	// WithStatsWindow
+	Last1m, Last5m, Last1h WindowStats
+}
+
+// HitRatio ... Return Hits over all reads, zero before the first read
+func (s Stats) HitRatio() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+type cacheStats struct {
+	hits       atomic.Uint64
+	misses     atomic.Uint64
+	sets       atomic.Uint64
+	expired    atomic.Uint64
+	evictions  atomic.Uint64
+	rejections atomic.Uint64
+	gcSweeps   atomic.Uint64
+	gcRemoved  atomic.Uint64
+	gcLast     atomic.Int64
+	gcTotal    atomic.Int64
+	window     *statsWindow // nil unless WithStatsWindow
+}
+
+// sweep ... Record a GC sweep that took d and removed removed Data
+func (s *cacheStats) sweep(d time.Duration, removed int) {
+	s.gcSweeps.Add(1)
+	s.gcRemoved.Add(uint64(removed))
+	s.gcLast.Store(int64(d))
+	s.gcTotal.Add(int64(d))
+}
+
+// read ... Count a read as a hit or a miss
+func (s *cacheStats) read(found bool) {
+	if found {
+		s.hits.Add(1)
+	} else {
+		s.misses.Add(1)
+	}
+	if s.window != nil {
+		s.window.read(found)
+	}
+}
+
+// Stats ... Return the counters of the Cache
+func (c *Cache) Stats() Stats {
+	return Stats{
+		Hits:      c.stats.hits.Load(),
+		Misses:    c.stats.misses.Load(),
+		Sets:      c.stats.sets.Load(),
+		Expired:   c.stats.expired.Load(),
+		Evictions: c.stats.evictions.Load(),
+		Rejected:  c.stats.rejections.Load(),
+		Items:     c.Count(),
+		Bytes:     c.Bytes(),
+
+		GcSweeps:       c.stats.gcSweeps.Load(),
+		GcRemoved:      c.stats.gcRemoved.Load(),
+		LastGcDuration: time.Duration(c.stats.gcLast.Load()),
+		TotalGcTime:    time.Duration(c.stats.gcTotal.Load()),
+		GcInterval:     c.GcInterval(),
+
+		Last1m: c.StatsOver(time.Minute),
+		Last5m: c.StatsOver(5 * time.Minute),
+		Last1h: c.StatsOver(time.Hour),
+	}
+}
@@ -0,0 +1,140 @@
+package GoCache
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// WindowStats ... Reads of a recent stretch of time, see WithStatsWindow
+type WindowStats struct {
+	Hits   uint64
+	Misses uint64
+	// Span ... Time the counts cover: the window asked for and the part of
+	// the current bucket gone by, less when the buckets kept do not reach
+	// that far back. Zero without WithStatsWindow
+	Span time.Duration
+}
+
+// HitRatio ... Return Hits over all reads of the window, zero if none
+func (w WindowStats) HitRatio() float64 {
+	if w.Hits+w.Misses == 0 {
+		return 0
+	}
+	return float64(w.Hits) / float64(w.Hits+w.Misses)
+}
+
+// add ... Sum the windows of two shards, which cover the same time
+func (w WindowStats) add(o WindowStats) WindowStats {
+	w.Hits += o.Hits
+	w.Misses += o.Misses
+	if o.Span > w.Span {
+		w.Span = o.Span
+	}
+	return w
+}
+
+// WithStatsWindow ... Count reads in n buckets of width each as well,
+// so Stats reports the hit ratio of the last minute, 5 minutes and hour
+// and StatsOver that of any recent window, where the counters since the
+// start hide a regression of a long running service. A width of zero or
+// less is a minute, n of zero or less covers an hour. Windows longer
+// than n buckets report what the buckets hold
+func WithStatsWindow(width time.Duration, n int) Option {
+	return func(o *Options) {
+		if width <= 0 {
+			width = time.Minute
+		}
+		if n <= 0 {
+			n = int((time.Hour + width - 1) / width)
+		}
+		o.StatsBucket, o.StatsBuckets = width, n
+	}
+}
+
+// statsBucket ... Reads of one width of time
+type statsBucket struct {
+	epoch  atomic.Int64 // Time the counts are of, in widths since the Unix epoch
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+// statsWindow ... Ring of buckets, one per width of time, a bucket is
+// reset when time comes round to it again
+type statsWindow struct {
+	clock   Clock
+	width   int64
+	buckets []statsBucket
+	mutex   sync.Mutex // Held to reset a bucket
+}
+
+func newStatsWindow(clock Clock, width time.Duration, n int) *statsWindow {
+	if width <= 0 || n <= 0 {
+		return nil
+	}
+	w := &statsWindow{clock: clock, width: int64(width), buckets: make([]statsBucket, n)}
+	for i := range w.buckets {
+		// No epoch is negative, so the buckets start out stale
+		w.buckets[i].epoch.Store(-1)
+	}
+	return w
+}
+
+// read ... Count a read in the bucket of now
+func (w *statsWindow) read(found bool) {
+	epoch := w.clock.Now().UnixNano() / w.width
+	b := &w.buckets[epoch%int64(len(w.buckets))]
+	if b.epoch.Load() != epoch {
+		// A read counted in between by a goRoutine that saw the old epoch
+		// is lost, which a ratio can bear
+		w.mutex.Lock()
+		if b.epoch.Load() < epoch {
+			b.hits.Store(0)
+			b.misses.Store(0)
+			b.epoch.Store(epoch)
+		}
+		w.mutex.Unlock()
+	}
+	if found {
+		b.hits.Add(1)
+	} else {
+		b.misses.Add(1)
+	}
+}
+
+// over ... Sum the buckets of the last d and the current one
+func (w *statsWindow) over(d time.Duration) WindowStats {
+	if w == nil || d <= 0 {
+		return WindowStats{}
+	}
+	now := w.clock.Now().UnixNano()
+	epoch := now / w.width
+	back := (int64(d) + w.width - 1) / w.width
+	if back >= int64(len(w.buckets)) {
+		back = int64(len(w.buckets)) - 1
+	}
+	var s WindowStats
+	for e := epoch - back; e <= epoch; e++ {
+		b := &w.buckets[e%int64(len(w.buckets))]
+		if b.epoch.Load() == e {
+			s.Hits += b.hits.Load()
+			s.Misses += b.misses.Load()
+		}
+	}
+	s.Span = time.Duration(now - (epoch-back)*w.width)
+	return s
+}
+
+// StatsOver ... Return the reads of the last d, see WithStatsWindow
+func (c *Cache) StatsOver(d time.Duration) WindowStats {
+	return c.stats.window.over(d)
+}
+
+// StatsOver ... Sum of StatsOver of every shard
+func (sc *ShardedCache) StatsOver(d time.Duration) WindowStats {
+	var s WindowStats
+	for _, c := range sc.shardSet() {
+		s = s.add(c.StatsOver(d))
+	}
+	return s
+}
@@ -0,0 +1,178 @@
+package GoCache
+
+import (
+	"sync"
+	"time"
+)
+
+// Store ... The slower system of record a StoreCache sits in front of,
+// like a database
+type Store interface {
+	// Get ... Return the value of k, found is false if there is none
+	Get(k string) (v interface{}, found bool, err error)
+	Set(k string, v interface{}) error
+	Delete(k string) error
+}
+
+// StoreMode ... How a StoreCache keeps its Store up to date
+type StoreMode int
+
+const (
+	// ReadThrough ... Get loads misses from the Store, Set and Delete
+	// only change the Cache
+	ReadThrough StoreMode = iota
+	// WriteThrough ... Like ReadThrough, and Set and Delete change the
+	// Store before the Cache, so the Cache never holds what the Store
+	// refused
+	WriteThrough
+	// WriteBehind ... Like ReadThrough, and Set and Delete change the
+	// Cache and queue the change for the Store, which a goRoutine
+	// applies in order
+	WriteBehind
+)
+
+// DefaultStoreQueue ... Length of the WriteBehind queue used when given zero
+const DefaultStoreQueue = 1024
+
+// storeOp ... A change queued for the Store, a Delete if del is set
+type storeOp struct {
+	k   string
+	v   interface{}
+	del bool
+}
+
+// StoreCache ... Cache in front of a Store
+type StoreCache struct {
+	cache     *Cache
+	store     Store
+	mode      StoreMode
+	ttl       time.Duration // Expiration of the Data Get loads
+	queue     chan storeOp
+	done      chan bool
+	errMutex  sync.Mutex
+	err       error // First error of a queued change
+	closeOnce sync.Once
+}
+
+// NewStoreCache ... Put c in front of store, Data loaded by Get Expires
+// after ttl. queueSize bounds the WriteBehind queue, Set and Delete wait
+// while it is full; it is DefaultStoreQueue if not positive
+func NewStoreCache(c *Cache, store Store, mode StoreMode, ttl time.Duration, queueSize int) *StoreCache {
+	sc := &StoreCache{cache: c, store: store, mode: mode, ttl: ttl}
+	if mode == WriteBehind {
+		if queueSize <= 0 {
+			queueSize = DefaultStoreQueue
+		}
+		sc.queue = make(chan storeOp, queueSize)
+		sc.done = make(chan bool)
+		go sc.writeBehind()
+	}
+	return sc
+}
+
+// Cache ... Return the Cache in front of the Store
+func (sc *StoreCache) Cache() *Cache {
+	return sc.cache
+}
+
+// Get ... Get the Data from the Cache, or on a miss from the Store and
+// Set it in the Cache. Concurrent misses on the same key share one
+// Store call
+func (sc *StoreCache) Get(k string) (interface{}, bool, error) {
+	if v, found := sc.cache.Get(k); found {
+		return v, true, nil
+	}
+	v, err := sc.cache.load(k, func() (interface{}, error) {
+		// Another flight may have filled k since the miss above
+		if v, found := sc.cache.Get(k); found {
+			return storeResult{v, true}, nil
+		}
+		v, found, err := sc.store.Get(k)
+		if err == nil && found {
+			sc.cache.Set(k, v, sc.ttl)
+		}
+		return storeResult{v, found}, err
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	if r, ok := v.(storeResult); ok {
+		return r.v, r.found, nil
+	}
+	// The flight was started by GetOrCompute
+	return v, true, nil
+}
+
+// storeResult ... What a Store Get returned, shared by a flight
+type storeResult struct {
+	v     interface{}
+	found bool
+}
+
+// Set ... Set the Data with Expiration d, and in the Store as the
+// StoreMode says
+func (sc *StoreCache) Set(k string, v interface{}, d time.Duration) error {
+	switch sc.mode {
+	case WriteThrough:
+		if err := sc.store.Set(k, v); err != nil {
+			return err
+		}
+	case WriteBehind:
+		sc.cache.Set(k, v, d)
+		sc.queue <- storeOp{k: k, v: v}
+		return nil
+	}
+	sc.cache.Set(k, v, d)
+	return nil
+}
+
+// Delete ... Delete the Data, and from the Store as the StoreMode says
+func (sc *StoreCache) Delete(k string) error {
+	switch sc.mode {
+	case WriteThrough:
+		if err := sc.store.Delete(k); err != nil {
+			return err
+		}
+	case WriteBehind:
+		sc.cache.Delete(k)
+		sc.queue <- storeOp{k: k, del: true}
+		return nil
+	}
+	sc.cache.Delete(k)
+	return nil
+}
+
+// writeBehind ... Apply the queued changes to the Store until Close
+func (sc *StoreCache) writeBehind() {
+	defer close(sc.done)
+	for op := range sc.queue {
+		var err error
+		if op.del {
+			err = sc.store.Delete(op.k)
+		} else {
+			err = sc.store.Set(op.k, op.v)
+		}
+		if err != nil {
+			sc.errMutex.Lock()
+			if sc.err == nil {
+				sc.err = err
+			}
+			sc.errMutex.Unlock()
+		}
+	}
+}
+
+// Close ... Wait for the queued changes to reach the Store and return
+// the first error one of them met. Set and Delete must not be called
+// after Close. Later calls return the same result
+func (sc *StoreCache) Close() error {
+	sc.closeOnce.Do(func() {
+		if sc.queue != nil {
+			close(sc.queue)
+			<-sc.done
+		}
+	})
+	sc.errMutex.Lock()
+	defer sc.errMutex.Unlock()
+	return sc.err
+}
@@ -0,0 +1,66 @@
+package GoCache
+
+import "time"
+
+// How many keys an incremental sweep handles between clock reads
+const sweepClockEvery = 64
+
+// sweepStep ... Delete Expired Data within the bounds of one GC tick
+// Without an expiration index the keys are snapshot once per round and
+// worked through over as many ticks as it takes, so Data Set during
+// the round is left for the next one
+func (c *Cache) sweepStep() (removed, scanned int) {
+	c.mutex.Lock()
+	defer c.unlock()
+	if c.paused.Load() || c.movedTo != nil {
+		return 0, 0
+	}
+	start := time.Now()
+	t := c.now().UnixNano()
+	now := t - c.grace() // Stale Data is kept for its grace
+	idle := 0
+	more := func() bool {
+		if c.sweepBatch > 0 && scanned >= c.sweepBatch {
+			return false
+		}
+		return c.sweepPause <= 0 || scanned%sweepClockEvery != 0 || time.Since(start) < c.sweepPause
+	}
+	if c.expirations != nil {
+		for e, ok := c.expirations.peek(); ok && now > e.at && more(); e, ok = c.expirations.peek() {
+			c.expire(e.key)
+			removed++
+			scanned++
+		}
+	}
+	if c.expirations == nil || c.idleTimeout > 0 {
+		if len(c.sweepKeys) == 0 {
+			c.sweepKeys = make([]string, 0, len(c.items))
+			for k := range c.items {
+				c.sweepKeys = append(c.sweepKeys, k)
+			}
+		}
+		for len(c.sweepKeys) > 0 && more() {
+			k := c.sweepKeys[len(c.sweepKeys)-1]
+			c.sweepKeys = c.sweepKeys[:len(c.sweepKeys)-1]
+			scanned++
+			item, found := c.items[k]
+			if !found {
+				continue
+			}
+			if e := item.deadline(); c.expirations == nil && e > 0 && now > e {
+				c.expire(k)
+				removed++
+			} else if c.isIdle(item, t) {
+				c.remove(k, EventEvict)
+				idle++
+			}
+		}
+		if len(c.sweepKeys) == 0 {
+			c.sweepKeys = nil
+		}
+	}
+	c.stats.expired.Add(uint64(removed))
+	c.stats.evictions.Add(uint64(idle))
+	c.stats.sweep(time.Since(start), removed+idle)
+	return removed + idle, scanned
+}
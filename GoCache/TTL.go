@@ -0,0 +1,60 @@
+package GoCache
+
+import "time"
+
+// TTL ... Return the time left before the Data Expires, NoExpiration if
+// it never does, and false if it is not In Cache or already Expired
+// Unlike Get it is no read: it counts no hit, slides nothing and leaves
+// the eviction policy alone, so dashboards can poll it freely
+func (c *Cache) TTL(k string) (time.Duration, bool) {
+	k = c.key(k)
+	c = c.rLockFor(k)
+	defer c.mutex.RUnlock()
+	return c.ttl(k)
+}
+
+// MultiTTL ... TTL of keys under a single lock, missing and Expired keys
+// are left out of the returned map, which holds the keys as given
+func (c *Cache) MultiTTL(keys []string) map[string]time.Duration {
+	c.rLock()
+	if sc := c.movedTo; sc != nil {
+		c.mutex.RUnlock()
+		return sc.MultiTTL(keys)
+	}
+	defer c.mutex.RUnlock()
+	res := make(map[string]time.Duration, len(keys))
+	for _, k := range keys {
+		if d, ok := c.ttl(c.key(k)); ok {
+			res[k] = d
+		}
+	}
+	return res
+}
+
+func (c *Cache) ttl(k string) (time.Duration, bool) {
+	item, found := c.items[k]
+	if !found || c.expired(item) {
+		return 0, false
+	}
+	e := item.deadline()
+	if e <= 0 {
+		return NoExpiration, true
+	}
+	return time.Duration(e - c.clock.Now().UnixNano()), true
+}
+
+// TTL ... TTL of k in its shard
+func (sc *ShardedCache) TTL(k string) (time.Duration, bool) {
+	return sc.shard(k).TTL(k)
+}
+
+// MultiTTL ... TTL of keys, locking each shard once
+func (sc *ShardedCache) MultiTTL(keys []string) map[string]time.Duration {
+	res := make(map[string]time.Duration, len(keys))
+	for c, part := range sc.splitKeys(keys) {
+		for k, d := range c.MultiTTL(part) {
+			res[k] = d
+		}
+	}
+	return res
+}
@@ -0,0 +1,50 @@
+package GoCache
+
+import "time"
+
+// SetWithTags ... Set the Data and file it under tags for InvalidateTag
+func (c *Cache) SetWithTags(k string, v interface{}, d time.Duration, tags ...string) {
+	k = c.key(k)
+	c = c.lockFor(k)
+	defer c.unlock()
+	c.put(k, Item{
+		Object:     v,
+		Expiration: c.expiration(d),
+		Tags:       tags,
+	})
+}
+
+// InvalidateTag ... Delete all Data filed under tag, Return how many
+func (c *Cache) InvalidateTag(tag string) int {
+	c.lock()
+	defer c.unlock()
+	keys := c.tags[tag]
+	n := len(keys)
+	for k := range keys {
+		c.delete(k)
+	}
+	return n
+}
+
+// tagKey ... File k under tags
+func (c *Cache) tagKey(k string, tags []string) {
+	for _, tag := range tags {
+		if c.tags == nil {
+			c.tags = map[string]map[string]struct{}{}
+		}
+		if c.tags[tag] == nil {
+			c.tags[tag] = map[string]struct{}{}
+		}
+		c.tags[tag][k] = struct{}{}
+	}
+}
+
+// untagKey ... Take k out of tags, dropping tags left empty
+func (c *Cache) untagKey(k string, tags []string) {
+	for _, tag := range tags {
+		delete(c.tags[tag], k)
+		if len(c.tags[tag]) == 0 {
+			delete(c.tags, tag)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package GoCache
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Text format: one key=value line per Data, sorted by key
+// Backslash, '=', '\n' and '\r' are escaped with a backslash
+// so keys and values may contain them
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, "=", `\=`, "\n", `\n`, "\r", `\r`)
+
+// SkipNonStringText ... Let ExportText skip non string Data instead of failing
+func (c *Cache) SkipNonStringText(skip bool) {
+	c.mutex.Lock()
+	defer c.unlock()
+	c.skipNonStringText = skip
+}
+
+// ExportText ... Write the string Data In Cache as key=value lines
+func (c *Cache) ExportText(w io.Writer) error {
+	c.mutex.RLock()
+	lines := make([]string, 0, len(c.items))
+	for k, v := range c.items {
+		if c.expired(v) {
+			continue
+		}
+		s, ok := c.open(v.Object).(string)
+		if !ok {
+			if c.skipNonStringText {
+				continue
+			}
+			c.mutex.RUnlock()
+			return errNotA(k, "string")
+		}
+		lines = append(lines, textEscaper.Replace(k)+"="+textEscaper.Replace(s))
+	}
+	c.mutex.RUnlock()
+	sort.Strings(lines)
+	bw := bufio.NewWriter(w)
+	for _, line := range lines {
+		if _, err := bw.WriteString(line + "\n"); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// ImportText ... Read key=value lines written by ExportText
+// and Set them with Expiration d, empty lines are ignored
+func (c *Cache) ImportText(r io.Reader, d time.Duration) error {
+	type pair struct{ k, v string }
+	var pairs []pair
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1<<30)
+	for n := 1; sc.Scan(); n++ {
+		line := sc.Text()
+		if line == "" {
+			continue
+		}
+		k, v, err := parseTextLine(line)
+		if err != nil {
+			return fmt.Errorf("line %d: %v", n, err)
+		}
+		pairs = append(pairs, pair{k, v})
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+	c.mutex.Lock()
+	defer c.unlock()
+	for _, p := range pairs {
+		c.set(c.key(p.k), p.v, d)
+	}
+	return nil
+}
+
+// parseTextLine ... Split a line on the first unescaped '=' and unescape both sides
+func parseTextLine(line string) (string, string, error) {
+	var key, cur strings.Builder
+	sawSep := false
+	for i := 0; i < len(line); i++ {
+		ch := line[i]
+		switch {
+		case ch == '\\':
+			i++
+			if i == len(line) {
+				return "", "", fmt.Errorf("dangling escape")
+			}
+			switch line[i] {
+			case '\\', '=':
+				cur.WriteByte(line[i])
+			case 'n':
+				cur.WriteByte('\n')
+			case 'r':
+				cur.WriteByte('\r')
+			default:
+				return "", "", fmt.Errorf("unknown escape \\%c", line[i])
+			}
+		case ch == '=' && !sawSep:
+			sawSep = true
+			key.WriteString(cur.String())
+			cur.Reset()
+		default:
+			cur.WriteByte(ch)
+		}
+	}
+	if !sawSep {
+		return "", "", fmt.Errorf("missing '='")
+	}
+	return key.String(), cur.String(), nil
+}
@@ -0,0 +1,91 @@
+package GoCache
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTextRoundTrip(t *testing.T) {
+	data := map[string]string{
+		"plain":         "value",
+		"a=b":           "x=y=z",
+		"=":             "=",
+		"multi\nline":   "first\nsecond\r\n",
+		`back\slash`:    `C:\dir\=file`,
+		"trailing\\":    "\\",
+		"empty":         "",
+		"mixed=\\\n=\r": "=\\n\n",
+	}
+	c := NewCache(0, time.Hour)
+	defer c.StopGc()
+	for k, v := range data {
+		c.Set(k, v, NoExpiration)
+	}
+	var buf bytes.Buffer
+	if err := c.ExportText(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(buf.String(), "\n"); n != len(data) {
+		t.Fatalf("ExportText wrote %d lines for %d Data:\n%s", n, len(data), buf.String())
+	}
+	d := NewCache(0, time.Hour)
+	defer d.StopGc()
+	if err := d.ImportText(&buf, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if d.Count() != len(data) {
+		t.Fatalf("ImportText read %d Data, want %d", d.Count(), len(data))
+	}
+	for k, want := range data {
+		if v, found := d.Get(k); !found || v != want {
+			t.Errorf("Get(%q) = %q, %v, want %q", k, v, found, want)
+		}
+	}
+}
+
+func TestExportTextNonString(t *testing.T) {
+	c := NewCache(0, time.Hour)
+	defer c.StopGc()
+	c.Set("s", "x", NoExpiration)
+	c.Set("n", 1, NoExpiration)
+	var buf bytes.Buffer
+	if err := c.ExportText(&buf); err == nil {
+		t.Fatal("ExportText of an int succeeded, want an error")
+	}
+	c.SkipNonStringText(true)
+	buf.Reset()
+	if err := c.ExportText(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "s=x\n" {
+		t.Fatalf("ExportText skipping non strings wrote %q", buf.String())
+	}
+}
+
+func TestParseTextLine(t *testing.T) {
+	for _, tc := range []struct {
+		line, k, v string
+		err        bool
+	}{
+		{line: "k=v", k: "k", v: "v"},
+		{line: `a\=b=c=d`, k: "a=b", v: "c=d"},
+		{line: `k=a\nb\rc\\d`, k: "k", v: "a\nb\rc\\d"},
+		{line: "=", k: "", v: ""},
+		{line: "novalue", err: true},
+		{line: `k=v\`, err: true},
+		{line: `k=\t`, err: true},
+	} {
+		k, v, err := parseTextLine(tc.line)
+		if tc.err {
+			if err == nil {
+				t.Errorf("parseTextLine(%q) succeeded, want an error", tc.line)
+			}
+			continue
+		}
+		if err != nil || k != tc.k || v != tc.v {
+			t.Errorf("parseTextLine(%q) = %q, %q, %v, want %q, %q", tc.line, k, v, err, tc.k, tc.v)
+		}
+	}
+}
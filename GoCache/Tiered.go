@@ -0,0 +1,83 @@
+package GoCache
+
+import "time"
+
+// Tier ... A slower cache level below the in-memory Cache of a
+// TieredCache, backed by Redis, memcached, disk or anything else
+type Tier interface {
+	// Get ... Return the live value of k, found is false if there is none
+	Get(k string) (v interface{}, found bool, err error)
+	// Set ... Store v under k, Expiring after d; d of zero or less never Expires
+	Set(k string, v interface{}, d time.Duration) error
+	Delete(k string) error
+}
+
+// TieredCache ... In-memory Cache (L1) checked before a slower Tier (L2)
+// Hits in L2 are copied into L1, Set and Delete go to both
+type TieredCache struct {
+	l1         *Cache
+	l2         Tier
+	promoteTTL time.Duration // Expiration in L1 of Data copied from L2
+}
+
+// NewTieredCache ... Put l1 in front of l2, Data found in l2 stays in
+// l1 for promoteTTL, DefaultExpiration uses the one of l1
+func NewTieredCache(l1 *Cache, l2 Tier, promoteTTL time.Duration) *TieredCache {
+	return &TieredCache{l1: l1, l2: l2, promoteTTL: promoteTTL}
+}
+
+// L1 ... Return the in-memory Cache
+func (tc *TieredCache) L1() *Cache {
+	return tc.l1
+}
+
+// L2 ... Return the slower Tier
+func (tc *TieredCache) L2() Tier {
+	return tc.l2
+}
+
+// Get ... Get the Data from L1, or on a miss from L2 and copy it into L1
+// Concurrent misses on the same key share one L2 call
+func (tc *TieredCache) Get(k string) (interface{}, bool, error) {
+	if v, found := tc.l1.Get(k); found {
+		return v, true, nil
+	}
+	v, err := tc.l1.load(k, func() (interface{}, error) {
+		// Another flight may have filled k since the miss above
+		if v, found := tc.l1.Get(k); found {
+			return storeResult{v, true}, nil
+		}
+		v, found, err := tc.l2.Get(k)
+		if err == nil && found {
+			tc.l1.Set(k, v, tc.promoteTTL)
+		}
+		return storeResult{v, found}, err
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	if r, ok := v.(storeResult); ok {
+		return r.v, r.found, nil
+	}
+	// The flight was started by GetOrCompute
+	return v, true, nil
+}
+
+// Set ... Set the Data with Expiration d in L2, then in L1
+// L1 is left alone if L2 fails
+func (tc *TieredCache) Set(k string, v interface{}, d time.Duration) error {
+	if err := tc.l2.Set(k, v, tc.l1.resolve(d)); err != nil {
+		return err
+	}
+	tc.l1.Set(k, v, d)
+	return nil
+}
+
+// Delete ... Delete the Data from L2, then from L1
+func (tc *TieredCache) Delete(k string) error {
+	if err := tc.l2.Delete(k); err != nil {
+		return err
+	}
+	tc.l1.Delete(k)
+	return nil
+}
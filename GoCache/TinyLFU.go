@@ -0,0 +1,160 @@
+package GoCache
+
+// AdmissionPolicy ... Decide whether new Data may enter a full Cache
+// The Cache calls it with its write lock held, so it need not lock
+type AdmissionPolicy interface {
+	Record(k string)                     // k was read or written
+	Admit(candidate, victim string) bool // May candidate push victim out
+}
+
+// tinyLFU ... Frequency sketch admission: a key is let in only if it
+// was used more often lately than the key it would evict
+// A doorkeeper filter keeps one hit wonders out of the sketch, and all
+// counts are halved every sample uses so old popularity fades
+type tinyLFU struct {
+	sketch     countMinSketch
+	doorkeeper []uint64 // Bloom filter of keys seen once since the last reset
+	additions  int
+	sample     int
+}
+
+// NewTinyLFU ... Make a TinyLFU admission policy for a Cache of about
+// capacity entries, for Options.Admission
+func NewTinyLFU(capacity int) func() AdmissionPolicy {
+	return func() AdmissionPolicy {
+		if capacity < 16 {
+			capacity = 16
+		}
+		width := 1
+		for width < capacity*4 {
+			width <<= 1
+		}
+		sample := capacity * 10
+		return &tinyLFU{
+			sketch:     newCountMinSketch(width),
+			doorkeeper: make([]uint64, sample*8/64),
+			sample:     sample,
+		}
+	}
+}
+
+func (t *tinyLFU) Record(k string) {
+	h := hash64(k)
+	if !t.doorkeeperAdd(h) {
+		// First sighting only goes into the doorkeeper
+		t.count()
+		return
+	}
+	t.sketch.add(h)
+	t.count()
+}
+
+func (t *tinyLFU) Admit(candidate, victim string) bool {
+	return t.estimate(hash64(candidate)) > t.estimate(hash64(victim))
+}
+
+func (t *tinyLFU) estimate(h uint64) uint8 {
+	n := t.sketch.estimate(h)
+	if t.doorkeeperHas(h) {
+		n++
+	}
+	return n
+}
+
+// count ... Age the counts once sample uses were recorded
+func (t *tinyLFU) count() {
+	t.additions++
+	if t.additions < t.sample {
+		return
+	}
+	t.additions = 0
+	t.sketch.halve()
+	for i := range t.doorkeeper {
+		t.doorkeeper[i] = 0
+	}
+}
+
+// doorkeeperAdd ... Add h and report whether it was in already
+func (t *tinyLFU) doorkeeperAdd(h uint64) bool {
+	bits := uint64(len(t.doorkeeper) * 64)
+	in := true
+	for i, x := uint64(0), h; i < 3; i, x = i+1, x>>21 {
+		b := x % bits
+		if t.doorkeeper[b/64]&(1<<(b%64)) == 0 {
+			in = false
+			t.doorkeeper[b/64] |= 1 << (b % 64)
+		}
+	}
+	return in
+}
+
+func (t *tinyLFU) doorkeeperHas(h uint64) bool {
+	bits := uint64(len(t.doorkeeper) * 64)
+	for i, x := uint64(0), h; i < 3; i, x = i+1, x>>21 {
+		b := x % bits
+		if t.doorkeeper[b/64]&(1<<(b%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// countMinSketch ... Four rows of saturating counters, an estimate is
+// the smallest counter of a key over the rows
+type countMinSketch struct {
+	rows [4][]uint8
+	mask uint64
+}
+
+// Counters stop here, like the 4 bit counters of the TinyLFU paper
+const sketchMaxCount = 15
+
+func newCountMinSketch(width int) countMinSketch {
+	var s countMinSketch
+	for i := range s.rows {
+		s.rows[i] = make([]uint8, width)
+	}
+	s.mask = uint64(width - 1)
+	return s
+}
+
+// slot ... Column of h in row i, by double hashing
+func (s *countMinSketch) slot(h uint64, i int) uint64 {
+	return (h + uint64(i)*(h>>32|1)) & s.mask
+}
+
+func (s *countMinSketch) add(h uint64) {
+	for i := range s.rows {
+		if j := s.slot(h, i); s.rows[i][j] < sketchMaxCount {
+			s.rows[i][j]++
+		}
+	}
+}
+
+func (s *countMinSketch) estimate(h uint64) uint8 {
+	min := uint8(sketchMaxCount)
+	for i := range s.rows {
+		if n := s.rows[i][s.slot(h, i)]; n < min {
+			min = n
+		}
+	}
+	return min
+}
+
+func (s *countMinSketch) halve() {
+	for i := range s.rows {
+		for j := range s.rows[i] {
+			s.rows[i][j] >>= 1
+		}
+	}
+}
+
+// hash64 ... FNV-1a of k
+func hash64(k string) uint64 {
+	h := uint64(14695981039346656037)
+	for i := 0; i < len(k); i++ {
+		h ^= uint64(k[i])
+		h *= 1099511628211
+	}
+	return h
+}
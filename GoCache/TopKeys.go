@@ -0,0 +1,228 @@
+package GoCache
+
+import (
+	"container/heap"
+	"sort"
+	"sync"
+)
+
+// TopBy ... What TopKeys ranks the keys by
+type TopBy int
+
+const (
+	// ByHits ... Reads that found the Data, kept with WithHotKeys
+	ByHits TopBy = iota
+	// BySize ... Approximate size of the Data, or its weight by WithSizer
+	BySize
+)
+
+// KeyStat ... A key reported by TopKeys
+type KeyStat struct {
+	Key string
+	// Hits ... Estimated reads that found the Data, over the true count
+	// by at most HitsError. Zero without WithHotKeys
+	Hits      uint64
+	HitsError uint64
+	// Size ... Approximate bytes of the Data, zero if it is gone
+	Size int64
+}
+
+// WithHotKeys ... Count the hits of about capacity keys with the space
+// saving algorithm, for TopKeys by ByHits: the keys hit most since the
+// Cache was made are kept, each new key taking the place of the least
+// hit one. A key hit more than 1/capacity of all hits is sure to be
+// among them. Every hit takes a short lock of its own
+func WithHotKeys(capacity int) Option {
+	return func(o *Options) { o.HotKeys = capacity }
+}
+
+// TopKeys ... Return the n keys hit most, or the n live keys with the
+// largest Data, most first, to find hot keys and oversized entries.
+// By ByHits it is empty without WithHotKeys, and may report keys since
+// Deleted
+func (c *Cache) TopKeys(n int, by TopBy) []KeyStat {
+	if n <= 0 {
+		return nil
+	}
+	var top []KeyStat
+	if by == ByHits {
+		top = c.hot.top(n)
+	}
+	c.rLock()
+	defer c.mutex.RUnlock()
+	if by == BySize {
+		top = c.largest(n)
+		for i := range top {
+			top[i].Hits, top[i].HitsError = c.hot.count(top[i].Key)
+		}
+		return top
+	}
+	for i := range top {
+		if item, found := c.items[top[i].Key]; found && !c.expired(item) {
+			top[i].Size = item.size
+		}
+	}
+	return top
+}
+
+// largest ... The n live keys with the largest Data, the caller holds
+// the lock
+func (c *Cache) largest(n int) []KeyStat {
+	var h bySize
+	for k, item := range c.items {
+		if c.expired(item) {
+			continue
+		}
+		if len(h) < n {
+			heap.Push(&h, KeyStat{Key: k, Size: item.size})
+		} else if item.size > h[0].Size {
+			h[0] = KeyStat{Key: k, Size: item.size}
+			heap.Fix(&h, 0)
+		}
+	}
+	sort.Slice(h, func(i, j int) bool { return h[i].Size > h[j].Size })
+	return h
+}
+
+// TopKeys ... TopKeys of the shards put together
+func (sc *ShardedCache) TopKeys(n int, by TopBy) []KeyStat {
+	var top []KeyStat
+	for _, c := range sc.shardSet() {
+		top = append(top, c.TopKeys(n, by)...)
+	}
+	sort.Slice(top, func(i, j int) bool {
+		if by == BySize {
+			return top[i].Size > top[j].Size
+		}
+		return top[i].Hits > top[j].Hits
+	})
+	if len(top) > n {
+		top = top[:n]
+	}
+	return top
+}
+
+// bySize ... Min heap of KeyStats by Size
+type bySize []KeyStat
+
+func (h bySize) Len() int            { return len(h) }
+func (h bySize) Less(i, j int) bool  { return h[i].Size < h[j].Size }
+func (h bySize) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *bySize) Push(x interface{}) { *h = append(*h, x.(KeyStat)) }
+
+func (h *bySize) Pop() interface{} {
+	x := (*h)[len(*h)-1]
+	*h = (*h)[:len(*h)-1]
+	return x
+}
+
+// countRead ... Count a read of k in the Stats, as a hit for TopKeys
+// and in the NamespaceStats of its namespace
+func (c *Cache) countRead(k string, found bool) {
+	c.stats.read(found)
+	if found && c.hot != nil {
+		c.hot.hit(k)
+	}
+	if c.namespaces != nil {
+		if nl := c.namespaceOf(k); nl != nil {
+			nl.read(found)
+		}
+	}
+}
+
+// hotKeys ... Space saving counter of hits: a min heap of the counted
+// keys by hits, indexed by key
+type hotKeys struct {
+	mutex    sync.Mutex
+	capacity int
+	keys     []hotKey
+	index    map[string]int // Position of a key in keys
+}
+
+type hotKey struct {
+	key        string
+	hits, errs uint64
+}
+
+func newHotKeys(capacity int) *hotKeys {
+	if capacity <= 0 {
+		return nil
+	}
+	return &hotKeys{capacity: capacity, index: make(map[string]int, capacity)}
+}
+
+// hit ... Count a hit of k
+func (h *hotKeys) hit(k string) {
+	if h == nil {
+		return
+	}
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if i, found := h.index[k]; found {
+		h.keys[i].hits++
+		heap.Fix(h, i)
+		return
+	}
+	if len(h.keys) < h.capacity {
+		heap.Push(h, hotKey{key: k, hits: 1})
+		return
+	}
+	// k takes the place of the least hit key, and its hits as the error
+	least := h.keys[0]
+	delete(h.index, least.key)
+	h.keys[0] = hotKey{key: k, hits: least.hits + 1, errs: least.hits}
+	h.index[k] = 0
+	heap.Fix(h, 0)
+}
+
+// count ... The hits of k and their error, zero if it is not counted
+func (h *hotKeys) count(k string) (uint64, uint64) {
+	if h == nil {
+		return 0, 0
+	}
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if i, found := h.index[k]; found {
+		return h.keys[i].hits, h.keys[i].errs
+	}
+	return 0, 0
+}
+
+// top ... The n keys hit most, most first
+func (h *hotKeys) top(n int) []KeyStat {
+	if h == nil {
+		return nil
+	}
+	h.mutex.Lock()
+	top := make([]KeyStat, len(h.keys))
+	for i, hk := range h.keys {
+		top[i] = KeyStat{Key: hk.key, Hits: hk.hits, HitsError: hk.errs}
+	}
+	h.mutex.Unlock()
+	sort.Slice(top, func(i, j int) bool { return top[i].Hits > top[j].Hits })
+	if len(top) > n {
+		top = top[:n]
+	}
+	return top
+}
+
+func (h *hotKeys) Len() int           { return len(h.keys) }
+func (h *hotKeys) Less(i, j int) bool { return h.keys[i].hits < h.keys[j].hits }
+func (h *hotKeys) Swap(i, j int) {
+	h.keys[i], h.keys[j] = h.keys[j], h.keys[i]
+	h.index[h.keys[i].key] = i
+	h.index[h.keys[j].key] = j
+}
+
+func (h *hotKeys) Push(x interface{}) {
+	hk := x.(hotKey)
+	h.index[hk.key] = len(h.keys)
+	h.keys = append(h.keys, hk)
+}
+
+func (h *hotKeys) Pop() interface{} {
+	hk := h.keys[len(h.keys)-1]
+	h.keys = h.keys[:len(h.keys)-1]
+	delete(h.index, hk.key)
+	return hk
+}
@@ -0,0 +1,175 @@
+package GoCache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"io"
+)
+
+// ValueTransform ... Turns Data, encoded with gob, into the bytes the
+// Cache keeps and back, to compress or encrypt it in memory and in
+// everything Saved from it
+type ValueTransform interface {
+	Encode(b []byte) ([]byte, error)
+	Decode(b []byte) ([]byte, error)
+}
+
+// GzipTransform ... Compress Data with gzip
+var GzipTransform ValueTransform = gzipTransform{}
+
+// sealed ... Stands in the Cache for Data encoded by its ValueTransform
+type sealed struct {
+	Data []byte
+}
+
+// nsSealed ... Stands in the Cache for Data encoded by the
+// ValueTransform of a Namespace
+type nsSealed struct {
+	Data []byte
+}
+
+// WithValueTransform ... Keep every Data sealed by t, gob encoded first,
+// and open it again on every read, which then always returns a copy
+// Data gob cannot encode is not stored, SetE, Add and Replace Return
+// why. Use ChainTransforms to compress
+// and then encrypt
+func WithValueTransform(t ValueTransform) Option {
+	return func(o *Options) { o.ValueTransform = t }
+}
+
+// WithTransform ... Return a view of the namespace that keeps the Data
+// it Sets sealed by t, and opens it on Get. Data of the namespace Set
+// without it reads as missing through it
+func (ns *Namespace) WithTransform(t ValueTransform) *Namespace {
+	view := *ns
+	view.transform = t
+	return &view
+}
+
+// seal ... Return v gob encoded then encoded by t
+func seal(t ValueTransform, v interface{}) ([]byte, error) {
+	b, err := gobEncodeValue(v)
+	if err != nil {
+		return nil, err
+	}
+	return t.Encode(b)
+}
+
+// unseal ... Undo seal
+func unseal(t ValueTransform, b []byte) (interface{}, error) {
+	b, err := t.Decode(b)
+	if err != nil {
+		return nil, err
+	}
+	return gobDecodeValue(b)
+}
+
+// reveal ... Return the Data object stands for, read back from disk if
+// spilled and opened if sealed
+func (c *Cache) reveal(object interface{}) (interface{}, error) {
+	if sp, ok := object.(spilled); ok {
+		v, err := sp.read()
+		if err != nil {
+			return nil, err
+		}
+		object = v
+	}
+	if s, ok := object.(sealed); ok && c.transform != nil {
+		return unseal(c.transform, s.Data)
+	}
+	return object, nil
+}
+
+// open ... reveal, nil if that fails
+func (c *Cache) open(object interface{}) interface{} {
+	v, _ := c.reveal(object)
+	return v
+}
+
+// ChainTransforms ... Return a ValueTransform applying ts in order on
+// Encode and in reverse order on Decode
+func ChainTransforms(ts ...ValueTransform) ValueTransform {
+	return chain(ts)
+}
+
+type chain []ValueTransform
+
+func (ts chain) Encode(b []byte) ([]byte, error) {
+	var err error
+	for _, t := range ts {
+		if b, err = t.Encode(b); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+func (ts chain) Decode(b []byte) ([]byte, error) {
+	var err error
+	for i := len(ts) - 1; i >= 0; i-- {
+		if b, err = ts[i].Decode(b); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+type gzipTransform struct{}
+
+func (gzipTransform) Encode(b []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(b); err != nil {
+		return nil, err
+	}
+	err := zw.Close()
+	return buf.Bytes(), err
+}
+
+func (gzipTransform) Decode(b []byte) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	return io.ReadAll(zr)
+}
+
+// aesTransform ... AES-GCM, the nonce is put before the sealed bytes
+type aesTransform struct {
+	aead cipher.AEAD
+}
+
+// NewAESTransform ... Return a ValueTransform encrypting Data with
+// AES-GCM under key, which is 16, 24 or 32 bytes long
+func NewAESTransform(key []byte) (ValueTransform, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return aesTransform{aead}, nil
+}
+
+func (t aesTransform) Encode(b []byte) ([]byte, error) {
+	nonce := make([]byte, t.aead.NonceSize(), t.aead.NonceSize()+len(b)+t.aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return t.aead.Seal(nonce, nonce, b, nil), nil
+}
+
+func (t aesTransform) Decode(b []byte) ([]byte, error) {
+	n := t.aead.NonceSize()
+	if len(b) < n {
+		return nil, errors.New("sealed Data too short")
+	}
+	return t.aead.Open(nil, b[:n], b[n:], nil)
+}
@@ -0,0 +1,128 @@
+package GoCache
+
+import "time"
+
+// Tx ... Reads and writes of an Update, the writes are buffered until
+// the Update function returns
+type Tx struct {
+	get    func(k string) (interface{}, bool) // Reads keys in canonical form
+	key    func(k string) string              // Canonical form of keys, see WithKeyFunc
+	writes map[string]txWrite
+}
+
+type txWrite struct {
+	v       interface{}
+	d       time.Duration
+	deleted bool
+}
+
+func newTx(get func(k string) (interface{}, bool), key func(k string) string) *Tx {
+	return &Tx{get: get, key: key, writes: map[string]txWrite{}}
+}
+
+// Get ... Get the Data as this Tx left it
+func (tx *Tx) Get(k string) (interface{}, bool) {
+	k = tx.key(k)
+	if w, found := tx.writes[k]; found {
+		return w.v, !w.deleted
+	}
+	return tx.get(k)
+}
+
+// Set ... Set the Data with Expiration d when the Tx commits
+func (tx *Tx) Set(k string, v interface{}, d time.Duration) {
+	k = tx.key(k)
+	tx.writes[k] = txWrite{v: v, d: d}
+}
+
+// Delete ... Delete the Data when the Tx commits
+func (tx *Tx) Delete(k string) {
+	k = tx.key(k)
+	tx.writes[k] = txWrite{deleted: true}
+}
+
+// commit ... Apply the writes to the Caches cacheOf picks for their
+// keys, whose locks the caller holds, Return the deleted keys. Every
+// Set is prepared first, so one the Cache rejects, like ErrValueTooLarge,
+// is returned with none of the writes applied
+func (tx *Tx) commit(cacheOf func(k string) *Cache) ([]string, error) {
+	prepared := make(map[string]Item, len(tx.writes))
+	for k, w := range tx.writes {
+		if w.deleted {
+			continue
+		}
+		c := cacheOf(k)
+		item, err := c.prepare(k, c.newItem(w.v, w.d))
+		if err != nil {
+			for _, item := range prepared {
+				release(item.Object, false)
+			}
+			return nil, err
+		}
+		prepared[k] = item
+	}
+	var deleted []string
+	for k, w := range tx.writes {
+		if w.deleted {
+			cacheOf(k).delete(k)
+			deleted = append(deleted, k)
+		} else {
+			cacheOf(k).place(k, prepared[k])
+		}
+	}
+	return deleted, nil
+}
+
+// Update ... Call fn with a Tx holding the lock of the Cache, and apply
+// all its writes at once if it returns nil, none if it returns an error
+// or the Cache rejects one of the writes, whose error is returned then
+// fn must not call the Cache itself, only the Tx
+func (c *Cache) Update(fn func(tx *Tx) error) error {
+	tx := newTx(c.get, c.key)
+	var deleted []string
+	var inv Invalidator
+	c.lock()
+	err := func() error {
+		defer c.unlock()
+		if err := fn(tx); err != nil {
+			return err
+		}
+		var err error
+		deleted, err = tx.commit(func(string) *Cache { return c })
+		inv = c.invalidator
+		return err
+	}()
+	for _, k := range deleted {
+		publish(inv, c.instanceID, Invalidation{Key: k})
+	}
+	return err
+}
+
+// Update ... Call fn with a Tx holding the locks of all shards, and apply
+// all its writes at once if it returns nil, none if it returns an error
+// or a shard rejects one of the writes, whose error is returned then
+// fn must not call the Cache itself, only the Tx
+func (sc *ShardedCache) Update(fn func(tx *Tx) error) error {
+	shards := sc.lockAll()
+	shardOf := func(k string) *Cache { return shardIn(shards, sc.hasher, k) }
+	tx := newTx(func(k string) (interface{}, bool) { return shardOf(k).get(k) }, sc.key)
+	var deleted []string
+	err := func() error {
+		defer func() {
+			for _, c := range shards {
+				c.unlock()
+			}
+		}()
+		if err := fn(tx); err != nil {
+			return err
+		}
+		var err error
+		deleted, err = tx.commit(shardOf)
+		return err
+	}()
+	inv := sc.getInvalidator()
+	for _, k := range deleted {
+		publish(inv, sc.instanceID, Invalidation{Key: k})
+	}
+	return err
+}
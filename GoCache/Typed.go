@@ -0,0 +1,43 @@
+package GoCache
+
+// GetManyTyped ... Get the Data of keys that are present, not Expired and of type T
+// Other keys are left out of the returned map
+func GetManyTyped[T any](c *Cache, keys []string) map[string]T {
+	res := make(map[string]T, len(keys))
+	defer c.unlockForRead(c.lockForRead())
+	for _, key := range keys {
+		k := c.key(key)
+		v, found := c.get(k)
+		c.countRead(k, found)
+		if !found {
+			continue
+		}
+		if t, ok := v.(T); ok {
+			res[key] = t
+		}
+	}
+	return res
+}
+
+// GetAs ... Get the Data as a T, found is false if it is missing,
+// Expired or of another type. Reading needs no allocation
+func GetAs[T any](c *Cache, k string) (T, bool) {
+	v, found := c.Get(k)
+	t, ok := v.(T)
+	return t, found && ok
+}
+
+// GetString ... Get the Data if it is a string
+func (c *Cache) GetString(k string) (string, bool) {
+	return GetAs[string](c, k)
+}
+
+// GetInt64 ... Get the Data if it is an int64
+func (c *Cache) GetInt64(k string) (int64, bool) {
+	return GetAs[int64](c, k)
+}
+
+// GetBytes ... Get the Data if it is a []byte, which is shared with the Cache
+func (c *Cache) GetBytes(k string) ([]byte, bool) {
+	return GetAs[[]byte](c, k)
+}
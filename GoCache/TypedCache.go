@@ -0,0 +1,141 @@
+package GoCache
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+type typedItem[V any] struct {
+	object     V
+	expiration int64
+}
+
+func (item typedItem[V]) expired(now int64) bool {
+	return item.expiration > 0 && now > item.expiration
+}
+
+// TypedCache ... Cache keyed by K holding values of V, so Get
+// needs no type assertion
+type TypedCache[K comparable, V any] struct {
+	defaultExpiration time.Duration
+	items             map[K]typedItem[V]
+	clock             Clock
+	mutex             sync.RWMutex
+	gc                gcRunner
+}
+
+// NewTypedCache ... Create a New TypedCache And goRoutine, of opts only
+// WithClock is read
+func NewTypedCache[K comparable, V any](defaultExpiration, gcInterval time.Duration, opts ...Option) *TypedCache[K, V] {
+	c := &TypedCache[K, V]{
+		defaultExpiration: defaultExpiration,
+		items:             map[K]typedItem[V]{},
+		clock:             SystemClock,
+	}
+	if o := buildOptions(opts); o.Clock != nil {
+		c.clock = o.Clock
+	}
+	c.gc.run = func(stop <-chan struct{}) {
+		runGc(c.clock, gcInterval, stop, nil, func(interval time.Duration) time.Duration {
+			c.DeleteExpired()
+			return interval
+		})
+	}
+	c.gc.start()
+	return c
+}
+
+// Set ... To Set the Data
+func (c *TypedCache[K, V]) Set(k K, v V, d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.set(k, v, d)
+}
+
+func (c *TypedCache[K, V]) set(k K, v V, d time.Duration) {
+	var e int64
+	if d == DefaultExpiration {
+		d = c.defaultExpiration
+	}
+	if d > 0 {
+		e = c.clock.Now().Add(d).UnixNano()
+	}
+	c.items[k] = typedItem[V]{object: v, expiration: e}
+}
+
+// Get ... To Get the Data, the zero V is returned when not found
+func (c *TypedCache[K, V]) Get(k K) (V, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.get(k)
+}
+
+func (c *TypedCache[K, V]) get(k K) (V, bool) {
+	item, found := c.items[k]
+	if !found || item.expired(c.clock.Now().UnixNano()) {
+		var zero V
+		return zero, false
+	}
+	return item.object, true
+}
+
+// Add ... Add Data if it did not Exist yet
+func (c *TypedCache[K, V]) Add(k K, v V, d time.Duration) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, found := c.get(k); found {
+		return fmt.Errorf("item %v: %w", k, ErrKeyExists)
+	}
+	c.set(k, v, d)
+	return nil
+}
+
+// Replace ... Set Data only if it Exists already
+func (c *TypedCache[K, V]) Replace(k K, v V, d time.Duration) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, found := c.get(k); !found {
+		return fmt.Errorf("item %v: %w", k, ErrKeyNotFound)
+	}
+	c.set(k, v, d)
+	return nil
+}
+
+// Delete ... Delete the Data
+func (c *TypedCache[K, V]) Delete(k K) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.items, k)
+}
+
+// DeleteExpired ... Delete all Expired Data
+func (c *TypedCache[K, V]) DeleteExpired() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	now := c.clock.Now().UnixNano()
+	for k, v := range c.items {
+		if v.expired(now) {
+			delete(c.items, k)
+		}
+	}
+}
+
+// Count ... Return Number of Data In Cache
+func (c *TypedCache[K, V]) Count() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return len(c.items)
+}
+
+// Flush ... Flush the Cache
+func (c *TypedCache[K, V]) Flush() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.items = map[K]typedItem[V]{}
+}
+
+// StopGc ... Stop the GC goRoutine, later calls do nothing
+func (c *TypedCache[K, V]) StopGc() {
+	c.gc.stop()
+}
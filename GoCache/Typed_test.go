@@ -0,0 +1,54 @@
+package GoCache
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGetManyTyped(t *testing.T) {
+	c := New(WithNoGC())
+	c.Set("s1", "one", NoExpiration)
+	c.Set("s2", "", NoExpiration)
+	c.Set("int", 3, NoExpiration)
+	c.Set("bytes", []byte("b"), NoExpiration)
+	c.Set("nil", nil, NoExpiration)
+	c.Set("gone", "x", NoExpiration)
+	c.Delete("gone")
+	for _, tc := range []struct {
+		name string
+		keys []string
+		want map[string]string
+	}{
+		{name: "strings", keys: []string{"s1", "s2"}, want: map[string]string{"s1": "one", "s2": ""}},
+		{name: "wrong type", keys: []string{"int", "bytes", "nil"}, want: map[string]string{}},
+		{name: "missing", keys: []string{"none", "gone"}, want: map[string]string{}},
+		{name: "mixed", keys: []string{"s1", "int", "none"}, want: map[string]string{"s1": "one"}},
+		{name: "repeated", keys: []string{"s1", "s1"}, want: map[string]string{"s1": "one"}},
+		{name: "empty", keys: nil, want: map[string]string{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			checkTyped(t, GetManyTyped[string](c, tc.keys), tc.want)
+		})
+	}
+}
+
+func TestGetManyTypedKeyFunc(t *testing.T) {
+	c := New(WithNoGC(), WithKeyFunc(strings.ToLower))
+	c.Set("User:1", "alice", NoExpiration)
+	c.Set("User:2", 2, NoExpiration)
+	// Results are keyed as asked, though the Data is found by canonical key
+	got := GetManyTyped[string](c, []string{"USER:1", "user:1", "user:2", "user:3"})
+	checkTyped(t, got, map[string]string{"USER:1": "alice", "user:1": "alice"})
+}
+
+func checkTyped(t *testing.T, got, want map[string]string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for k, v := range want {
+		if g, ok := got[k]; !ok || g != v {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
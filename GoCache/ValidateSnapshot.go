@@ -0,0 +1,60 @@
+package GoCache
+
+import (
+	"bufio"
+	"io"
+)
+
+// SnapshotInfo ... What ValidateSnapshot found in a snapshot
+type SnapshotInfo struct {
+	// File ... The snapshot is wrapped as SaveToFile writes it, checksum
+	// checked, and Compressed or Encrypted tell how
+	File       bool
+	Compressed bool
+	Encrypted  bool
+	// Version and Codec ... Of the header Save writes, zero and empty for
+	// a stream from before headers
+	Version int
+	Codec   string
+	// Declared ... Items the header announces
+	Declared uint64
+	// Items ... Items decoded, Expired of them already Expired by the
+	// clock of the Cache, Bytes their approximate size
+	Items   int
+	Expired int
+	Bytes   int64
+}
+
+// ValidateSnapshot ... Decode the snapshot in r, a file SaveToFile wrote
+// or a stream of Save, as LoadFile and Load would and report what it
+// holds, without changing the Cache, to check a backup before restoring
+// it. The error is the one Load would fail with: ErrCorruptSnapshot,
+// ErrSnapshotKey, ErrIncompatibleSnapshot or one of the Codec
+func (c *Cache) ValidateSnapshot(r io.Reader) (SnapshotInfo, error) {
+	var info SnapshotInfo
+	br := bufio.NewReader(r)
+	if head, _ := br.Peek(snapshotHeaderSize); len(head) == snapshotHeaderSize && string(head[:len(snapshotMagic)]) == snapshotMagic {
+		flags := head[snapshotHeaderSize-1]
+		info.File = true
+		info.Compressed = flags&flagGzip != 0
+		info.Encrypted = flags&flagEncrypted != 0
+	}
+	now := c.now().UnixNano()
+	err := openFile(c.snapshotKey, func(r io.Reader) error {
+		return decodeSnapshotInfo(r, c.codec, func(items map[string]Item) {
+			for _, item := range items {
+				info.Items++
+				if e := item.Expiration; e > 0 && now > e {
+					info.Expired++
+				}
+				info.Bytes += approxSize(item.Object)
+			}
+		}, &info)
+	})(br)
+	return info, err
+}
+
+// ValidateSnapshot ... ValidateSnapshot with the settings of the shards
+func (sc *ShardedCache) ValidateSnapshot(r io.Reader) (SnapshotInfo, error) {
+	return sc.shardSet()[0].ValidateSnapshot(r)
+}
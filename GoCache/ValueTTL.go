@@ -0,0 +1,38 @@
+package GoCache
+
+import "time"
+
+// TTLer ... Data that knows how long it stays fresh. Set with
+// DefaultExpiration uses CacheTTL instead of the default of the Cache,
+// unless it returns DefaultExpiration too; NoExpiration keeps it forever
+type TTLer interface {
+	CacheTTL() time.Duration
+}
+
+// Expirer ... Data that knows when it goes stale. Set with
+// DefaultExpiration Expires it then, a zero time keeps the default
+type Expirer interface {
+	ExpiresAt() time.Time
+}
+
+// valueTTL ... The duration v asks for when d is DefaultExpiration,
+// d otherwise. MinTTL and MaxTTL still apply
+func (c *Cache) valueTTL(v interface{}, d time.Duration) time.Duration {
+	if d != DefaultExpiration {
+		return d
+	}
+	switch t := v.(type) {
+	case TTLer:
+		return t.CacheTTL()
+	case Expirer:
+		at := t.ExpiresAt()
+		if at.IsZero() {
+			return d
+		}
+		if d = at.Sub(c.now()); d <= 0 {
+			// Already stale, Expire at once instead of never
+			d = time.Nanosecond
+		}
+	}
+	return d
+}
@@ -0,0 +1,68 @@
+package GoCache
+
+import (
+	"context"
+	"sync"
+)
+
+// Warm ... Fill the Cache with loader, running at most concurrency
+// loads at once, and Set each result with DefaultExpiration
+// Return the errors by key, nil if every load worked. Keys not loaded
+// yet when ctx is done fail with ctx.Err()
+func (c *Cache) Warm(ctx context.Context, keys []string, loader func(k string) (interface{}, error), concurrency int) map[string]error {
+	return warm(ctx, keys, loader, concurrency, func(k string, v interface{}) {
+		c.Set(k, v, DefaultExpiration)
+	})
+}
+
+// Warm ... Fill the shards with loader, see Cache.Warm
+func (sc *ShardedCache) Warm(ctx context.Context, keys []string, loader func(k string) (interface{}, error), concurrency int) map[string]error {
+	return warm(ctx, keys, loader, concurrency, func(k string, v interface{}) {
+		sc.Set(k, v, DefaultExpiration)
+	})
+}
+
+func warm(ctx context.Context, keys []string, loader func(string) (interface{}, error), concurrency int, set func(string, interface{})) map[string]error {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	var (
+		mutex sync.Mutex
+		errs  map[string]error
+		wg    sync.WaitGroup
+	)
+	fail := func(k string, err error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if errs == nil {
+			errs = map[string]error{}
+		}
+		errs[k] = err
+	}
+	slots := make(chan struct{}, concurrency)
+	for _, k := range keys {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if err := ctx.Err(); err != nil {
+			fail(k, err)
+			continue
+		}
+		wg.Add(1)
+		go func(k string) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			v, err := loader(k)
+			if err != nil {
+				fail(k, err)
+				return
+			}
+			set(k, v)
+		}(k)
+	}
+	wg.Wait()
+	return errs
+}
@@ -0,0 +1,131 @@
+package GoCache
+
+import (
+	"strings"
+	"sync"
+)
+
+// DefaultWatchBuffer ... Events a watcher channel holds before new ones
+// are dropped
+const DefaultWatchBuffer = 256
+
+// EventType ... What happened to the Data of an Event
+type EventType int
+
+const (
+	EventSet    EventType = iota // Stored by a Set, Add, Increment...
+	EventDelete                  // Deleted by a caller
+	EventExpire                  // Removed by the GC or lazy Expiration
+	EventEvict                   // Removed to stay within the limits of the Cache
+	EventFlush                   // All Data removed, Key is empty
+)
+
+func (t EventType) String() string {
+	switch t {
+	case EventSet:
+		return "set"
+	case EventDelete:
+		return "delete"
+	case EventExpire:
+		return "expire"
+	case EventEvict:
+		return "evict"
+	case EventFlush:
+		return "flush"
+	}
+	return "unknown"
+}
+
+// Event ... A change of the Data at Key, Value is set for EventSet only
+type Event struct {
+	Type  EventType
+	Key   string
+	Value interface{}
+}
+
+// CancelFunc ... Stop a Watch and close its channel
+type CancelFunc func()
+
+type watcher struct {
+	prefix string
+	events chan Event
+}
+
+// Watch ... Return a channel of the Events of keys starting with prefix,
+// all keys if empty, and the func stopping it. Events are sent in order
+// but never waited for: those a full channel cannot take are dropped,
+// so a slow reader misses changes instead of stalling the Cache
+func (c *Cache) Watch(prefix string) (<-chan Event, CancelFunc) {
+	w := &watcher{prefix: prefix, events: make(chan Event, DefaultWatchBuffer)}
+	c.lock()
+	c.watchers = append(c.watchers, w)
+	c.unlock()
+	var once sync.Once
+	return w.events, func() {
+		once.Do(func() {
+			c.lock()
+			defer c.unlock()
+			for i, o := range c.watchers {
+				if o == w {
+					c.watchers = append(c.watchers[:i:i], c.watchers[i+1:]...)
+					break
+				}
+			}
+			if len(c.watchers) == 0 {
+				c.watchers = nil
+			}
+			close(w.events)
+		})
+	}
+}
+
+// notify ... Send an Event to the watchers of k, the caller holds the lock
+func (c *Cache) notify(t EventType, k string, v interface{}) {
+	for _, w := range c.watchers {
+		if t != EventFlush && !strings.HasPrefix(k, w.prefix) {
+			continue
+		}
+		select {
+		case w.events <- Event{Type: t, Key: k, Value: v}:
+		default:
+		}
+	}
+}
+
+// Watch ... Return a channel of the Events of keys starting with prefix
+// in all shards, and the func stopping it. A Flush sends one EventFlush
+// per shard
+func (sc *ShardedCache) Watch(prefix string) (<-chan Event, CancelFunc) {
+	out := make(chan Event, DefaultWatchBuffer)
+	// Reshard refuses to run while the shards have watchers, it must
+	// not swap them before these are there
+	sc.resharding.Lock()
+	defer sc.resharding.Unlock()
+	shards := sc.shardSet()
+	cancels := make([]CancelFunc, len(shards))
+	var wg sync.WaitGroup
+	for i, c := range shards {
+		events, cancel := c.Watch(prefix)
+		cancels[i] = cancel
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for e := range events {
+				select {
+				case out <- e:
+				default:
+				}
+			}
+		}()
+	}
+	var once sync.Once
+	return out, func() {
+		once.Do(func() {
+			for _, cancel := range cancels {
+				cancel()
+			}
+			wg.Wait()
+			close(out)
+		})
+	}
+}
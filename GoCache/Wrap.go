@@ -0,0 +1,103 @@
+package GoCache
+
+import "time"
+
+// Wrapped ... A view of a Cache holding values of V, so callers never
+// assert types. Data of another type Set through the Cache itself reads
+// as missing
+type Wrapped[V any] struct {
+	c *Cache
+}
+
+// Wrap ... Return the view of c holding values of V
+//
+//	users := GoCache.Wrap[*User](c)
+//	u, found := users.Get("42")
+func Wrap[V any](c *Cache) Wrapped[V] {
+	return Wrapped[V]{c}
+}
+
+// Cache ... Return the Cache behind the view
+func (w Wrapped[V]) Cache() *Cache {
+	return w.c
+}
+
+// Get ... Get the Data if it is a V
+func (w Wrapped[V]) Get(k string) (V, bool) {
+	return GetAs[V](w.c, k)
+}
+
+// GetWithExpiration ... Get the Data if it is a V, and the time it Expires
+func (w Wrapped[V]) GetWithExpiration(k string) (V, time.Time, bool) {
+	v, e, found := w.c.GetWithExpiration(k)
+	t, ok := v.(V)
+	return t, e, found && ok
+}
+
+// Set ... Set the Data with Expiration d
+func (w Wrapped[V]) Set(k string, v V, d time.Duration) {
+	w.c.Set(k, v, d)
+}
+
+// Add ... Set the Data if k holds no live Data
+func (w Wrapped[V]) Add(k string, v V, d time.Duration) error {
+	return w.c.Add(k, v, d)
+}
+
+// Replace ... Set the Data if k holds live Data
+func (w Wrapped[V]) Replace(k string, v V, d time.Duration) error {
+	return w.c.Replace(k, v, d)
+}
+
+// Delete ... Delete the Data
+func (w Wrapped[V]) Delete(k string) {
+	w.c.Delete(k)
+}
+
+// Pop ... Get the Data and Delete it in one step, found is false if it
+// is not a V, in which case it is Deleted all the same
+func (w Wrapped[V]) Pop(k string) (V, bool) {
+	v, found := w.c.Pop(k)
+	t, ok := v.(V)
+	return t, found && ok
+}
+
+// LoadOrStore ... Return the live Data at k if it is a V, or Set v and Return it
+func (w Wrapped[V]) LoadOrStore(k string, v V, d time.Duration) (actual V, loaded bool, err error) {
+	a, loaded, err := w.c.LoadOrStore(k, v, d)
+	if err != nil {
+		return actual, false, err
+	}
+	if t, ok := a.(V); ok {
+		return t, loaded, nil
+	}
+	return v, false, nil
+}
+
+// GetOrCompute ... Get the Data, or on a miss Set what loader returns
+// with Expiration d, see Cache.GetOrCompute
+func (w Wrapped[V]) GetOrCompute(k string, loader func() (V, error), d time.Duration) (V, error) {
+	v, err := w.c.GetOrCompute(k, func() (interface{}, error) { return loader() }, d)
+	t, _ := v.(V)
+	return t, err
+}
+
+// GetOrComputeResult ... GetOrCompute caching errors for errTTL, see
+// Cache.GetOrComputeResult
+func (w Wrapped[V]) GetOrComputeResult(k string, loader func() (V, error), d, errTTL time.Duration) (V, error) {
+	v, err := w.c.GetOrComputeResult(k, func() (interface{}, error) { return loader() }, d, errTTL)
+	t, _ := v.(V)
+	return t, err
+}
+
+// Items ... Return the live Data that is a V
+func (w Wrapped[V]) Items() map[string]V {
+	items := w.c.Items()
+	res := make(map[string]V, len(items))
+	for k, item := range items {
+		if t, ok := item.Object.(V); ok {
+			res[k] = t
+		}
+	}
+	return res
+}
@@ -0,0 +1,255 @@
+package bench
+
+import (
+	"GoCache"
+	"flag"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Benchmarks of the single lock Cache against the ShardedCache under
+// parallel Get/Set mixes and GC load, and a stress run to use with the
+// race detector:
+//
+//	go test -run '^$' -bench . ./bench              all benchmarks
+//	go test -run '^$' -bench 'Mix/.*/read90' ./bench  the ones matching a regexp
+//	go test -race -run Stress ./bench -stress 30s     hammer every cache for 30s
+//
+// Keys and random choices are seeded, so runs are comparable
+
+type cache interface {
+	Set(k string, v interface{}, d time.Duration, opts ...GoCache.SetOption)
+	Get(k string) (interface{}, bool)
+	SetMulti(items map[string]interface{}, d time.Duration)
+	Delete(k string)
+	Increment(k string, n int64) (interface{}, error)
+	Items() map[string]GoCache.Item
+	Count() int
+	Flush()
+	DeleteExpired()
+	StopGc()
+}
+
+// getOnlyCache ... Hides GetString from typedGet
+type getOnlyCache struct {
+	cache
+}
+
+var keys = make([]string, 1<<14)
+
+func init() {
+	for i := range keys {
+		keys[i] = strconv.Itoa(i)
+	}
+}
+
+// mix ... Parallel Gets and Sets on a filled cache, one Set every
+// setEvery operations, no Sets if zero
+func mix(c cache, setEvery int) func(b *testing.B) {
+	fill := make(map[string]interface{}, len(keys))
+	for i, k := range keys {
+		fill[k] = i
+	}
+	return func(b *testing.B) {
+		c.SetMulti(fill, GoCache.DefaultExpiration)
+		b.ReportAllocs()
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			i := 0
+			for pb.Next() {
+				k := keys[i%len(keys)]
+				if setEvery > 0 && i%setEvery == 0 {
+					c.Set(k, i, GoCache.DefaultExpiration)
+				} else {
+					c.Get(k)
+				}
+				i++
+			}
+		})
+	}
+}
+
+// gcLoad ... Parallel Gets while half the keys keep Expiring and the
+// GC sweeps every millisecond
+func gcLoad(c cache) func(b *testing.B) {
+	return func(b *testing.B) {
+		b.ReportAllocs()
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			i := 0
+			for pb.Next() {
+				k := keys[i%len(keys)]
+				if i%2 == 0 {
+					c.Set(k, i, time.Millisecond)
+				} else {
+					c.Get(k)
+				}
+				i++
+			}
+		})
+	}
+}
+
+// typedGet ... Parallel reads of string Data through Get and a type
+// assertion or through GetString, if the Cache has one
+func typedGet(c cache) func(b *testing.B) {
+	return func(b *testing.B) {
+		for _, k := range keys {
+			c.Set(k, k, GoCache.DefaultExpiration)
+		}
+		gs, fast := c.(interface {
+			GetString(k string) (string, bool)
+		})
+		b.ReportAllocs()
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			i, n := 0, 0
+			for pb.Next() {
+				k := keys[i%len(keys)]
+				if fast {
+					s, _ := gs.GetString(k)
+					n += len(s)
+				} else if v, found := c.Get(k); found {
+					n += len(v.(string))
+				}
+				i++
+			}
+		})
+	}
+}
+
+// run ... Run fn on a Cache made by newCache as the sub-benchmark
+// name, made only when it runs so idle GCs do not skew the others
+func run(b *testing.B, name string, newCache func() cache, fn func(c cache) func(b *testing.B)) {
+	b.Run(name, func(b *testing.B) {
+		c := newCache()
+		defer c.StopGc()
+		fn(c)(b)
+	})
+}
+
+var (
+	opts   = GoCache.Options{DefaultExpiration: time.Minute, GcInterval: time.Minute}
+	gcOpts = GoCache.Options{DefaultExpiration: time.Minute, GcInterval: time.Millisecond}
+)
+
+func single(o GoCache.Options) func() cache {
+	return func() cache { return GoCache.NewCacheWithOptions(o) }
+}
+
+func sharded(o GoCache.Options) func() cache {
+	return func() cache { return GoCache.NewShardedCache(GoCache.DefaultShards, o) }
+}
+
+// BenchmarkMix ... The single lock Cache against the ShardedCache from
+// all reads to all writes
+func BenchmarkMix(b *testing.B) {
+	for _, m := range []struct {
+		name     string
+		setEvery int
+	}{{"read100", 0}, {"read90", 10}, {"read75", 4}, {"read50", 2}, {"write100", 1}} {
+		setEvery := m.setEvery
+		fn := func(c cache) func(b *testing.B) { return mix(c, setEvery) }
+		run(b, "single/"+m.name, single(opts), fn)
+		run(b, "sharded/"+m.name, sharded(opts), fn)
+	}
+	// Every Set copies the Cache, so only reads are worth measuring
+	lockFreeOpts := opts
+	lockFreeOpts.LockFreeReads = true
+	run(b, "lockfree/read100", single(lockFreeOpts), func(c cache) func(b *testing.B) { return mix(c, 0) })
+}
+
+// BenchmarkTypedGet ... Get and a type assertion against GetString
+func BenchmarkTypedGet(b *testing.B) {
+	run(b, "get-assert", func() cache { return getOnlyCache{GoCache.NewCacheWithOptions(opts)} }, typedGet)
+	run(b, "get-string", single(opts), typedGet)
+}
+
+// BenchmarkGC ... Reads while the GC sweeps every millisecond
+func BenchmarkGC(b *testing.B) {
+	incOpts := gcOpts
+	incOpts.SweepBatch = 1024
+	run(b, "single", single(gcOpts), gcLoad)
+	run(b, "single-incremental", single(incOpts), gcLoad)
+	run(b, "sharded", sharded(gcOpts), gcLoad)
+}
+
+// stress ... Run every kind of operation on c from many goRoutines for
+// d, failing on values that could not have been Set
+func stress(name string, c cache, d time.Duration) error {
+	deadline := time.Now().Add(d)
+	var wg sync.WaitGroup
+	errs := make(chan error, 1)
+	for g := 0; g < 16; g++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(seed))
+			for time.Now().Before(deadline) {
+				k := keys[r.Intn(256)]
+				switch op := r.Intn(100); {
+				case op < 50:
+					if v, found := c.Get(k); found {
+						if _, ok := v.(int); !ok {
+							select {
+							case errs <- fmt.Errorf("%s: item %s holds %T", name, k, v):
+							default:
+							}
+							return
+						}
+					}
+				case op < 80:
+					c.Set(k, r.Intn(1000), time.Duration(r.Intn(5))*time.Millisecond)
+				case op < 90:
+					c.Increment(k, 1)
+				case op < 95:
+					c.Delete(k)
+				case op < 98:
+					c.DeleteExpired()
+				case op < 99:
+					c.Items()
+					c.Count()
+				default:
+					c.Flush()
+				}
+			}
+		}(int64(g))
+	}
+	wg.Wait()
+	select {
+	case err := <-errs:
+		return err
+	default:
+		return nil
+	}
+}
+
+var stressFor = flag.Duration("stress", time.Second, "how long TestStress hammers each cache")
+
+// TestStress ... Hammer every kind of Cache, longer with -stress and
+// best under -race
+func TestStress(t *testing.T) {
+	if testing.Short() {
+		t.Skip("stress run skipped in short mode")
+	}
+	lockFreeOpts := gcOpts
+	lockFreeOpts.LockFreeReads = true
+	for _, s := range []struct {
+		name string
+		c    cache
+	}{
+		{"single", GoCache.NewCacheWithOptions(gcOpts)},
+		{"sharded", GoCache.NewShardedCache(16, gcOpts)},
+		{"lockfree", GoCache.NewCacheWithOptions(lockFreeOpts)},
+	} {
+		err := stress(s.name, s.c, *stressFor)
+		s.c.StopGc()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
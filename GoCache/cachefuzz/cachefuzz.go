@@ -0,0 +1,279 @@
+// Package cachefuzz checks GoCache against a model under random
+// operations, for go test -fuzz and for stress runs
+//
+// Check plays a byte string as a sequence of Set, Get, Add, Replace,
+// Delete, DeleteExpired, clock moves and Save/Load round trips on a Cache
+// with a fake clock, next to a plain map of what it must hold, and
+// reports the first broken invariant: a value that should have Expired
+// or a wrong one, Add and Replace disagreeing with the map, CountValid
+// off, or a snapshot that does not load back the same. Any byte string
+// is a valid sequence, so a fuzz target is three lines, the one of this
+// package runs with go test -fuzz=FuzzCache ./cachefuzz:
+//
+//	func FuzzCache(f *testing.F) {
+//		f.Fuzz(func(t *testing.T, data []byte) {
+//			if err := cachefuzz.Check(data); err != nil {
+//				t.Fatal(err)
+//			}
+//		})
+//	}
+//
+// Concurrent runs random operations on many goRoutines at once, best
+// under the race detector, checking what holds whatever the interleaving
+package cachefuzz
+
+import (
+	"GoCache"
+	"GoCache/clocktest"
+	"bytes"
+	"fmt"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Operations of a sequence, one per 4 bytes: op, key, value, ttl
+const (
+	opSet = iota
+	opGet
+	opAdd
+	opReplace
+	opDelete
+	opAdvance
+	opDeleteExpired
+	opRoundTrip
+	opCount
+)
+
+// Keys of a sequence, few so that operations meet
+const keys = 8
+
+// configs ... The Options the first byte of a sequence picks from
+var configs = []GoCache.Options{
+	{},
+	{ExpirationIndex: true},
+	{LazyExpiration: true},
+	{PrefixIndex: true, ExpirationIndex: true},
+}
+
+// entry ... What the model holds for a key
+type entry struct {
+	value int
+	exp   int64 // UnixNano, zero when it never Expires
+}
+
+// model ... The Data a Cache must hold
+type model struct {
+	clock   *clocktest.FakeClock
+	entries map[string]entry
+}
+
+// live ... Return the entry of k if it has not Expired, by the rule of
+// the Cache: Expired once the clock is past exp
+func (m *model) live(k string) (entry, bool) {
+	e, ok := m.entries[k]
+	if !ok || e.exp > 0 && m.clock.Now().UnixNano() > e.exp {
+		return entry{}, false
+	}
+	return e, true
+}
+
+func (m *model) set(k string, v int, d time.Duration) {
+	e := entry{value: v}
+	if d > 0 {
+		e.exp = m.clock.Now().Add(d).UnixNano()
+	}
+	m.entries[k] = e
+}
+
+func (m *model) count() int {
+	n := 0
+	for k := range m.entries {
+		if _, ok := m.live(k); ok {
+			n++
+		}
+	}
+	return n
+}
+
+// ttl ... The duration byte b stands for: mostly a few seconds, some
+// never Expiring
+func ttl(b byte) time.Duration {
+	if b >= 200 {
+		return GoCache.NoExpiration
+	}
+	return time.Duration(b%10+1) * time.Second
+}
+
+// Check ... Play data as a sequence of operations, Return the first
+// invariant it breaks, nil if none
+func Check(data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+	config := int(data[0]) % len(configs)
+	opts := configs[config]
+	clock := clocktest.New(time.Unix(1000, 0))
+	opts.Clock = clock
+	opts.GcInterval = time.Hour
+	opts.NoGC = true
+	c := GoCache.NewCacheWithOptions(opts)
+	defer c.Close()
+	m := &model{clock: clock, entries: map[string]entry{}}
+	data = data[1:]
+	for i := 0; i+4 <= len(data); i += 4 {
+		op, k, v, d := int(data[i])%(opCount+1), fmt.Sprint("k", data[i+1]%keys), int(data[i+2]), ttl(data[i+3])
+		if err := step(c, m, op, k, v, d); err != nil {
+			return fmt.Errorf("op %d with config %d: %w", i/4, config, err)
+		}
+	}
+	return nil
+}
+
+// step ... Apply one operation to c and m and check them
+func step(c *GoCache.Cache, m *model, op int, k string, v int, d time.Duration) error {
+	switch op {
+	case opSet:
+		c.Set(k, v, d)
+		m.set(k, v, d)
+	case opGet:
+		got, found := c.Get(k)
+		want, ok := m.live(k)
+		if found != ok || found && got != want.value {
+			return fmt.Errorf("Get(%s) = %v, %v, want %v, %v", k, got, found, want.value, ok)
+		}
+		if !ok {
+			// A lazily Expired key may have been Deleted by the Get
+			delete(m.entries, k)
+		}
+	case opAdd:
+		err := c.Add(k, v, d)
+		if _, ok := m.live(k); ok != (err != nil) {
+			return fmt.Errorf("Add(%s) = %v with live %v", k, err, ok)
+		}
+		if err == nil {
+			m.set(k, v, d)
+		}
+	case opReplace:
+		err := c.Replace(k, v, d)
+		if _, ok := m.live(k); ok != (err == nil) {
+			return fmt.Errorf("Replace(%s) = %v with live %v", k, err, ok)
+		}
+		if err == nil {
+			m.set(k, v, d)
+		}
+	case opDelete:
+		c.Delete(k)
+		delete(m.entries, k)
+	case opAdvance:
+		m.clock.Add(time.Duration(v%4) * time.Second)
+	case opDeleteExpired:
+		c.DeleteExpired()
+		for k := range m.entries {
+			if _, ok := m.live(k); !ok {
+				delete(m.entries, k)
+			}
+		}
+		if n := c.Count(); n != len(m.entries) {
+			return fmt.Errorf("Count() = %d after DeleteExpired, want %d", n, len(m.entries))
+		}
+	case opRoundTrip:
+		if err := roundTrip(c, m.clock); err != nil {
+			return err
+		}
+	case opCount:
+		if n, want := c.CountValid(), m.count(); n != want {
+			return fmt.Errorf("CountValid() = %d, want %d", n, want)
+		}
+	}
+	return nil
+}
+
+// roundTrip ... Check that a Save of c Loads into an empty Cache on the
+// same clock as the same live Data
+func roundTrip(c *GoCache.Cache, clock GoCache.Clock) error {
+	var buf bytes.Buffer
+	if err := c.Save(&buf); err != nil {
+		return fmt.Errorf("Save: %w", err)
+	}
+	loaded := GoCache.NewCacheWithOptions(GoCache.Options{GcInterval: time.Hour, NoGC: true, Clock: clock})
+	defer loaded.Close()
+	if err := loaded.Load(&buf); err != nil {
+		return fmt.Errorf("Load: %w", err)
+	}
+	want, got := c.Items(), loaded.Items()
+	for k, w := range want {
+		g, ok := got[k]
+		if !ok || g.Object != w.Object || g.Expiration != w.Expiration {
+			return fmt.Errorf("round trip of %s = %v, %v, want %v", k, g, ok, w)
+		}
+	}
+	if len(got) != len(want) {
+		return fmt.Errorf("round trip holds %d Data, want %d", len(got), len(want))
+	}
+	return nil
+}
+
+// Concurrent ... Run ops random operations from seed spread over
+// workers goRoutines on one Cache made with opts, Return the first
+// invariant broken: a Get of Data past its Expiration, Stats not adding
+// up to the reads made, CountValid disagreeing with Items once all
+// stopped, or a snapshot not loading back the same
+func Concurrent(opts GoCache.Options, seed int64, workers, ops int) error {
+	clock := clocktest.New(time.Unix(1000, 0))
+	opts.Clock = clock
+	if opts.GcInterval <= 0 {
+		opts.GcInterval = time.Second
+	}
+	c := GoCache.NewCacheWithOptions(opts)
+	defer c.Close()
+	var reads atomic.Uint64
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for w := 0; w < workers; w++ {
+		rnd := rand.New(rand.NewSource(seed + int64(w)))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < ops/workers; i++ {
+				k := fmt.Sprint("k", rnd.Intn(keys))
+				switch rnd.Intn(8) {
+				case 0, 1:
+					c.Set(k, rnd.Int(), ttl(byte(rnd.Intn(256))))
+				case 2:
+					c.Add(k, rnd.Int(), ttl(byte(rnd.Intn(256))))
+				case 3:
+					c.Replace(k, rnd.Int(), ttl(byte(rnd.Intn(256))))
+				case 4:
+					c.Delete(k)
+				case 5:
+					clock.Add(time.Duration(rnd.Intn(3)) * time.Second)
+				default:
+					// The clock only moves forward, so Data live at the
+					// Get was live before it
+					before := clock.Now()
+					_, exp, found := c.GetWithExpiration(k)
+					reads.Add(1)
+					if found && !exp.IsZero() && exp.Before(before) {
+						errs <- fmt.Errorf("Get(%s) returned Data Expired at %v before %v", k, exp, before)
+						return
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	if err := <-errs; err != nil {
+		return err
+	}
+	s := c.Stats()
+	if s.Hits+s.Misses != reads.Load() {
+		return fmt.Errorf("Stats Hits %d + Misses %d, want %d reads", s.Hits, s.Misses, reads.Load())
+	}
+	if n, items := c.CountValid(), len(c.Items()); n != items {
+		return fmt.Errorf("CountValid() = %d, Items holds %d", n, items)
+	}
+	return roundTrip(c, clock)
+}
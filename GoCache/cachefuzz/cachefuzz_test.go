@@ -0,0 +1,55 @@
+package cachefuzz_test
+
+import (
+	"GoCache"
+	"GoCache/cachefuzz"
+	"math/rand"
+	"testing"
+)
+
+// FuzzCache ... go test -fuzz=FuzzCache ./cachefuzz
+func FuzzCache(f *testing.F) {
+	f.Add([]byte{})
+	// Set, advance past the ttl, Get, DeleteExpired, round trip, Count
+	f.Add([]byte{0, 0, 1, 1, 5, 5, 1, 2, 3, 1, 1, 0, 0, 6, 0, 0, 0, 7, 0, 0, 0, 8, 0, 0, 0})
+	// The same on every config, with Add and Replace meeting a live key
+	for config := byte(1); config < 4; config++ {
+		f.Add([]byte{config, 0, 2, 7, 200, 2, 2, 8, 3, 3, 2, 9, 1, 1, 2, 0, 4, 2, 0, 0, 8, 0, 0, 0})
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if err := cachefuzz.Check(data); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+// TestCheck ... Random sequences, for runs without -fuzz
+func TestCheck(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 3000; i++ {
+		data := make([]byte, 1+4*rnd.Intn(60))
+		rnd.Read(data)
+		if err := cachefuzz.Check(data); err != nil {
+			t.Fatalf("sequence %d %v: %v", i, data, err)
+		}
+	}
+}
+
+func TestConcurrent(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		opts GoCache.Options
+	}{
+		{"default", GoCache.Options{}},
+		{"expiration-index", GoCache.Options{ExpirationIndex: true}},
+		{"lazy", GoCache.Options{LazyExpiration: true}},
+		{"bounded", GoCache.Options{MaxEntries: 4}},
+		{"lock-free", GoCache.Options{LockFreeReads: true}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := cachefuzz.Concurrent(tt.opts, 1, 8, 4000); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+// Package cacherpc shares one GoCache with other services over the
+// network: a Service wrapping a Cache, and a Client for it
+//
+// The Service and Client speak net/rpc from the standard library, its
+// gob encoding over TCP, so no generated code or third party dependency
+// is needed, and only Go net/rpc clients can call it. Values are bytes,
+// the way they cross the network
+package cacherpc
+
+import (
+	"GoCache"
+	"fmt"
+	"net"
+	"net/rpc"
+	"time"
+)
+
+// ServiceName ... Name the Service is registered under
+const ServiceName = "Cache"
+
+// GetRequest ... Arguments of Get
+type GetRequest struct {
+	Key string
+}
+
+// GetReply ... Result of Get
+type GetReply struct {
+	Value []byte
+	Found bool
+}
+
+// SetRequest ... Arguments of Set, TTL as given to Cache.Set
+type SetRequest struct {
+	Key   string
+	Value []byte
+	TTL   time.Duration
+}
+
+// DeleteRequest ... Arguments of Delete
+type DeleteRequest struct {
+	Key string
+}
+
+// Empty ... Arguments or result of calls that need none
+type Empty struct{}
+
+// Service ... The RPC methods over one Cache
+type Service struct {
+	cache *GoCache.Cache
+}
+
+// NewService ... Create the Service of c
+func NewService(c *GoCache.Cache) *Service {
+	return &Service{cache: c}
+}
+
+// Get ... Get the Data of req.Key, it must be []byte or string
+func (s *Service) Get(req *GetRequest, reply *GetReply) error {
+	v, found := s.cache.Get(req.Key)
+	if !found {
+		*reply = GetReply{}
+		return nil
+	}
+	switch x := v.(type) {
+	case []byte:
+		*reply = GetReply{Value: x, Found: true}
+	case string:
+		*reply = GetReply{Value: []byte(x), Found: true}
+	default:
+		return fmt.Errorf("item %s of type %T is not bytes", req.Key, v)
+	}
+	return nil
+}
+
+// Set ... Set req.Value with Expiration req.TTL
+func (s *Service) Set(req *SetRequest, _ *Empty) error {
+	s.cache.Set(req.Key, req.Value, req.TTL)
+	return nil
+}
+
+// Delete ... Delete the Data of req.Key
+func (s *Service) Delete(req *DeleteRequest, _ *Empty) error {
+	s.cache.Delete(req.Key)
+	return nil
+}
+
+// Stats ... Return the Stats of the Cache
+func (s *Service) Stats(_ *Empty, reply *GoCache.Stats) error {
+	*reply = s.cache.Stats()
+	return nil
+}
+
+// Flush ... Flush the Cache
+func (s *Service) Flush(_ *Empty, _ *Empty) error {
+	s.cache.Flush()
+	return nil
+}
+
+// NewServer ... Return an rpc.Server with the Service of c registered
+func NewServer(c *GoCache.Cache) *rpc.Server {
+	srv := rpc.NewServer()
+	// Only fails for a Service without exported methods
+	if err := srv.RegisterName(ServiceName, NewService(c)); err != nil {
+		panic(err)
+	}
+	return srv
+}
+
+// Serve ... Serve c to the connections accepted on l until l is closed
+func Serve(l net.Listener, c *GoCache.Cache) {
+	NewServer(c).Accept(l)
+}
+
+// Client ... Talks to a Service over one connection, safe for
+// concurrent use
+type Client struct {
+	rpc *rpc.Client
+}
+
+// Dial ... Connect to the Service at addr
+func Dial(network, addr string) (*Client, error) {
+	c, err := rpc.Dial(network, addr)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{rpc: c}, nil
+}
+
+// NewClient ... Talk to the Service on conn
+func NewClient(conn net.Conn) *Client {
+	return &Client{rpc: rpc.NewClient(conn)}
+}
+
+// Get ... Get the Data of k
+func (c *Client) Get(k string) ([]byte, bool, error) {
+	var reply GetReply
+	if err := c.rpc.Call(ServiceName+".Get", &GetRequest{Key: k}, &reply); err != nil {
+		return nil, false, err
+	}
+	return reply.Value, reply.Found, nil
+}
+
+// Set ... Set v with Expiration d
+func (c *Client) Set(k string, v []byte, d time.Duration) error {
+	return c.rpc.Call(ServiceName+".Set", &SetRequest{Key: k, Value: v, TTL: d}, &Empty{})
+}
+
+// Delete ... Delete the Data of k
+func (c *Client) Delete(k string) error {
+	return c.rpc.Call(ServiceName+".Delete", &DeleteRequest{Key: k}, &Empty{})
+}
+
+// Stats ... Return the Stats of the remote Cache
+func (c *Client) Stats() (GoCache.Stats, error) {
+	var s GoCache.Stats
+	err := c.rpc.Call(ServiceName+".Stats", &Empty{}, &s)
+	return s, err
+}
+
+// Flush ... Flush the remote Cache
+func (c *Client) Flush() error {
+	return c.rpc.Call(ServiceName+".Flush", &Empty{}, &Empty{})
+}
+
+// Close ... Close the connection
+func (c *Client) Close() error {
+	return c.rpc.Close()
+}
+
+// Tier ... Return c as a GoCache.Tier, for TieredCache or ClusterCache
+// Set takes []byte or string values
+func (c *Client) Tier() GoCache.Tier {
+	return clientTier{c}
+}
+
+type clientTier struct {
+	c *Client
+}
+
+func (t clientTier) Get(k string) (interface{}, bool, error) {
+	v, found, err := t.c.Get(k)
+	if !found {
+		// A nil interface, not a nil []byte
+		return nil, false, err
+	}
+	return v, true, nil
+}
+
+func (t clientTier) Set(k string, v interface{}, d time.Duration) error {
+	switch x := v.(type) {
+	case []byte:
+		return t.c.Set(k, x, d)
+	case string:
+		return t.c.Set(k, []byte(x), d)
+	}
+	return fmt.Errorf("item %s of type %T is not bytes", k, v)
+}
+
+func (t clientTier) Delete(k string) error {
+	return t.c.Delete(k)
+}
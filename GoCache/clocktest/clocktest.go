@@ -0,0 +1,119 @@
+// Package clocktest provides a GoCache.Clock that only moves when told,
+// for testing Expiration and the GC loop without sleeping
+package clocktest
+
+import (
+	"GoCache"
+	"sync"
+	"time"
+)
+
+// FakeClock ... Clock whose time changes only through Add and Set
+type FakeClock struct {
+	mutex   sync.Mutex
+	now     time.Time
+	tickers []*fakeTicker
+}
+
+// New ... Create a FakeClock reading now
+func New(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now ... Return the time of the clock
+func (f *FakeClock) Now() time.Time {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.now
+}
+
+// Add ... Move the clock forward by d and fire the tickers that are due
+func (f *FakeClock) Add(d time.Duration) {
+	f.mutex.Lock()
+	f.now = f.now.Add(d)
+	now := f.now
+	tickers := append([]*fakeTicker(nil), f.tickers...)
+	f.mutex.Unlock()
+	for _, t := range tickers {
+		t.fire(now)
+	}
+}
+
+// Set ... Move the clock to now and fire the tickers that are due
+func (f *FakeClock) Set(now time.Time) {
+	f.Add(now.Sub(f.Now()))
+}
+
+// NewTicker ... Create a ticker driven by Add and Set
+func (f *FakeClock) NewTicker(d time.Duration) GoCache.Ticker {
+	if d <= 0 {
+		panic("clocktest: non-positive interval for NewTicker")
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	t := &fakeTicker{clock: f, c: make(chan time.Time, 1), period: d, next: f.now.Add(d)}
+	f.tickers = append(f.tickers, t)
+	return t
+}
+
+// BlockUntil ... Wait until n tickers are running, so a test knows the
+// GC loop has started before it moves the clock
+func (f *FakeClock) BlockUntil(n int) {
+	for {
+		f.mutex.Lock()
+		running := len(f.tickers)
+		f.mutex.Unlock()
+		if running >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+type fakeTicker struct {
+	clock  *FakeClock
+	c      chan time.Time
+	mutex  sync.Mutex
+	period time.Duration
+	next   time.Time
+}
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.c
+}
+
+// fire ... Send one tick if due, like time.Ticker it drops ticks
+// the reader is too slow for
+func (t *fakeTicker) fire(now time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if now.Before(t.next) {
+		return
+	}
+	for !now.Before(t.next) {
+		t.next = t.next.Add(t.period)
+	}
+	select {
+	case t.c <- now:
+	default:
+	}
+}
+
+func (t *fakeTicker) Reset(d time.Duration) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.period = d
+	t.next = t.clock.Now().Add(d)
+}
+
+func (t *fakeTicker) Stop() {
+	f := t.clock
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	for i, other := range f.tickers {
+		if other == t {
+			f.tickers = append(f.tickers[:i], f.tickers[i+1:]...)
+			return
+		}
+	}
+}
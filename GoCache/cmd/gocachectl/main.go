@@ -0,0 +1,266 @@
+package main
+
+import (
+	"GoCache"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// gocachectl ... Operate a running Cache through the admin endpoint of
+// GoCache.CacheHandler:
+//
+//	gocachectl -addr http://host:8080/cache dump > cache.jsonl
+//	gocachectl dump -snapshot -o cache.snap
+//	gocachectl restore cache.snap              snapshot file, loaded Data wins
+//	gocachectl restore -policy keep cache.snap
+//	gocachectl restore -dry-run cache.snap     check it, restoring nothing
+//	gocachectl restore cache.jsonl             JSON lines, by the extension
+//	gocachectl keys 'user:*'
+//	gocachectl stats
+//	gocachectl top -by size -n 20
+//	gocachectl victims -n 20
+//
+// -addr defaults to $GOCACHE_ADDR, then http://localhost:8080
+
+const usage = `usage: gocachectl [-addr url] <command> [args]
+
+commands:
+  dump [-snapshot] [-o file]           write the Data as JSON lines, or a snapshot file
+  restore [-policy p] [-jsonl] [-dry-run] file
+                                       load a snapshot file or JSON lines, - for stdin
+  keys [pattern]                       list the live keys, matching a glob if given
+  stats                                show the Stats of the Cache
+  top [-by hits|size] [-n count]       list the keys hit most, or the largest
+  victims [-n count]                   list the next keys to be evicted
+`
+
+var client = &http.Client{Timeout: 5 * time.Minute}
+
+func main() {
+	addr := flag.String("addr", defaultAddr(), "base URL of the admin endpoint")
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	base := strings.TrimSuffix(*addr, "/")
+	args := flag.Args()[1:]
+	var err error
+	switch flag.Arg(0) {
+	case "dump":
+		err = dump(base, args)
+	case "restore":
+		err = restore(base, args)
+	case "keys":
+		err = keys(base, args)
+	case "stats":
+		err = stats(base)
+	case "top":
+		err = top(base, args)
+	case "victims":
+		err = victims(base, args)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "gocachectl:", err)
+		os.Exit(1)
+	}
+}
+
+func defaultAddr() string {
+	if addr := os.Getenv("GOCACHE_ADDR"); addr != "" {
+		return addr
+	}
+	return "http://localhost:8080"
+}
+
+func dump(base string, args []string) error {
+	fs := flag.NewFlagSet("dump", flag.ExitOnError)
+	snapshot := fs.Bool("snapshot", false, "write a snapshot file instead of JSON lines")
+	out := fs.String("o", "-", "file to write, - for stdout")
+	fs.Parse(args)
+	path := "/dump"
+	if *snapshot {
+		path = "/snapshot"
+	}
+	res, err := do(http.MethodGet, base+path, "", nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if *out == "-" {
+		_, err = io.Copy(os.Stdout, res.Body)
+		return err
+	}
+	// Write next to the file and rename, so a failed dump keeps the old one
+	tmp := *out + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(f, res.Body); err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, *out)
+}
+
+func restore(base string, args []string) error {
+	fs := flag.NewFlagSet("restore", flag.ExitOnError)
+	policy := fs.String("policy", "loaded", "who wins on keys the Cache holds: keep, loaded or newer")
+	jsonl := fs.Bool("jsonl", false, "the file is JSON lines, the default for .jsonl files")
+	dryRun := fs.Bool("dry-run", false, "check the snapshot file and show what it holds, restoring nothing")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		return fmt.Errorf("restore takes one file")
+	}
+	file := fs.Arg(0)
+	var body io.Reader = os.Stdin
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		body = f
+	}
+	contentType := "application/octet-stream"
+	if *jsonl || strings.HasSuffix(file, ".jsonl") {
+		contentType = "application/x-ndjson"
+	}
+	if *dryRun {
+		res, err := do(http.MethodPost, base+"/restore?dry_run=1", contentType, body)
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+		var info GoCache.SnapshotInfo
+		if err := json.NewDecoder(res.Body).Decode(&info); err != nil {
+			return err
+		}
+		fmt.Printf("format     version %d, codec %q, file %v, compressed %v, encrypted %v\n",
+			info.Version, info.Codec, info.File, info.Compressed, info.Encrypted)
+		fmt.Printf("items      %d of %d declared, %d expired, %d bytes\n", info.Items, info.Declared, info.Expired, info.Bytes)
+		return nil
+	}
+	res, err := do(http.MethodPost, base+"/restore?policy="+url.QueryEscape(*policy), contentType, body)
+	if err != nil {
+		return err
+	}
+	return res.Body.Close()
+}
+
+func keys(base string, args []string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("keys takes at most one pattern")
+	}
+	u := base + "/keys"
+	if len(args) == 1 {
+		u += "?match=" + url.QueryEscape(args[0])
+	}
+	var ks []string
+	if err := getJSON(u, &ks); err != nil {
+		return err
+	}
+	for _, k := range ks {
+		fmt.Println(k)
+	}
+	return nil
+}
+
+func stats(base string) error {
+	var s GoCache.Stats
+	if err := getJSON(base+"/stats", &s); err != nil {
+		return err
+	}
+	fmt.Printf("items      %d\n", s.Items)
+	fmt.Printf("bytes      %d\n", s.Bytes)
+	fmt.Printf("hits       %d\n", s.Hits)
+	fmt.Printf("misses     %d\n", s.Misses)
+	fmt.Printf("hit ratio  %.3f\n", s.HitRatio())
+	fmt.Printf("sets       %d\n", s.Sets)
+	fmt.Printf("expired    %d\n", s.Expired)
+	fmt.Printf("evictions  %d\n", s.Evictions)
+	fmt.Printf("rejected   %d\n", s.Rejected)
+	fmt.Printf("gc sweeps  %d (last %v, total %v)\n", s.GcSweeps, s.LastGcDuration, s.TotalGcTime)
+	return nil
+}
+
+func top(base string, args []string) error {
+	fs := flag.NewFlagSet("top", flag.ExitOnError)
+	by := fs.String("by", "hits", "rank by hits or size")
+	n := fs.Int("n", 10, "number of keys")
+	fs.Parse(args)
+	var ks []GoCache.KeyStat
+	u := fmt.Sprintf("%s/top?by=%s&n=%d", base, url.QueryEscape(*by), *n)
+	if err := getJSON(u, &ks); err != nil {
+		return err
+	}
+	fmt.Printf("%10s %10s  %s\n", "hits", "bytes", "key")
+	for _, k := range ks {
+		fmt.Printf("%10d %10d  %s\n", k.Hits, k.Size, k.Key)
+	}
+	return nil
+}
+
+func victims(base string, args []string) error {
+	fs := flag.NewFlagSet("victims", flag.ExitOnError)
+	n := fs.Int("n", 10, "number of keys")
+	fs.Parse(args)
+	var keys []string
+	if err := getJSON(fmt.Sprintf("%s/victims?n=%d", base, *n), &keys); err != nil {
+		return err
+	}
+	for _, k := range keys {
+		fmt.Println(k)
+	}
+	return nil
+}
+
+func getJSON(u string, v interface{}) error {
+	res, err := do(http.MethodGet, u, "", nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+// do ... Send the request, turning replies other than 2xx into errors
+// carrying the message of the server
+func do(method, u, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequest(method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 4096))
+		res.Body.Close()
+		return nil, fmt.Errorf("%s %s: %s: %s", method, u, res.Status, bytes.TrimSpace(msg))
+	}
+	return res, nil
+}
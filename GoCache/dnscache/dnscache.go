@@ -0,0 +1,106 @@
+// Package dnscache caches DNS lookups in a GoCache, so hot names are
+// resolved once per TTL instead of on every dial
+//
+//	r := dnscache.New(c, dnscache.Config{TTL: time.Minute, ErrTTL: 5 * time.Second})
+//	addrs, err := r.LookupHost(ctx, "example.com")
+//
+// Lookups go through GoCache.Cache.GetOrComputeResult: concurrent misses
+// on a name share one lookup, made with the context of the first, and a
+// failed lookup is cached for ErrTTL so a failing DNS server is not asked
+// on every miss
+package dnscache
+
+import (
+	"GoCache"
+	"context"
+	"net"
+	"time"
+)
+
+// keyPrefix ... Prefix of the cache keys of lookups
+const keyPrefix = "dnscache:"
+
+// Lookuper ... The lookups a Resolver caches, as *net.Resolver has them
+type Lookuper interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// Config ... Settings of a Resolver
+type Config struct {
+	// Resolver ... Lookups to cache, net.DefaultResolver if nil
+	Resolver Lookuper
+	// TTL ... Expiration of answers, GoCache.DefaultExpiration uses the
+	// one of the cache. DNS record TTLs are not known to net.Resolver
+	TTL time.Duration
+	// ErrTTL ... Expiration of failed lookups, zero caches none
+	ErrTTL time.Duration
+}
+
+// Resolver ... Caching wrapper of a Lookuper
+type Resolver struct {
+	c   *GoCache.Cache
+	cfg Config
+}
+
+// New ... Return a Resolver caching lookups in c
+func New(c *GoCache.Cache, cfg Config) *Resolver {
+	if cfg.Resolver == nil {
+		cfg.Resolver = net.DefaultResolver
+	}
+	return &Resolver{c: c, cfg: cfg}
+}
+
+// LookupHost ... LookupHost of the Resolver, from the cache when it can
+func (r *Resolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	v, err := r.c.GetOrComputeResult(keyPrefix+"host:"+host, func() (interface{}, error) {
+		return r.cfg.Resolver.LookupHost(ctx, host)
+	}, r.cfg.TTL, r.cfg.ErrTTL)
+	if err != nil {
+		return nil, err
+	}
+	addrs, _ := v.([]string)
+	return append([]string(nil), addrs...), nil
+}
+
+// LookupIPAddr ... LookupIPAddr of the Resolver, from the cache when it
+// can
+func (r *Resolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	v, err := r.c.GetOrComputeResult(keyPrefix+"ip:"+host, func() (interface{}, error) {
+		return r.cfg.Resolver.LookupIPAddr(ctx, host)
+	}, r.cfg.TTL, r.cfg.ErrTTL)
+	if err != nil {
+		return nil, err
+	}
+	addrs, _ := v.([]net.IPAddr)
+	return append([]net.IPAddr(nil), addrs...), nil
+}
+
+// DialContext ... Dial address, a host:port, at the first address of its
+// host that answers, for http.Transport.DialContext and the like
+func (r *Resolver) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	if net.ParseIP(host) != nil {
+		var d net.Dialer
+		return d.DialContext(ctx, network, address)
+	}
+	addrs, err := r.LookupHost(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	var d net.Dialer
+	for _, addr := range addrs {
+		conn, dialErr := d.DialContext(ctx, network, net.JoinHostPort(addr, port))
+		if dialErr == nil {
+			return conn, nil
+		}
+		err = dialErr
+	}
+	if err == nil {
+		err = &net.DNSError{Err: "no addresses", Name: host}
+	}
+	return nil, err
+}
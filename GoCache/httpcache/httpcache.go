@@ -0,0 +1,283 @@
+// Package httpcache caches the responses of an http.Handler in a GoCache,
+// keyed by method, URL and chosen request headers
+//
+//	h = httpcache.Middleware(c, httpcache.Config{TTL: time.Minute})(h)
+//
+// Transport does the same for the responses an http.Client receives.
+//
+// Only GET and HEAD are cached. Cache-Control is honored where a shared
+// cache would: a request with no-store bypasses the cache, no-cache skips
+// the lookup but stores the fresh response; a response with no-store,
+// no-cache, private, Vary: * or Set-Cookie is not stored, and s-maxage or
+// max-age replace the TTL. A response to a request with Authorization is
+// stored and served for one only if it says public, s-maxage or
+// must-revalidate, or Config.Headers tells the credentials apart. The
+// request headers a response Varies on are part of its key. Responses
+// carry X-Cache: HIT or MISS
+package httpcache
+
+import (
+	"GoCache"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultMaxBodyBytes ... Largest body stored when Config.MaxBodyBytes is zero
+const DefaultMaxBodyBytes = 1 << 20
+
+// keyPrefix ... Prefix of the cache keys of responses
+const keyPrefix = "httpcache:"
+
+// Config ... Settings of Middleware
+type Config struct {
+	// TTL ... Expiration of stored responses without max-age,
+	// GoCache.DefaultExpiration uses the one of the cache
+	TTL time.Duration
+	// Headers ... Request headers that tell responses apart, like
+	// Accept-Encoding or Authorization, on top of those a response Varies
+	// on. With Authorization each user has responses of their own
+	Headers []string
+	// MaxBodyBytes ... Larger responses are served but not stored,
+	// DefaultMaxBodyBytes if zero
+	MaxBodyBytes int
+}
+
+// response ... What is stored for one request
+type response struct {
+	Status int
+	Header http.Header
+	Body   []byte
+}
+
+// Middleware ... Return a wrapper serving responses from c when it can
+// and storing the cacheable ones next handlers produce
+func Middleware(c *GoCache.Cache, cfg Config) func(http.Handler) http.Handler {
+	if cfg.MaxBodyBytes == 0 {
+		cfg.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			reqCC := parseCacheControl(r.Header.Get("Cache-Control"))
+			if r.Method != http.MethodGet && r.Method != http.MethodHead || reqCC.has("no-store") {
+				next.ServeHTTP(w, r)
+				return
+			}
+			key := cacheKey(r, cfg.Headers)
+			authorized := cfg.authorized(r)
+			if !reqCC.has("no-cache") {
+				if res, found := lookup(c, key, r, authorized); found {
+					serve(w, r, res)
+					return
+				}
+			}
+			rec := &recorder{ResponseWriter: w, max: cfg.MaxBodyBytes}
+			w.Header().Set("X-Cache", "MISS")
+			next.ServeHTTP(rec, r)
+			if ttl, ok := storable(rec, cfg.TTL, authorized); ok {
+				store(c, key, r, response{Status: rec.status, Header: rec.header, Body: rec.body}, ttl)
+			}
+		})
+	}
+}
+
+// cacheKey ... Method, URL and the values of headers
+func cacheKey(r *http.Request, headers []string) string {
+	var b strings.Builder
+	b.WriteString(keyPrefix)
+	b.WriteString(r.Method)
+	b.WriteByte(' ')
+	b.WriteString(r.URL.String())
+	for _, h := range headers {
+		b.WriteByte('\n')
+		b.WriteString(h)
+		b.WriteByte(':')
+		b.WriteString(strings.Join(r.Header.Values(h), ","))
+	}
+	return b.String()
+}
+
+// authorized ... Report whether r carries credentials the key does not
+// tell apart, so only responses meant for any user may serve it
+func (cfg Config) authorized(r *http.Request) bool {
+	if r.Header.Get("Authorization") == "" {
+		return false
+	}
+	for _, h := range cfg.Headers {
+		if http.CanonicalHeaderKey(h) == "Authorization" {
+			return false
+		}
+	}
+	return true
+}
+
+// variants ... Stored at the key of a response that Varies, the sorted
+// request headers it Varies on. The response itself is stored under the
+// key of its variant
+type variants []string
+
+// lookup ... The stored response for r at key, following variants to
+// the one of r
+func lookup(c *GoCache.Cache, key string, r *http.Request, authorized bool) (response, bool) {
+	v, found := c.Get(key)
+	if names, ok := v.(variants); found && ok {
+		v, found = c.Get(variantKey(key, names, r))
+	}
+	res, ok := v.(response)
+	if !found || !ok || authorized && !shared(parseCacheControl(res.Header.Get("Cache-Control"))) {
+		return response{}, false
+	}
+	return res, true
+}
+
+// store ... Store res at key with Expiration ttl, under the key of the
+// variant of r if it Varies
+func store(c *GoCache.Cache, key string, r *http.Request, res response, ttl time.Duration) {
+	if names := varyNames(res.Header); len(names) > 0 {
+		c.Set(key, names, ttl)
+		key = variantKey(key, names, r)
+	}
+	c.Set(key, res, ttl)
+}
+
+// varyNames ... The request headers h Varies on, canonical and sorted
+func varyNames(h http.Header) variants {
+	var names variants
+	for _, v := range h.Values("Vary") {
+		for _, name := range strings.Split(v, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, http.CanonicalHeaderKey(name))
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// variantKey ... key and the values of the headers named by names in r
+func variantKey(key string, names variants, r *http.Request) string {
+	var b strings.Builder
+	b.WriteString(key)
+	b.WriteString("\nVary")
+	for _, name := range names {
+		b.WriteByte('\n')
+		b.WriteString(name)
+		b.WriteByte(':')
+		b.WriteString(strings.Join(r.Header.Values(name), ","))
+	}
+	return b.String()
+}
+
+// serve ... Write a stored response
+func serve(w http.ResponseWriter, r *http.Request, res response) {
+	for k, v := range res.Header {
+		w.Header()[k] = append([]string(nil), v...)
+	}
+	w.Header().Set("X-Cache", "HIT")
+	w.WriteHeader(res.Status)
+	if r.Method != http.MethodHead {
+		w.Write(res.Body)
+	}
+}
+
+// storable ... Return the Expiration of the response rec recorded, ok
+// is false if it must not be stored
+func storable(rec *recorder, ttl time.Duration, authorized bool) (time.Duration, bool) {
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+		rec.header = rec.Header().Clone()
+		rec.header.Del("X-Cache")
+	}
+	if rec.overflow {
+		return 0, false
+	}
+	return storableResponse(rec.status, rec.header, ttl, authorized)
+}
+
+// storableResponse ... Return the Expiration of a response with status
+// and h, ok is false if it must not be stored. authorized tells it
+// answers credentials, see Config.authorized
+func storableResponse(status int, h http.Header, ttl time.Duration, authorized bool) (time.Duration, bool) {
+	if status != http.StatusOK {
+		return 0, false
+	}
+	if h.Get("Set-Cookie") != "" || h.Get("Vary") == "*" {
+		return 0, false
+	}
+	cc := parseCacheControl(h.Get("Cache-Control"))
+	if cc.has("no-store") || cc.has("no-cache") || cc.has("private") || authorized && !shared(cc) {
+		return 0, false
+	}
+	for _, directive := range []string{"s-maxage", "max-age"} {
+		if v, ok := cc[directive]; ok {
+			secs, err := strconv.Atoi(v)
+			if err != nil || secs <= 0 {
+				return 0, false
+			}
+			return time.Duration(secs) * time.Second, true
+		}
+	}
+	return ttl, true
+}
+
+// cacheControl ... Directives of a Cache-Control header, lower cased
+type cacheControl map[string]string
+
+func parseCacheControl(header string) cacheControl {
+	cc := cacheControl{}
+	for _, part := range strings.Split(header, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, value, _ := strings.Cut(part, "=")
+		cc[strings.ToLower(strings.TrimSpace(name))] = strings.Trim(strings.TrimSpace(value), `"`)
+	}
+	return cc
+}
+
+// shared ... Report whether a response to a request with Authorization
+// may be stored and reused for others by a shared cache, RFC 9111 3.5
+func shared(cc cacheControl) bool {
+	return cc.has("public") || cc.has("s-maxage") || cc.has("must-revalidate")
+}
+
+func (cc cacheControl) has(directive string) bool {
+	_, ok := cc[directive]
+	return ok
+}
+
+// recorder ... Passes the response through and keeps a copy of it
+type recorder struct {
+	http.ResponseWriter
+	status   int
+	header   http.Header
+	body     []byte
+	max      int
+	overflow bool // The body went over max and is not kept
+}
+
+func (rec *recorder) WriteHeader(status int) {
+	if rec.status == 0 {
+		rec.status = status
+		rec.header = rec.Header().Clone()
+		rec.header.Del("X-Cache")
+	}
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *recorder) Write(b []byte) (int, error) {
+	if rec.status == 0 {
+		rec.WriteHeader(http.StatusOK)
+	}
+	if !rec.overflow {
+		if len(rec.body)+len(b) > rec.max {
+			rec.overflow, rec.body = true, nil
+		} else {
+			rec.body = append(rec.body, b...)
+		}
+	}
+	return rec.ResponseWriter.Write(b)
+}
@@ -0,0 +1,127 @@
+package httpcache
+
+import (
+	"GoCache"
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// Transport ... Return an http.RoundTripper serving GET and HEAD
+// responses of next, http.DefaultTransport if nil, from c when it can
+// and storing the cacheable ones, by the rules of Middleware
+// Concurrent misses on the same key wait for a single round trip and
+// share its response if it could be stored and served to them, by the
+// headers it Varies on and their Authorization. The others, and those
+// waiting on a body over MaxBodyBytes, which is streamed to the request
+// that made the trip, make a trip of their own
+//
+//	client := &http.Client{Transport: httpcache.Transport(c, nil, httpcache.Config{TTL: time.Minute})}
+func Transport(c *GoCache.Cache, next http.RoundTripper, cfg Config) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	if cfg.MaxBodyBytes == 0 {
+		cfg.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+	return &transport{c: c, next: next, cfg: cfg, flights: map[string]*flight{}}
+}
+
+type transport struct {
+	c       *GoCache.Cache
+	next    http.RoundTripper
+	cfg     Config
+	mutex   sync.Mutex
+	flights map[string]*flight // Round trips in progress by key
+}
+
+// flight ... A round trip others wait for, res is unset unless its
+// response was stored, for the request with variant key variant
+// GetOrCompute does not fit: whether and how long a response is stored
+// is known only once it is in
+type flight struct {
+	done    chan struct{}
+	res     *response
+	variant string
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqCC := parseCacheControl(req.Header.Get("Cache-Control"))
+	if req.Method != http.MethodGet && req.Method != http.MethodHead || reqCC.has("no-store") {
+		return t.next.RoundTrip(req)
+	}
+	key := cacheKey(req, t.cfg.Headers)
+	authorized := t.cfg.authorized(req)
+	if !reqCC.has("no-cache") {
+		if res, found := lookup(t.c, key, req, authorized); found {
+			return res.http(req, "HIT"), nil
+		}
+	}
+	t.mutex.Lock()
+	if f, ok := t.flights[key]; ok {
+		t.mutex.Unlock()
+		<-f.done
+		if f.res != nil && f.variant == variantKey(key, varyNames(f.res.Header), req) &&
+			(!authorized || shared(parseCacheControl(f.res.Header.Get("Cache-Control")))) {
+			return f.res.http(req, "MISS"), nil
+		}
+		return t.next.RoundTrip(req)
+	}
+	f := &flight{done: make(chan struct{})}
+	t.flights[key] = f
+	t.mutex.Unlock()
+	defer func() {
+		t.mutex.Lock()
+		delete(t.flights, key)
+		t.mutex.Unlock()
+		close(f.done)
+	}()
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(io.LimitReader(res.Body, int64(t.cfg.MaxBodyBytes)+1))
+	if err != nil {
+		res.Body.Close()
+		return nil, err
+	}
+	if len(body) > t.cfg.MaxBodyBytes {
+		res.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), res.Body), res.Body}
+		res.Header.Set("X-Cache", "MISS")
+		return res, nil
+	}
+	res.Body.Close()
+	header := res.Header.Clone()
+	header.Del("X-Cache")
+	stored := response{Status: res.StatusCode, Header: header, Body: body}
+	if ttl, ok := storableResponse(res.StatusCode, header, t.cfg.TTL, authorized); ok {
+		store(t.c, key, req, stored, ttl)
+		f.res, f.variant = &stored, variantKey(key, varyNames(header), req)
+	}
+	return stored.http(req, "MISS"), nil
+}
+
+// http ... The response as a client receives it, for req
+func (res response) http(req *http.Request, xCache string) *http.Response {
+	header := res.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set("X-Cache", xCache)
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", res.Status, http.StatusText(res.Status)),
+		StatusCode:    res.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(res.Body)),
+		ContentLength: int64(len(res.Body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,49 @@
+// Package metrics publishes the Stats of a GoCache through expvar,
+// so they show up on /debug/vars next to the rest of the service
+package metrics
+
+import (
+	"GoCache"
+	"expvar"
+)
+
+// Source ... Anything with Stats, a Cache or a ShardedCache
+type Source interface {
+	Stats() GoCache.Stats
+}
+
+// Publish ... Publish the Stats of c as the expvar name
+// Like expvar.Publish it panics if name is already in use
+func Publish(name string, c Source) {
+	expvar.Publish(name, expvar.Func(func() interface{} {
+		return Snapshot(c)
+	}))
+}
+
+// Snapshot ... Return the Stats of c as the map Publish exports
+func Snapshot(c Source) map[string]interface{} {
+	s := c.Stats()
+	m := map[string]interface{}{
+		"hits":                s.Hits,
+		"misses":              s.Misses,
+		"hit_ratio":           s.HitRatio(),
+		"sets":                s.Sets,
+		"expired":             s.Expired,
+		"evictions":           s.Evictions,
+		"rejected":            s.Rejected,
+		"items":               s.Items,
+		"bytes":               s.Bytes,
+		"gc_sweeps":           s.GcSweeps,
+		"gc_removed":          s.GcRemoved,
+		"gc_last_seconds":     s.LastGcDuration.Seconds(),
+		"gc_total_seconds":    s.TotalGcTime.Seconds(),
+		"gc_interval_seconds": s.GcInterval.Seconds(),
+	}
+	if s.Last1h.Span > 0 {
+		// Kept with GoCache.WithStatsWindow only
+		m["hit_ratio_1m"] = s.Last1m.HitRatio()
+		m["hit_ratio_5m"] = s.Last5m.HitRatio()
+		m["hit_ratio_1h"] = s.Last1h.HitRatio()
+	}
+	return m
+}
@@ -0,0 +1,13 @@
+// Package otelcache instruments a GoCache with OpenTelemetry: a Cache
+// wrapping a Cache or ShardedCache records a span per Get, Set, Delete
+// and Load, and metrics for operation latency and hit ratio, so the
+// cache shows up in the traces and dashboards of the service
+//
+// OpenTelemetry is a third party dependency GoCache does not otherwise
+// need, so the package is only built with the otel build tag:
+//
+//	go build -tags otel
+//
+// and needs go.opentelemetry.io/otel, its metric and trace modules, in
+// the go.mod of the service
+package otelcache
@@ -0,0 +1,202 @@
+//go:build otel
+
+package otelcache
+
+import (
+	"GoCache"
+	"context"
+	"io"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName ... Instrumentation scope of the spans and metrics
+const ScopeName = "GoCache/otelcache"
+
+// Target ... What a Cache instruments, a GoCache.Cache or
+// GoCache.ShardedCache
+type Target interface {
+	Get(k string) (interface{}, bool)
+	Set(k string, v interface{}, d time.Duration, opts ...GoCache.SetOption)
+	Delete(k string)
+	Load(r io.Reader) error
+	Stats() GoCache.Stats
+}
+
+// Option ... Configure New
+type Option func(*config)
+
+type config struct {
+	name           string
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	recordKeys     bool
+}
+
+// WithName ... Tell this cache apart from others in the service, set as
+// the cache.name attribute of every span and metric
+func WithName(name string) Option {
+	return func(c *config) { c.name = name }
+}
+
+// WithTracerProvider ... Use tp instead of the global TracerProvider
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) { c.tracerProvider = tp }
+}
+
+// WithMeterProvider ... Use mp instead of the global MeterProvider
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) { c.meterProvider = mp }
+}
+
+// WithKeys ... Record the key as the cache.key attribute of spans
+// Off by default, keys may be personal data or many
+func WithKeys() Option {
+	return func(c *config) { c.recordKeys = true }
+}
+
+// Cache ... A Target whose operations are traced and measured
+// The operations take the context carrying the span of the caller, so
+// their spans become its children
+type Cache struct {
+	target     Target
+	tracer     trace.Tracer
+	attrs      []attribute.KeyValue
+	recordKeys bool
+	duration   metric.Float64Histogram
+	requests   metric.Int64Counter
+	ratio      metric.Registration
+}
+
+// New ... Instrument target, failing only if the meter rejects the
+// metrics
+// Besides the per operation metrics it reports the hit ratio and item
+// count of the Stats of target, see Close
+func New(target Target, opts ...Option) (*Cache, error) {
+	cfg := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	c := &Cache{
+		target:     target,
+		tracer:     cfg.tracerProvider.Tracer(ScopeName),
+		recordKeys: cfg.recordKeys,
+	}
+	if cfg.name != "" {
+		c.attrs = []attribute.KeyValue{attribute.String("cache.name", cfg.name)}
+	}
+	meter := cfg.meterProvider.Meter(ScopeName)
+	var err error
+	if c.duration, err = meter.Float64Histogram("gocache.operation.duration",
+		metric.WithDescription("Duration of cache operations"),
+		metric.WithUnit("s")); err != nil {
+		return nil, err
+	}
+	if c.requests, err = meter.Int64Counter("gocache.requests",
+		metric.WithDescription("Get calls, by result hit or miss")); err != nil {
+		return nil, err
+	}
+	hitRatio, err := meter.Float64ObservableGauge("gocache.hit_ratio",
+		metric.WithDescription("Hits over Gets since the cache was made"))
+	if err != nil {
+		return nil, err
+	}
+	items, err := meter.Int64ObservableGauge("gocache.items",
+		metric.WithDescription("Data in the cache"))
+	if err != nil {
+		return nil, err
+	}
+	set := metric.WithAttributes(c.attrs...)
+	c.ratio, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		s := target.Stats()
+		o.ObserveFloat64(hitRatio, s.HitRatio(), set)
+		o.ObserveInt64(items, int64(s.Items), set)
+		return nil
+	}, hitRatio, items)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Close ... Stop reporting the Stats of the target, which is left open
+func (c *Cache) Close() error {
+	return c.ratio.Unregister()
+}
+
+// Target ... Return the instrumented cache, to reach what Cache does not
+// wrap
+func (c *Cache) Target() Target {
+	return c.target
+}
+
+// start ... Start the span of operation op on k, "" for none
+func (c *Cache) start(ctx context.Context, op, k string) (context.Context, trace.Span, time.Time) {
+	attrs := append([]attribute.KeyValue{
+		attribute.String("db.system", "gocache"),
+		attribute.String("db.operation", op),
+	}, c.attrs...)
+	if c.recordKeys && k != "" {
+		attrs = append(attrs, attribute.String("cache.key", k))
+	}
+	ctx, span := c.tracer.Start(ctx, "GoCache."+op,
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(attrs...))
+	return ctx, span, time.Now()
+}
+
+// end ... End the span of op, recording its duration and err
+func (c *Cache) end(ctx context.Context, span trace.Span, op string, start time.Time, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+	attrs := append([]attribute.KeyValue{attribute.String("operation", op)}, c.attrs...)
+	c.duration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attrs...))
+}
+
+// Get ... Get the Data, in a span marked with cache.hit
+func (c *Cache) Get(ctx context.Context, k string) (interface{}, bool) {
+	ctx, span, start := c.start(ctx, "Get", k)
+	v, found := c.target.Get(k)
+	span.SetAttributes(attribute.Bool("cache.hit", found))
+	result := "miss"
+	if found {
+		result = "hit"
+	}
+	attrs := append([]attribute.KeyValue{attribute.String("result", result)}, c.attrs...)
+	c.requests.Add(ctx, 1, metric.WithAttributes(attrs...))
+	c.end(ctx, span, "Get", start, nil)
+	return v, found
+}
+
+// Set ... Set the Data with Expiration d, in a span
+func (c *Cache) Set(ctx context.Context, k string, v interface{}, d time.Duration, opts ...GoCache.SetOption) {
+	ctx, span, start := c.start(ctx, "Set", k)
+	c.target.Set(k, v, d, opts...)
+	c.end(ctx, span, "Set", start, nil)
+}
+
+// Delete ... Delete the Data, in a span
+func (c *Cache) Delete(ctx context.Context, k string) {
+	ctx, span, start := c.start(ctx, "Delete", k)
+	c.target.Delete(k)
+	c.end(ctx, span, "Delete", start, nil)
+}
+
+// Load ... Load a snapshot from r, in a span holding its error
+func (c *Cache) Load(ctx context.Context, r io.Reader) error {
+	ctx, span, start := c.start(ctx, "Load", "")
+	err := c.target.Load(r)
+	c.end(ctx, span, "Load", start, err)
+	return err
+}
@@ -0,0 +1,274 @@
+// Package replication keeps a follower Cache a warm standby of a leader
+// Cache over TCP, so the follower can take over with its Data in place
+//
+// A follower connects and names the leader and SnapshotID it last
+// applied. The leader answers with the Diff since then, or a Reset with
+// all its Data for a new follower or one it cannot serve a Diff to, and
+// then streams a Diff after every change it sees through Watch, and an
+// empty one each Heartbeat. Sets and Deletes are replicated as they
+// happen; Expirations travel with the Data, the follower Expires it on
+// its own. Frames are gob encoded, so values of types of your own must
+// be registered with gob.Register on both sides, as for SaveToFile
+//
+//	go replication.NewLeader(leader).Serve(ln)
+//	go replication.NewFollower(standby).Run(ctx, "tcp", addr)
+package replication
+
+import (
+	"GoCache"
+	"context"
+	"crypto/rand"
+	"encoding/gob"
+	"encoding/hex"
+	"net"
+	"sync"
+	"time"
+)
+
+// DefaultHeartbeat ... Time between the frames of an idle Leader
+const DefaultHeartbeat = 5 * time.Second
+
+// Backoff bounds of Follower.Run between failed connections
+const (
+	minRetry = 100 * time.Millisecond
+	maxRetry = 5 * time.Second
+)
+
+// Source ... What a Leader replicates, *GoCache.Cache or
+// *GoCache.ShardedCache
+type Source interface {
+	Snapshot() (map[string]GoCache.Item, GoCache.SnapshotID)
+	Diff(since GoCache.SnapshotID) GoCache.Diff
+	Watch(prefix string) (<-chan GoCache.Event, GoCache.CancelFunc)
+}
+
+// Target ... What a Follower applies the Diffs to, *GoCache.Cache or
+// *GoCache.ShardedCache
+type Target interface {
+	ApplyDiff(d GoCache.Diff)
+}
+
+// hello ... First frame of a follower: the leader and SnapshotID it is at,
+// empty for a new one
+type hello struct {
+	Leader string
+	Since  GoCache.SnapshotID
+}
+
+// frame ... What a Leader sends
+type frame struct {
+	Leader string
+	Diff   GoCache.Diff
+}
+
+// Leader ... Streams the changes of a Source to its followers. Heartbeat
+// and ResetTimeout may be changed before Serve
+type Leader struct {
+	Heartbeat time.Duration
+	// ResetTimeout ... Longest time to write the first frame to a
+	// follower, no limit if zero. A Reset carries all the Data, so it
+	// grows with the Cache where Heartbeat bounds the frames after it
+	ResetTimeout time.Duration
+	src          Source
+	id           string // Followers of another Leader, or of this one restarted, start over
+	done         chan struct{}
+	closeOnce    sync.Once
+}
+
+// NewLeader ... Create the Leader of src
+func NewLeader(src Source) *Leader {
+	var b [16]byte
+	rand.Read(b[:])
+	return &Leader{
+		Heartbeat: DefaultHeartbeat,
+		src:       src,
+		id:        hex.EncodeToString(b[:]),
+		done:      make(chan struct{}),
+	}
+}
+
+// Serve ... Stream to the followers accepted on ln until ln or the Leader
+// is closed, Return the error that ended it
+func (l *Leader) Serve(ln net.Listener) error {
+	go func() {
+		<-l.done
+		ln.Close()
+	}()
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			select {
+			case <-l.done:
+				return nil
+			default:
+				return err
+			}
+		}
+		go l.ServeConn(conn)
+	}
+}
+
+// ServeConn ... Stream to the follower on conn until it goes away or the
+// Leader is closed, then close conn
+func (l *Leader) ServeConn(conn net.Conn) error {
+	defer conn.Close()
+	heartbeat := l.Heartbeat
+	if heartbeat <= 0 {
+		heartbeat = DefaultHeartbeat
+	}
+	var h hello
+	conn.SetReadDeadline(time.Now().Add(heartbeat))
+	if err := gob.NewDecoder(conn).Decode(&h); err != nil {
+		return err
+	}
+	// Watch before the first Diff, so no change falls between them
+	events, cancel := l.src.Watch("")
+	defer cancel()
+	var d GoCache.Diff
+	if h.Leader == l.id {
+		d = l.src.Diff(h.Since)
+	} else {
+		items, id := l.src.Snapshot()
+		d = GoCache.Diff{To: id, Reset: true, Set: items}
+	}
+	enc := gob.NewEncoder(conn)
+	conn.SetWriteDeadline(deadline(l.ResetTimeout))
+	if err := enc.Encode(frame{Leader: l.id, Diff: d}); err != nil {
+		return err
+	}
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-events:
+			// One Diff covers all the changes queued so far
+			for len(events) > 0 {
+				<-events
+			}
+		case <-ticker.C:
+		case <-l.done:
+			return nil
+		}
+		d = l.src.Diff(d.To)
+		conn.SetWriteDeadline(time.Now().Add(heartbeat))
+		if err := enc.Encode(frame{Leader: l.id, Diff: d}); err != nil {
+			return err
+		}
+	}
+}
+
+// deadline ... The deadline d from now, none if d is not positive
+func deadline(d time.Duration) time.Time {
+	if d <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(d)
+}
+
+// Close ... Stop Serve and every ServeConn
+func (l *Leader) Close() {
+	l.closeOnce.Do(func() { close(l.done) })
+}
+
+// Follower ... Applies the Diffs of a Leader to a Target, keeping track
+// of where it is so a new connection carries on from there
+type Follower struct {
+	// Timeout ... Longest wait for a frame before the Leader is taken
+	// for gone, three Heartbeats if zero
+	Timeout time.Duration
+	// ResetTimeout ... Longest wait for the first frame, which may be a
+	// Reset with all the Data of the Leader, no limit if zero
+	ResetTimeout time.Duration
+	target       Target
+	mutex        sync.Mutex
+	leader       string
+	at           GoCache.SnapshotID
+}
+
+// NewFollower ... Create a Follower applying to t
+func NewFollower(t Target) *Follower {
+	return &Follower{target: t}
+}
+
+// Version ... Return the SnapshotID of the Leader applied last, to watch
+// the lag of the Follower
+func (f *Follower) Version() GoCache.SnapshotID {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.at
+}
+
+// Follow ... Apply the Diffs the Leader sends on conn until it fails or
+// ctx is done, then close conn
+func (f *Follower) Follow(ctx context.Context, conn net.Conn) error {
+	defer conn.Close()
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+	timeout := f.Timeout
+	if timeout <= 0 {
+		timeout = 3 * DefaultHeartbeat
+	}
+	f.mutex.Lock()
+	h := hello{Leader: f.leader, Since: f.at}
+	f.mutex.Unlock()
+	conn.SetWriteDeadline(time.Now().Add(timeout))
+	if err := gob.NewEncoder(conn).Encode(h); err != nil {
+		return f.failed(ctx, err)
+	}
+	dec := gob.NewDecoder(conn)
+	conn.SetReadDeadline(deadline(f.ResetTimeout))
+	for {
+		var fr frame
+		if err := dec.Decode(&fr); err != nil {
+			return f.failed(ctx, err)
+		}
+		f.target.ApplyDiff(fr.Diff)
+		f.mutex.Lock()
+		f.leader, f.at = fr.Leader, fr.Diff.To
+		f.mutex.Unlock()
+		conn.SetReadDeadline(time.Now().Add(timeout))
+	}
+}
+
+// failed ... The error ending Follow, ctx.Err() if ctx closed conn
+func (f *Follower) failed(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// Run ... Follow the Leader at addr, connecting again with a growing
+// pause whenever the connection fails, until ctx is done
+func (f *Follower) Run(ctx context.Context, network, addr string) error {
+	var dialer net.Dialer
+	retry := minRetry
+	for {
+		conn, err := dialer.DialContext(ctx, network, addr)
+		if err == nil {
+			start := time.Now()
+			f.Follow(ctx, conn)
+			if time.Since(start) > maxRetry {
+				// It was up for a while, try again right away
+				retry = minRetry
+			}
+		}
+		t := time.NewTimer(retry)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+		if retry *= 2; retry > maxRetry {
+			retry = maxRetry
+		}
+	}
+}
@@ -0,0 +1,447 @@
+// Package server serves a GoCache over the Redis protocol (RESP), so
+// the cache can run as a small daemon any Redis client can talk to
+//
+// Supported commands: PING, GET, SET (with EX, PX, NX and XX), DEL,
+// EXISTS, EXPIRE, PEXPIRE, TTL, PTTL, INCR, INCRBY, DECR, DECRBY,
+// DBSIZE and FLUSHALL. Values are stored as strings
+package server
+
+import (
+	"GoCache"
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Server ... Serves one Cache to any number of connections
+type Server struct {
+	cache     *GoCache.Cache
+	mutex     sync.Mutex
+	listeners map[net.Listener]bool
+	conns     map[net.Conn]bool
+	closed    bool
+}
+
+// New ... Create a Server for c
+func New(c *GoCache.Cache) *Server {
+	return &Server{
+		cache:     c,
+		listeners: map[net.Listener]bool{},
+		conns:     map[net.Conn]bool{},
+	}
+}
+
+// ErrServerClosed ... Returned by Serve and ListenAndServe after Close
+var ErrServerClosed = errors.New("server: closed")
+
+// ListenAndServe ... Listen on the TCP address addr and Serve it
+func (s *Server) ListenAndServe(addr string) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(l)
+}
+
+// Serve ... Accept connections on l until Close, each served by its
+// own goRoutine
+func (s *Server) Serve(l net.Listener) error {
+	s.mutex.Lock()
+	if s.closed {
+		s.mutex.Unlock()
+		l.Close()
+		return ErrServerClosed
+	}
+	s.listeners[l] = true
+	s.mutex.Unlock()
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			s.mutex.Lock()
+			closed := s.closed
+			delete(s.listeners, l)
+			s.mutex.Unlock()
+			if closed {
+				return ErrServerClosed
+			}
+			return err
+		}
+		s.mutex.Lock()
+		if s.closed {
+			s.mutex.Unlock()
+			conn.Close()
+			return ErrServerClosed
+		}
+		s.conns[conn] = true
+		s.mutex.Unlock()
+		go s.serveConn(conn)
+	}
+}
+
+// Close ... Stop the listeners and close every connection, the Cache
+// itself is left open
+func (s *Server) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.closed = true
+	for l := range s.listeners {
+		l.Close()
+	}
+	for conn := range s.conns {
+		conn.Close()
+	}
+	return nil
+}
+
+func (s *Server) serveConn(conn net.Conn) {
+	defer func() {
+		// A bad command only costs its own connection, not the daemon
+		if err := recover(); err != nil {
+			log.Printf("server: panic serving %v: %v", conn.RemoteAddr(), err)
+		}
+		conn.Close()
+		s.mutex.Lock()
+		delete(s.conns, conn)
+		s.mutex.Unlock()
+	}()
+	r := bufio.NewReader(conn)
+	w := bufio.NewWriter(conn)
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			if err != io.EOF {
+				writeError(w, err.Error())
+				w.Flush()
+			}
+			return
+		}
+		if len(args) == 0 {
+			continue
+		}
+		if strings.EqualFold(args[0], "QUIT") {
+			writeSimple(w, "OK")
+			w.Flush()
+			return
+		}
+		s.execute(w, args)
+		// Only flush once the pipelined commands already read are answered
+		if r.Buffered() == 0 {
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readCommand ... Read a RESP array of bulk strings, or an inline
+// command of words separated by spaces
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 || line[0] != '*' {
+		return strings.Fields(line), nil
+	}
+	n, err := strconv.Atoi(line[1:])
+	if err != nil || n > 1024*1024 {
+		return nil, fmt.Errorf("Protocol error: invalid multibulk length")
+	}
+	if n <= 0 {
+		// A null or empty array, as Redis skips it
+		return nil, nil
+	}
+	args := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		line, err := readLine(r)
+		if err != nil {
+			return nil, err
+		}
+		if len(line) == 0 || line[0] != '$' {
+			return nil, fmt.Errorf("Protocol error: expected '$', got '%s'", line)
+		}
+		size, err := strconv.Atoi(line[1:])
+		if err != nil || size < 0 || size > 512*1024*1024 {
+			return nil, fmt.Errorf("Protocol error: invalid bulk length")
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		args = append(args, string(buf[:size]))
+	}
+	return args, nil
+}
+
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		if err == io.EOF && line != "" {
+			err = io.ErrUnexpectedEOF
+		}
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func writeSimple(w *bufio.Writer, s string) {
+	w.WriteString("+" + s + "\r\n")
+}
+
+func writeError(w *bufio.Writer, s string) {
+	w.WriteString("-ERR " + s + "\r\n")
+}
+
+func writeInt(w *bufio.Writer, n int64) {
+	w.WriteString(":" + strconv.FormatInt(n, 10) + "\r\n")
+}
+
+func writeBulk(w *bufio.Writer, s string) {
+	w.WriteString("$" + strconv.Itoa(len(s)) + "\r\n" + s + "\r\n")
+}
+
+func writeNil(w *bufio.Writer) {
+	w.WriteString("$-1\r\n")
+}
+
+// execute ... Run one command and write its reply
+func (s *Server) execute(w *bufio.Writer, args []string) {
+	cmd := strings.ToUpper(args[0])
+	args = args[1:]
+	wrongArgs := func() {
+		writeError(w, fmt.Sprintf("wrong number of arguments for '%s' command", strings.ToLower(cmd)))
+	}
+	c := s.cache
+	switch cmd {
+	case "PING":
+		switch len(args) {
+		case 0:
+			writeSimple(w, "PONG")
+		case 1:
+			writeBulk(w, args[0])
+		default:
+			wrongArgs()
+		}
+	case "GET":
+		if len(args) != 1 {
+			wrongArgs()
+			return
+		}
+		v, found := c.Get(args[0])
+		if !found {
+			writeNil(w)
+			return
+		}
+		writeBulk(w, toString(v))
+	case "SET":
+		if len(args) < 2 {
+			wrongArgs()
+			return
+		}
+		s.set(w, args[0], args[1], args[2:])
+	case "DEL", "EXISTS":
+		if len(args) == 0 {
+			wrongArgs()
+			return
+		}
+		var n int64
+		for _, k := range args {
+			if _, found := c.Get(k); found {
+				n++
+				if cmd == "DEL" {
+					c.Delete(k)
+				}
+			}
+		}
+		writeInt(w, n)
+	case "EXPIRE", "PEXPIRE":
+		if len(args) != 2 {
+			wrongArgs()
+			return
+		}
+		n, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			writeError(w, "value is not an integer or out of range")
+			return
+		}
+		unit := time.Second
+		if cmd == "PEXPIRE" {
+			unit = time.Millisecond
+		}
+		if n > math.MaxInt64/int64(unit) || n < math.MinInt64/int64(unit) {
+			writeError(w, fmt.Sprintf("invalid expire time in '%s' command", strings.ToLower(cmd)))
+			return
+		}
+		d := time.Duration(n) * unit
+		if d <= 0 {
+			if _, found := c.Get(args[0]); found {
+				c.Delete(args[0])
+				writeInt(w, 1)
+			} else {
+				writeInt(w, 0)
+			}
+			return
+		}
+		if c.Touch(args[0], d) {
+			writeInt(w, 1)
+		} else {
+			writeInt(w, 0)
+		}
+	case "TTL", "PTTL":
+		if len(args) != 1 {
+			wrongArgs()
+			return
+		}
+		_, exp, found := c.GetWithExpiration(args[0])
+		switch {
+		case !found:
+			writeInt(w, -2)
+		case exp.IsZero():
+			writeInt(w, -1)
+		case cmd == "TTL":
+			writeInt(w, int64((time.Until(exp)+time.Second-1)/time.Second))
+		default:
+			writeInt(w, time.Until(exp).Milliseconds())
+		}
+	case "INCR", "DECR", "INCRBY", "DECRBY":
+		by := int64(1)
+		switch {
+		case (cmd == "INCR" || cmd == "DECR") && len(args) != 1,
+			(cmd == "INCRBY" || cmd == "DECRBY") && len(args) != 2:
+			wrongArgs()
+			return
+		case len(args) == 2:
+			n, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				writeError(w, "value is not an integer or out of range")
+				return
+			}
+			by = n
+		}
+		if strings.HasPrefix(cmd, "DECR") {
+			if by == math.MinInt64 {
+				writeError(w, errOverflow.Error())
+				return
+			}
+			by = -by
+		}
+		n, err := s.incr(args[0], by)
+		if err != nil {
+			writeError(w, err.Error())
+			return
+		}
+		writeInt(w, n)
+	case "DBSIZE":
+		writeInt(w, int64(c.Count()))
+	case "FLUSHALL", "FLUSHDB":
+		c.Flush()
+		writeSimple(w, "OK")
+	default:
+		writeError(w, fmt.Sprintf("unknown command '%s'", strings.ToLower(cmd)))
+	}
+}
+
+// set ... SET k v [EX seconds|PX milliseconds] [NX|XX]
+func (s *Server) set(w *bufio.Writer, k, v string, opts []string) {
+	d := GoCache.NoExpiration
+	nx, xx := false, false
+	for i := 0; i < len(opts); i++ {
+		switch opt := strings.ToUpper(opts[i]); opt {
+		case "NX":
+			nx = true
+		case "XX":
+			xx = true
+		case "EX", "PX":
+			if i+1 == len(opts) {
+				writeError(w, "syntax error")
+				return
+			}
+			i++
+			unit := time.Second
+			if opt == "PX" {
+				unit = time.Millisecond
+			}
+			n, err := strconv.ParseInt(opts[i], 10, 64)
+			if err != nil || n <= 0 || n > math.MaxInt64/int64(unit) {
+				writeError(w, "invalid expire time in 'set' command")
+				return
+			}
+			d = time.Duration(n) * unit
+		default:
+			writeError(w, "syntax error")
+			return
+		}
+	}
+	var err error
+	switch {
+	case nx && xx:
+		writeError(w, "syntax error")
+		return
+	case nx:
+		err = s.cache.Add(k, v, d)
+	case xx:
+		err = s.cache.Replace(k, v, d)
+	default:
+		s.cache.Set(k, v, d)
+	}
+	if err != nil {
+		writeNil(w)
+		return
+	}
+	writeSimple(w, "OK")
+}
+
+// incr ... Add by to the integer stored as a string at k, keeping its
+// Expiration, a missing k counts as 0
+func (s *Server) incr(k string, by int64) (int64, error) {
+	var n int64
+	_, err := s.cache.Modify(k, GoCache.NoExpiration, func(v interface{}, found bool) (interface{}, error) {
+		n = 0
+		if found {
+			str, ok := v.(string)
+			if !ok {
+				return nil, errNotInteger
+			}
+			var err error
+			if n, err = strconv.ParseInt(str, 10, 64); err != nil {
+				return nil, errNotInteger
+			}
+		}
+		if by > 0 && n > math.MaxInt64-by || by < 0 && n < math.MinInt64-by {
+			return nil, errOverflow
+		}
+		n += by
+		return strconv.FormatInt(n, 10), nil
+	})
+	switch {
+	case errors.Is(err, errNotInteger):
+		return 0, errNotInteger
+	case errors.Is(err, errOverflow):
+		return 0, errOverflow
+	}
+	return n, err
+}
+
+var (
+	errNotInteger = errors.New("value is not an integer or out of range")
+	errOverflow   = errors.New("increment or decrement would overflow")
+)
+
+// toString ... Format Data set through the Go API for a reply
+func toString(v interface{}) string {
+	switch x := v.(type) {
+	case string:
+		return x
+	case []byte:
+		return string(x)
+	}
+	return fmt.Sprint(v)
+}
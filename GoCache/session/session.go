@@ -0,0 +1,143 @@
+// Package session keeps net/http sessions in a GoCache: the cookie only
+// carries a random ID, the values stay in the cache and Expire after
+// MaxAge without a request, every load pushing that out again
+//
+//	store := session.NewStore(c, 30*time.Minute)
+//	sess, _ := store.Get(r)
+//	sess.Values["user"] = id
+//	store.Save(w, sess)
+package session
+
+import (
+	"GoCache"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// DefaultCookieName ... Cookie NewStore uses
+const DefaultCookieName = "session"
+
+// keyPrefix ... Prefix of the cache keys of sessions, so they can share
+// a cache with other Data
+const keyPrefix = "session:"
+
+// ErrNoSession ... Returned by Save for a Session that was Destroyed
+var ErrNoSession = errors.New("session: destroyed")
+
+// Session ... The values of one client, read by Store.Get
+type Session struct {
+	ID     string
+	Values map[string]interface{}
+	// IsNew ... The request carried no live session, Save sets the cookie
+	IsNew     bool
+	destroyed bool
+}
+
+// Store ... Sessions kept in a Cache. The cookie fields may be changed
+// before the Store is used
+type Store struct {
+	cache  *GoCache.Cache
+	MaxAge time.Duration // Sessions Expire this long after their last use
+
+	CookieName string
+	Path       string
+	Domain     string
+	Secure     bool
+	HTTPOnly   bool
+	SameSite   http.SameSite
+}
+
+// NewStore ... Create a Store keeping sessions in c for maxAge after
+// their last use, with HTTPOnly, SameSite=Lax cookies on path /
+func NewStore(c *GoCache.Cache, maxAge time.Duration) *Store {
+	return &Store{
+		cache:      c,
+		MaxAge:     maxAge,
+		CookieName: DefaultCookieName,
+		Path:       "/",
+		HTTPOnly:   true,
+		SameSite:   http.SameSiteLaxMode,
+	}
+}
+
+// Get ... Return the Session of r, or a New empty one if r has none or
+// it Expired. Loading it pushes its Expiration out by MaxAge
+func (s *Store) Get(r *http.Request) (*Session, error) {
+	if cookie, err := r.Cookie(s.CookieName); err == nil {
+		if v, found := s.cache.Get(keyPrefix + cookie.Value); found {
+			if values, ok := v.(map[string]interface{}); ok {
+				return &Session{ID: cookie.Value, Values: copyValues(values)}, nil
+			}
+		}
+	}
+	id, err := newID()
+	if err != nil {
+		return nil, err
+	}
+	return &Session{ID: id, Values: map[string]interface{}{}, IsNew: true}, nil
+}
+
+// Save ... Store the values of sess and send its cookie
+func (s *Store) Save(w http.ResponseWriter, sess *Session) error {
+	if sess.destroyed {
+		return ErrNoSession
+	}
+	s.cache.SetSliding(keyPrefix+sess.ID, copyValues(sess.Values), s.MaxAge)
+	http.SetCookie(w, s.cookie(sess.ID, int(s.MaxAge/time.Second)))
+	sess.IsNew = false
+	return nil
+}
+
+// Regenerate ... Move sess to a New ID, dropping the old one, as done on
+// login to defeat session fixation. Save sends the New cookie
+func (s *Store) Regenerate(sess *Session) error {
+	id, err := newID()
+	if err != nil {
+		return err
+	}
+	s.cache.Delete(keyPrefix + sess.ID)
+	sess.ID = id
+	sess.IsNew = true
+	return nil
+}
+
+// Destroy ... Delete sess and expire its cookie
+func (s *Store) Destroy(w http.ResponseWriter, sess *Session) {
+	s.cache.Delete(keyPrefix + sess.ID)
+	http.SetCookie(w, s.cookie("", -1))
+	sess.destroyed = true
+}
+
+func (s *Store) cookie(value string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     s.CookieName,
+		Value:    value,
+		Path:     s.Path,
+		Domain:   s.Domain,
+		MaxAge:   maxAge,
+		Secure:   s.Secure,
+		HttpOnly: s.HTTPOnly,
+		SameSite: s.SameSite,
+	}
+}
+
+// newID ... Return 256 random bits from crypto/rand, URL safe
+func newID() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// copyValues ... So a Session does not share its map with the cache
+func copyValues(values map[string]interface{}) map[string]interface{} {
+	res := make(map[string]interface{}, len(values))
+	for k, v := range values {
+		res[k] = v
+	}
+	return res
+}